@@ -0,0 +1,151 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestEmailVerificationHandler emails the current user a new email verification link.
+func RequestEmailVerificationHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RequestEmailVerificationRequest{UserId: ctx.GetString("user_id")}
+
+	if _, err := userClient.Client.RequestEmailVerification(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.JSONResponse{Data: "Verification email sent"})
+}
+
+type confirmEmailVerificationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ConfirmEmailVerificationHandler verifies an email address using the token from a verification link.
+// Tokens issued before carry the old verification status until they are refreshed.
+func ConfirmEmailVerificationHandler(ctx *gin.Context) {
+	var req confirmEmailVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ConfirmEmailVerificationRequest{Token: req.Token}
+
+	if _, err := userClient.Client.ConfirmEmailVerification(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "Email address verified"})
+}
+
+type requestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// RequestPasswordResetHandler emails a password reset link. It responds the same whether or not
+// an account with the email address exists.
+func RequestPasswordResetHandler(ctx *gin.Context) {
+	var req requestPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RequestPasswordResetRequest{Email: req.Email}
+
+	if _, err := userClient.Client.RequestPasswordReset(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.JSONResponse{Data: "If an account exists for this email address, a password reset email was sent"})
+}
+
+type confirmPasswordResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ConfirmPasswordResetHandler sets a new password using the token from a password reset link.
+// Every session of the user is signed out.
+func ConfirmPasswordResetHandler(ctx *gin.Context) {
+	var req confirmPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ConfirmPasswordResetRequest{
+		Token:    req.Token,
+		Password: req.Password,
+	}
+
+	if _, err := userClient.Client.ConfirmPasswordReset(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	clearAuthCookies(ctx)
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "Password reset, please log in again"})
+}
+
+type unlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UnlockAccountHandler lifts an account lockout using the token from the link emailed when it was locked.
+func UnlockAccountHandler(ctx *gin.Context) {
+	var req unlockAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	if _, err := userClient.Client.UnlockAccount(ctx, &pbu.UnlockAccountRequest{Token: req.Token}); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "Account unlocked, you can log in again"})
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type createAlertSubscriptionRequest struct {
+	Name     string              `json:"name" binding:"required"`
+	Center   *types.Coordinates  `json:"center"`
+	Radius   int32               `json:"radius"` // meters, with center
+	Polygon  []types.Coordinates `json:"polygon"`
+	Hazards  []string            `json:"hazards"`
+	Channels []string            `json:"channels"`
+}
+
+// CreateAlertSubscriptionHandler subscribes the current user to alerts for disasters approved inside an area,
+// given either as a center and radius or as a polygon.
+func CreateAlertSubscriptionHandler(ctx *gin.Context) {
+	var req createAlertSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	sub := &pbu.AlertSubscription{
+		Name:     req.Name,
+		Radius:   req.Radius,
+		Hazards:  req.Hazards,
+		Channels: req.Channels,
+	}
+	if req.Center != nil {
+		sub.Center = &pbu.Point{Latitude: req.Center.Latitude, Longitude: req.Center.Longitude}
+	}
+	for _, c := range req.Polygon {
+		sub.Polygon = append(sub.Polygon, &pbu.Point{Latitude: c.Latitude, Longitude: c.Longitude})
+	}
+
+	pbReq := &pbu.CreateAlertSubscriptionRequest{UserId: ctx.GetString("user_id"), Subscription: sub}
+
+	pbRes, err := userClient.Client.CreateAlertSubscription(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toAlertSubscription(pbRes)})
+}
+
+// ListAlertSubscriptionsHandler lists the alert subscriptions of the current user.
+func ListAlertSubscriptionsHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListAlertSubscriptionsRequest{UserId: ctx.GetString("user_id")}
+
+	pbRes, err := userClient.Client.ListAlertSubscriptions(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	subs := make([]*types.AlertSubscription, 0, len(pbRes.GetSubscriptions()))
+	for _, s := range pbRes.GetSubscriptions() {
+		subs = append(subs, toAlertSubscription(s))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: subs})
+}
+
+// DeleteAlertSubscriptionHandler deletes an alert subscription of the current user.
+func DeleteAlertSubscriptionHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.DeleteAlertSubscriptionRequest{UserId: ctx.GetString("user_id"), Id: ctx.Param("id")}
+
+	if _, err := userClient.Client.DeleteAlertSubscription(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "Alert subscription deleted"})
+}
+
+func toAlertSubscription(s *pbu.AlertSubscription) *types.AlertSubscription {
+	id, _ := bson.ObjectIDFromHex(s.GetId())
+	userID, _ := bson.ObjectIDFromHex(s.GetUserId())
+	sub := &types.AlertSubscription{
+		ID:        id,
+		UserID:    userID,
+		Name:      s.GetName(),
+		Radius:    int(s.GetRadius()),
+		Hazards:   s.GetHazards(),
+		Channels:  s.GetChannels(),
+		CreatedAt: s.GetCreatedAt().AsTime(),
+	}
+	if s.GetCenter() != nil {
+		sub.Center = &types.Coordinates{Latitude: s.GetCenter().GetLatitude(), Longitude: s.GetCenter().GetLongitude()}
+	}
+	for _, p := range s.GetPolygon() {
+		sub.Polygon = append(sub.Polygon, types.Coordinates{Latitude: p.GetLatitude(), Longitude: p.GetLongitude()})
+	}
+	return sub
+}
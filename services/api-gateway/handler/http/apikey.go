@@ -0,0 +1,146 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type createAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"required"`
+	Permissions []string   `json:"permissions" binding:"required,min=1"`
+	RateLimit   int32      `json:"rate_limit" binding:"omitempty,min=1"` // requests per minute, 0 for the default
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type apiKey struct {
+	ID          string     `json:"id"`
+	OrgID       string     `json:"org_id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Key         string     `json:"key,omitempty"` // only set when the key is created
+	Permissions []string   `json:"permissions"`
+	RateLimit   int32      `json:"rate_limit"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyHandler creates an API key for an organization's scripts. Org admins only.
+// The key is returned once and can't be retrieved again.
+func CreateAPIKeyHandler(ctx *gin.Context) {
+	var req createAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.CreateAPIKeyRequest{
+		ActorId:     ctx.GetString("user_id"),
+		OrgId:       ctx.Param("id"),
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		RateLimit:   req.RateLimit,
+	}
+	if req.ExpiresAt != nil {
+		pbReq.ExpiresAt = timestamppb.New(*req.ExpiresAt)
+	}
+
+	pbRes, err := userClient.Client.CreateAPIKey(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toAPIKey(pbRes)})
+}
+
+// ListAPIKeysHandler lists the API keys of an organization, including revoked and expired ones. Org admins only.
+func ListAPIKeysHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListAPIKeysRequest{ActorId: ctx.GetString("user_id"), OrgId: ctx.Param("id")}
+
+	pbRes, err := userClient.Client.ListAPIKeys(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	keys := make([]*apiKey, 0, len(pbRes.GetKeys()))
+	for _, k := range pbRes.GetKeys() {
+		keys = append(keys, toAPIKey(k))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: keys})
+}
+
+// RevokeAPIKeyHandler revokes an API key of an organization. Org admins only.
+func RevokeAPIKeyHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RevokeAPIKeyRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		Id:      ctx.Param("key_id"),
+	}
+
+	pbRes, err := userClient.Client.RevokeAPIKey(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toAPIKey(pbRes)})
+}
+
+func toAPIKey(k *pbu.APIKey) *apiKey {
+	key := &apiKey{
+		ID:          k.GetId(),
+		OrgID:       k.GetOrgId(),
+		Name:        k.GetName(),
+		Prefix:      k.GetPrefix(),
+		Key:         k.GetKey(),
+		Permissions: k.GetPermissions(),
+		RateLimit:   k.GetRateLimit(),
+		CreatedBy:   k.GetCreatedBy(),
+		CreatedAt:   k.GetCreatedAt().AsTime(),
+	}
+	if k.GetExpiresAt() != nil {
+		expiresAt := k.GetExpiresAt().AsTime()
+		key.ExpiresAt = &expiresAt
+	}
+	if k.GetLastUsedAt() != nil {
+		lastUsedAt := k.GetLastUsedAt().AsTime()
+		key.LastUsedAt = &lastUsedAt
+	}
+	if k.GetRevokedAt() != nil {
+		revokedAt := k.GetRevokedAt().AsTime()
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
@@ -1,12 +1,12 @@
 package http
 
 import (
-	"log"
 	"net/http"
+	"slices"
 
 	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/services/api-gateway/middleware"
 	pbd "github.com/cprakhar/relief-ops/shared/proto/disaster"
-	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
 	"github.com/cprakhar/relief-ops/shared/response"
 	"github.com/cprakhar/relief-ops/shared/types"
 	"github.com/gin-gonic/gin"
@@ -26,10 +26,26 @@ func ReportDisasterHandler(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if req.Severity != "" && !slices.Contains(types.SeverityLevels, req.Severity) {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "severity must be one of low, medium, high or critical"})
+		return
+	}
+
+	// API keys report for their organization, users only for organizations they are a member of
+	if keyOrgID := ctx.GetString("api_key_org_id"); keyOrgID != "" {
+		if req.OrgID != "" && req.OrgID != keyOrgID {
+			ctx.JSON(http.StatusForbidden, response.JSONResponse{Error: "API key belongs to another organization"})
+			return
+		}
+		req.OrgID = keyOrgID
+	} else if req.OrgID != "" && !requireOrgMember(ctx, req.OrgID) {
+		return
+	}
 
 	disasterClient, err := grpcclient.NewDisasterServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer disasterClient.Close()
 
@@ -40,6 +56,8 @@ func ReportDisasterHandler(ctx *gin.Context) {
 		Location:    &pbd.Coordinates{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
 		VolunteerID: userID,
 		ImageURLs:   req.ImageURLs,
+		OrgID:       req.OrgID,
+		Severity:    req.Severity,
 	}
 
 	pbRes, err := disasterClient.Client.ReportDisaster(ctx, pbReq)
@@ -59,27 +77,43 @@ func ReportDisasterHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: responseData})
 }
 
+// ReviewDisasterHandler approves or rejects a reported disaster. Admins scoped to regions may only review
+// disasters inside their regions.
 func ReviewDisasterHandler(ctx *gin.Context) {
-	adminID := ctx.GetString("user_id")
+	principal := middleware.Principal(ctx)
 
 	disasterID := ctx.Param("id")
 	decision := ctx.Query("decision") // expected values: "approve" or "reject"
 
+	var reviewStatus string
+	switch decision {
+	case "approve":
+		reviewStatus = types.DisasterApproved
+	case "reject":
+		reviewStatus = types.DisasterRejected
+	default:
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "decision must be approve or reject"})
+		return
+	}
+
 	disasterClient, err := grpcclient.NewDisasterServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer disasterClient.Close()
 
 	pbReq := &pbd.ReviewDisasterRequest{
-		Id:      disasterID,
-		AdminID: adminID,
-		Status:  decision,
+		Id:           disasterID,
+		AdminID:      principal.UserID,
+		Status:       reviewStatus,
+		AdminRole:    string(principal.Role),
+		AdminRegions: toPbRegions(principal.Regions),
 	}
 
 	_, err = disasterClient.Client.ReviewDisaster(ctx, pbReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: err.Error()})
+		grpcError(ctx, err)
 		return
 	}
 
@@ -90,7 +124,8 @@ func ReviewDisasterHandler(ctx *gin.Context) {
 func GetAllDisastersHandler(ctx *gin.Context) {
 	disasterClient, err := grpcclient.NewDisasterServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer disasterClient.Close()
 
@@ -105,23 +140,7 @@ func GetAllDisastersHandler(ctx *gin.Context) {
 
 	var disasters []types.Disaster
 	for _, d := range pbRes.GetDisasters() {
-		oid, _ := bson.ObjectIDFromHex(d.GetId())
-		disaster := types.Disaster{
-			ID:          oid,
-			Title:       d.GetTitle(),
-			Description: d.GetDescription(),
-			Tags:        d.GetTags(),
-			VolunteerID: d.GetVolunteerID(),
-			CreatedAt:   d.GetCreatedAt().AsTime(),
-			UpdatedAt:   d.GetUpdatedAt().AsTime(),
-			ImageURLs:   d.GetImageURLs(),
-			Location: types.Coordinates{
-				Latitude:  d.GetLocation().GetLatitude(),
-				Longitude: d.GetLocation().GetLongitude(),
-			},
-			Status: d.GetStatus(),
-		}
-		disasters = append(disasters, disaster)
+		disasters = append(disasters, *toDisaster(d))
 	}
 
 	ctx.JSON(http.StatusOK, response.JSONResponse{Data: disasters})
@@ -133,7 +152,8 @@ func GetDisasterHandler(ctx *gin.Context) {
 
 	disasterClient, err := grpcclient.NewDisasterServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer disasterClient.Close()
 
@@ -144,32 +164,32 @@ func GetDisasterHandler(ctx *gin.Context) {
 		return
 	}
 
-	oid, _ := bson.ObjectIDFromHex(pbRes.GetId())
-	disaster := &types.Disaster{
-		ID:          oid,
-		Title:       pbRes.GetTitle(),
-		Description: pbRes.GetDescription(),
-		Tags:        pbRes.GetTags(),
-		VolunteerID: pbRes.GetVolunteerID(),
-		CreatedAt:   pbRes.GetCreatedAt().AsTime(),
-		UpdatedAt:   pbRes.GetUpdatedAt().AsTime(),
-		ImageURLs:   pbRes.GetImageURLs(),
-		Location: types.Coordinates{
-			Latitude:  pbRes.GetLocation().GetLatitude(),
-			Longitude: pbRes.GetLocation().GetLongitude(),
-		},
-		Status: pbRes.GetStatus(),
-	}
+	disaster := toDisaster(pbRes)
 
 	ctx.JSON(http.StatusOK, response.JSONResponse{Data: disaster})
 }
 
+// GetDisasterWithResourcesHandler retrieves a disaster along with nearby resources, nearest first,
+// optionally filtered by category and paginated.
 func GetDisasterWithResourcesHandler(ctx *gin.Context) {
 	disasterID := ctx.Param("id")
 
+	var page resourcePageQuery
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	categories, err := parseCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
 	disasterClient, err := grpcclient.NewDisasterServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer disasterClient.Close()
 
@@ -180,54 +200,23 @@ func GetDisasterWithResourcesHandler(ctx *gin.Context) {
 		return
 	}
 
-	oid, _ := bson.ObjectIDFromHex(pbRes.GetId())
-	disaster := &types.Disaster{
-		ID:          oid,
-		Title:       pbRes.GetTitle(),
-		Description: pbRes.GetDescription(),
-		Tags:        pbRes.GetTags(),
-		VolunteerID: pbRes.GetVolunteerID(),
-		CreatedAt:   pbRes.GetCreatedAt().AsTime(),
-		UpdatedAt:   pbRes.GetUpdatedAt().AsTime(),
-		ImageURLs:   pbRes.GetImageURLs(),
-		Location: types.Coordinates{
-			Latitude:  pbRes.GetLocation().GetLatitude(),
-			Longitude: pbRes.GetLocation().GetLongitude(),
-		},
-		Status: pbRes.GetStatus(),
-	}
+	disaster := toDisaster(pbRes)
 
 	resourceClient, err := grpcclient.NewResourceServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer resourceClient.Close()
 
-	resourcesPbRes, err := resourceClient.Client.GetNearbyResources(ctx, &pbr.GetResourcesRequest{Location: &pbr.Coordinates{
-		Latitude:  disaster.Location.Latitude,
-		Longitude: disaster.Location.Longitude,
-	}, Within: 10000}) // 10 km range
+	pbResourcesReq := newResourcesRequest(disaster.Location.Latitude, disaster.Location.Longitude, &page, categories)
+	resourcesPbRes, err := resourceClient.Client.GetNearbyResources(ctx, pbResourcesReq)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: err.Error()})
 		return
 	}
 
-	var resources []types.Resource
-	for _, r := range resourcesPbRes.GetResources() {
-		oid, _ := bson.ObjectIDFromHex(r.GetId())
-		resource := types.Resource{
-			ID:          oid,
-			Name:        r.GetName(),
-			AmenityType: r.GetAmenityType(),
-			Location: &types.Location{
-				Coordinates: []float64{
-					r.GetLocation().Longitude,
-					r.GetLocation().Latitude,
-				},
-			},
-		}
-		resources = append(resources, resource)
-	}
+	resources := toResources(resourcesPbRes.GetResources())
 
 	responseData := struct {
 		Disaster  *types.Disaster  `json:"disaster"`
@@ -239,3 +228,57 @@ func GetDisasterWithResourcesHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response.JSONResponse{Data: responseData})
 }
+
+// toPbRegions converts a principal's scoped regions to their protobuf representation.
+func toPbRegions(regions []types.Region) []*pbd.Region {
+	var pbRegions []*pbd.Region
+	for _, r := range regions {
+		pbRegion := &pbd.Region{Name: r.Name}
+		for _, c := range r.Polygon {
+			pbRegion.Polygon = append(pbRegion.Polygon, &pbd.Coordinates{Latitude: c.Latitude, Longitude: c.Longitude})
+		}
+		pbRegions = append(pbRegions, pbRegion)
+	}
+	return pbRegions
+}
+
+// toDisaster converts a protobuf disaster to its JSON representation.
+func toDisaster(d *pbd.GetDisasterResponse) *types.Disaster {
+	oid, _ := bson.ObjectIDFromHex(d.GetId())
+	disaster := &types.Disaster{
+		ID:          oid,
+		Title:       d.GetTitle(),
+		Description: d.GetDescription(),
+		Tags:        d.GetTags(),
+		VolunteerID: d.GetVolunteerID(),
+		CreatedAt:   d.GetCreatedAt().AsTime(),
+		UpdatedAt:   d.GetUpdatedAt().AsTime(),
+		ImageURLs:   d.GetImageURLs(),
+		Location: types.Coordinates{
+			Latitude:  d.GetLocation().GetLatitude(),
+			Longitude: d.GetLocation().GetLongitude(),
+		},
+		Status:         d.GetStatus(),
+		ResourceCounts: d.GetResourceCounts(),
+		SearchRadius:   int(d.GetSearchRadius()),
+		OrgID:          d.GetOrgID(),
+		Severity:       d.GetSeverity(),
+	}
+	if d.GetResourcesFoundAt() != nil {
+		disaster.ResourcesFoundAt = d.GetResourcesFoundAt().AsTime()
+	}
+
+	for _, r := range d.GetResources() {
+		disaster.Resources = append(disaster.Resources, types.ResourceSnapshot{
+			ID:       r.GetId(),
+			Name:     r.GetName(),
+			Category: r.GetType(),
+			Location: types.Coordinates{
+				Latitude:  r.GetLocation().GetLatitude(),
+				Longitude: r.GetLocation().GetLongitude(),
+			},
+			Distance: r.GetDistance(),
+		})
+	}
+	return disaster
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/services/api-gateway/middleware"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	pbd "github.com/cprakhar/relief-ops/shared/proto/disaster"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type assignDispatchRequest struct {
+	AssigneeType string `json:"assignee_type" binding:"required,oneof=volunteer resource team"`
+	AssigneeID   string `json:"assignee_id" binding:"required"`
+	OrgID        string `json:"org_id" binding:"required_if=AssigneeType team"` // the team's organization
+	Notes        string `json:"notes"`
+}
+
+type updateAssignmentStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=acknowledged en_route on_scene released"`
+}
+
+// AssignDispatchHandler dispatches a volunteer, mobile resource or organization team to an approved disaster.
+func AssignDispatchHandler(ctx *gin.Context) {
+	principal := middleware.Principal(ctx)
+	disasterID := ctx.Param("id")
+
+	var req assignDispatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	// Resolve the assignee so only real volunteers, resources and teams are dispatched
+	var assigneeName string
+	switch req.AssigneeType {
+	case types.AssigneeVolunteer:
+		userClient, err := grpcclient.NewUserServiceClient()
+		if err != nil {
+			unavailable(ctx, err)
+			return
+		}
+		defer userClient.Close()
+
+		user, err := userClient.Client.GetUser(ctx, &pbu.GetUserRequest{Id: req.AssigneeID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Unknown volunteer"})
+				return
+			}
+			grpcError(ctx, err)
+			return
+		}
+		if types.Role(user.GetRole()) != types.RoleVolunteer {
+			ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Assignee is not a volunteer"})
+			return
+		}
+		assigneeName = user.GetName()
+	case types.AssigneeResource:
+		resourceClient, err := grpcclient.NewResourceServiceClient()
+		if err != nil {
+			unavailable(ctx, err)
+			return
+		}
+		defer resourceClient.Close()
+
+		resource, err := resourceClient.Client.GetResource(ctx, &pbr.GetResourceRequest{Id: req.AssigneeID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Unknown resource"})
+				return
+			}
+			grpcError(ctx, err)
+			return
+		}
+		assigneeName = resource.GetName()
+	case types.AssigneeTeam:
+		userClient, err := grpcclient.NewUserServiceClient()
+		if err != nil {
+			unavailable(ctx, err)
+			return
+		}
+		defer userClient.Close()
+
+		team, err := userClient.Client.GetTeam(ctx, &pbu.GetTeamRequest{OrgId: req.OrgID, TeamId: req.AssigneeID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Unknown team"})
+				return
+			}
+			grpcError(ctx, err)
+			return
+		}
+		assigneeName = team.GetName()
+	}
+
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer disasterClient.Close()
+
+	pbReq := &pbd.AssignDispatchRequest{
+		DisasterID:    disasterID,
+		AssigneeType:  req.AssigneeType,
+		AssigneeID:    req.AssigneeID,
+		AssigneeName:  assigneeName,
+		AssigneeOrgID: req.OrgID,
+		Notes:         req.Notes,
+		AdminID:       principal.UserID,
+		AdminRole:     string(principal.Role),
+		AdminRegions:  toPbRegions(principal.Regions),
+	}
+
+	pbRes, err := disasterClient.Client.AssignDispatch(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toAssignment(pbRes)})
+}
+
+// UpdateAssignmentStatusHandler records an acknowledgement or progress update on an assignment.
+func UpdateAssignmentStatusHandler(ctx *gin.Context) {
+	principal := middleware.Principal(ctx)
+	assignmentID := ctx.Param("id")
+
+	var req updateAssignmentStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	teamIDs, err := teamIDsOf(ctx, principal.UserID)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer disasterClient.Close()
+
+	pbReq := &pbd.UpdateAssignmentStatusRequest{
+		Id:           assignmentID,
+		Status:       req.Status,
+		ActorID:      principal.UserID,
+		ActorRole:    string(principal.Role),
+		ActorRegions: toPbRegions(principal.Regions),
+		ActorTeamIDs: teamIDs,
+	}
+
+	pbRes, err := disasterClient.Client.UpdateAssignmentStatus(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toAssignment(pbRes)})
+}
+
+// GetDispatchBoardHandler shows the assignments of a disaster grouped by their current status.
+func GetDispatchBoardHandler(ctx *gin.Context) {
+	disasterID := ctx.Param("id")
+
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer disasterClient.Close()
+
+	pbRes, err := disasterClient.Client.GetDispatchBoard(ctx, &pbd.GetDispatchBoardRequest{DisasterID: disasterID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	board := map[string][]*types.Assignment{
+		types.DispatchAssigned:     {},
+		types.DispatchAcknowledged: {},
+		types.DispatchEnRoute:      {},
+		types.DispatchOnScene:      {},
+		types.DispatchReleased:     {},
+	}
+	for _, a := range pbRes.GetAssignments() {
+		board[a.GetStatus()] = append(board[a.GetStatus()], toAssignment(a))
+	}
+
+	responseData := struct {
+		DisasterID string                         `json:"disaster_id"`
+		Board      map[string][]*types.Assignment `json:"board"`
+	}{
+		DisasterID: disasterID,
+		Board:      board,
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: responseData})
+}
+
+// ListMyAssignmentsHandler lists the current user's dispatch assignments, including those of the teams they are on.
+func ListMyAssignmentsHandler(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	teamIDs, err := teamIDsOf(ctx, userID)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer disasterClient.Close()
+
+	pbReq := &pbd.ListAssignmentsRequest{
+		AssigneeType: types.AssigneeVolunteer,
+		AssigneeID:   userID,
+		TeamIDs:      teamIDs,
+		ActiveOnly:   ctx.Query("active") == "true",
+	}
+
+	pbRes, err := disasterClient.Client.ListAssignments(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	var assignments []*types.Assignment
+	for _, a := range pbRes.GetAssignments() {
+		assignments = append(assignments, toAssignment(a))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: assignments})
+}
+
+// teamIDsOf lists the teams a user is on across their organizations.
+func teamIDsOf(ctx *gin.Context, userID string) ([]string, error) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		logs.L().Errorw("Failed to create gRPC client", "service", "user", "error", err)
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+	defer userClient.Close()
+
+	pbRes, err := userClient.Client.ListOrganizations(ctx, &pbu.ListOrganizationsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	var teamIDs []string
+	for _, m := range pbRes.GetMemberships() {
+		teamIDs = append(teamIDs, m.GetMembership().GetTeamIds()...)
+	}
+	return teamIDs, nil
+}
+
+// toAssignment converts a protobuf assignment to its JSON representation.
+func toAssignment(a *pbd.Assignment) *types.Assignment {
+	oid, _ := bson.ObjectIDFromHex(a.GetId())
+
+	var history []types.AssignmentProgress
+	for _, p := range a.GetHistory() {
+		history = append(history, types.AssignmentProgress{
+			Status:    p.GetStatus(),
+			ChangedBy: p.GetChangedBy(),
+			ChangedAt: p.GetChangedAt().AsTime(),
+		})
+	}
+
+	return &types.Assignment{
+		ID:            oid,
+		DisasterID:    a.GetDisasterID(),
+		AssigneeType:  a.GetAssigneeType(),
+		AssigneeID:    a.GetAssigneeID(),
+		AssigneeName:  a.GetAssigneeName(),
+		AssigneeOrgID: a.GetAssigneeOrgID(),
+		Status:        a.GetStatus(),
+		Active:        a.GetActive(),
+		Notes:         a.GetNotes(),
+		AssignedBy:    a.GetAssignedBy(),
+		History:       history,
+		CreatedAt:     a.GetCreatedAt().AsTime(),
+		UpdatedAt:     a.GetUpdatedAt().AsTime(),
+	}
+}
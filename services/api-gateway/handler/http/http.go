@@ -1,20 +1,41 @@
 package http
 
 import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cprakhar/relief-ops/services/api-gateway/middleware"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/observe/traces"
 	"github.com/cprakhar/relief-ops/shared/response"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// NewHttpHandler sets up the HTTP routes and returns a Gin engine.
-func NewHttpHandler(webURLs string) *gin.Engine {
+// NewHttpHandler sets up the HTTP routes and returns a Gin engine. Client IP addresses are only taken from
+// X-Forwarded-For when the request comes through one of the comma-separated trusted proxies, so clients
+// can't pick their own address to dodge per-IP limits.
+func NewHttpHandler(webURLs, trustedProxies string) (*gin.Engine, error) {
 	r := gin.Default()
 
+	var proxies []string
+	for _, p := range strings.Split(trustedProxies, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowAllOrigins:  false,
 		AllowOrigins:     strings.Split(webURLs, ","),
@@ -26,27 +47,150 @@ func NewHttpHandler(webURLs string) *gin.Engine {
 
 	r.Use(traces.GinTracingMiddleware("api-gateway"))
 
+	// Token signing keys, for verifying tokens issued by relief-ops
+	r.GET("/.well-known/jwks.json", JWKSHandler)
+
 	apiGroup := r.Group("/api")
 	// Health check endpoint
 	apiGroup.GET("/health", HealthCheckHandler)
 
 	// Admin endpoints
-	apiGroup.POST("/admin/review/:id", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, ReviewDisasterHandler)
+	apiGroup.POST("/admin/review/:id", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.ReviewDisasters), ReviewDisasterHandler)
+	apiGroup.GET("/admin/roads/blocked", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, ListBlockedRoadsHandler)
+	apiGroup.POST("/admin/roads/:way_id/block", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, BlockRoadHandler)
+	apiGroup.DELETE("/admin/roads/:way_id/block", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, UnblockRoadHandler)
+	apiGroup.POST("/admin/resources", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, CreateResourceHandler)
+	apiGroup.PUT("/admin/resources/:id/inventory", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, SetInventoryHandler)
+	apiGroup.GET("/admin/regions", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, ListRefreshRegionsHandler)
+	apiGroup.POST("/admin/regions", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, WatchRegionHandler)
+	apiGroup.DELETE("/admin/regions/:id", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, UnwatchRegionHandler)
+	apiGroup.PUT("/admin/users/:id/role", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.ManageUsers), SetUserRoleHandler)
+	apiGroup.GET("/admin/users/:id/role-changes", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.ManageUsers), ListRoleChangesHandler)
+	apiGroup.POST("/admin/invites", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.ManageUsers), CreateInviteHandler)
+	apiGroup.POST("/admin/regions/:id/refresh", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, TriggerRefreshHandler)
 
 	// User endpoints
 	apiGroup.POST("/auth/signup", RegisterUserHandler)
 	apiGroup.POST("/auth/login", LoginUserHandler)
 	apiGroup.POST("/auth/oauth/signin", OAuthSignInHandler)
 	apiGroup.POST("/auth/oauth/callback", OAuthCallbackHandler)
+	apiGroup.POST("/auth/refresh", RefreshTokenHandler)
 	apiGroup.POST("/auth/logout", LogoutUserHandler)
+	apiGroup.POST("/auth/verify-email/request", middleware.JWTAuthMiddleware, RequestEmailVerificationHandler)
+	apiGroup.POST("/auth/verify-email/confirm", ConfirmEmailVerificationHandler)
+	apiGroup.POST("/auth/password-reset/request", RequestPasswordResetHandler)
+	apiGroup.POST("/auth/password-reset/confirm", ConfirmPasswordResetHandler)
+	apiGroup.POST("/auth/unlock", UnlockAccountHandler)
+	apiGroup.POST("/auth/mfa/verify", VerifyMFALoginHandler)
+	apiGroup.POST("/auth/mfa/enroll", middleware.JWTAuthMiddleware, BeginMFAEnrollmentHandler)
+	apiGroup.POST("/auth/mfa/enroll/confirm", middleware.JWTAuthMiddleware, ConfirmMFAEnrollmentHandler)
+	apiGroup.POST("/auth/mfa/disable", middleware.JWTAuthMiddleware, DisableMFAHandler)
+	apiGroup.POST("/auth/mfa/recovery-codes", middleware.JWTAuthMiddleware, RegenerateRecoveryCodesHandler)
 	apiGroup.GET("/users/me", middleware.JWTAuthMiddleware, GetCurrentUserHandler)
+	apiGroup.GET("/users/me/volunteer-profile", middleware.JWTAuthMiddleware, GetMyVolunteerProfileHandler)
+	apiGroup.PUT("/users/me/volunteer-profile", middleware.JWTAuthMiddleware, UpdateMyVolunteerProfileHandler)
+	apiGroup.GET("/users/me/orgs", middleware.JWTAuthMiddleware, ListMyOrganizationsHandler)
+	apiGroup.GET("/users/me/alerts", middleware.JWTAuthMiddleware, ListAlertSubscriptionsHandler)
+	apiGroup.POST("/users/me/alerts", middleware.JWTAuthMiddleware, middleware.VerifiedEmailMiddleware, CreateAlertSubscriptionHandler)
+	apiGroup.DELETE("/users/me/alerts/:id", middleware.JWTAuthMiddleware, DeleteAlertSubscriptionHandler)
+	apiGroup.GET("/users/me/notifications", middleware.JWTAuthMiddleware, GetNotificationSettingsHandler)
+	apiGroup.PUT("/users/me/notifications", middleware.JWTAuthMiddleware, UpdateNotificationSettingsHandler)
+	apiGroup.GET("/users/me/notifications/deliveries", middleware.JWTAuthMiddleware, ListDeliveriesHandler)
+
+	// Volunteer endpoints
+	apiGroup.GET("/volunteers/vocabulary", GetVolunteerVocabularyHandler)
+	apiGroup.GET("/volunteers", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.Dispatch), FindVolunteersHandler)
+	apiGroup.GET("/volunteers/:id", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.Dispatch), GetVolunteerProfileHandler)
+
+	// Organization endpoints
+	apiGroup.POST("/orgs", middleware.JWTAuthMiddleware, middleware.VerifiedEmailMiddleware, CreateOrganizationHandler)
+	apiGroup.POST("/orgs/join", middleware.JWTAuthMiddleware, AcceptOrgInviteHandler)
+	apiGroup.GET("/orgs/:id", GetOrganizationHandler)
+	apiGroup.GET("/orgs/:id/members", middleware.JWTAuthMiddleware, ListOrgMembersHandler)
+	apiGroup.POST("/orgs/:id/invites", middleware.JWTAuthMiddleware, InviteOrgMemberHandler)
+	apiGroup.PUT("/orgs/:id/members/:user_id/role", middleware.JWTAuthMiddleware, SetOrgMemberRoleHandler)
+	apiGroup.DELETE("/orgs/:id/members/:user_id", middleware.JWTAuthMiddleware, RemoveOrgMemberHandler)
+	apiGroup.GET("/orgs/:id/teams", middleware.JWTAuthMiddleware, ListTeamsHandler)
+	apiGroup.POST("/orgs/:id/teams", middleware.JWTAuthMiddleware, CreateTeamHandler)
+	apiGroup.PUT("/orgs/:id/teams/:team_id/members/:user_id", middleware.JWTAuthMiddleware, AddTeamMemberHandler)
+	apiGroup.DELETE("/orgs/:id/teams/:team_id/members/:user_id", middleware.JWTAuthMiddleware, RemoveTeamMemberHandler)
+	apiGroup.GET("/orgs/:id/api-keys", middleware.JWTAuthMiddleware, ListAPIKeysHandler)
+	apiGroup.POST("/orgs/:id/api-keys", middleware.JWTAuthMiddleware, CreateAPIKeyHandler)
+	apiGroup.DELETE("/orgs/:id/api-keys/:key_id", middleware.JWTAuthMiddleware, RevokeAPIKeyHandler)
 
 	// Disaster endpoints
-	apiGroup.POST("/disasters", middleware.JWTAuthMiddleware, ReportDisasterHandler)
-	apiGroup.GET("/disasters", GetAllDisastersHandler)
-	apiGroup.GET("/disasters/:id", GetDisasterHandler)
+	apiGroup.POST("/disasters", middleware.APIKeyAuthMiddleware(authz.ReportDisasters, middleware.JWTAuthMiddleware), middleware.VerifiedEmailMiddleware, ReportDisasterHandler)
+	apiGroup.GET("/disasters", middleware.APIKeyAuthMiddleware(authz.ReadDisasters, nil), GetAllDisastersHandler)
+	apiGroup.GET("/disasters/:id", middleware.APIKeyAuthMiddleware(authz.ReadDisasters, nil), GetDisasterHandler)
 	apiGroup.GET("/disasters/:id/resources", GetDisasterWithResourcesHandler)
-	return r
+	apiGroup.GET("/disasters/:id/needs", ListNeedsHandler)
+	apiGroup.POST("/disasters/:id/needs", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, DeclareNeedHandler)
+	apiGroup.GET("/disasters/:id/needs/matches", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, MatchNeedsHandler)
+	apiGroup.POST("/disasters/:id/needs/:need_id/commit", middleware.JWTAuthMiddleware, middleware.AdminOnlyMiddleware, CommitSupplyHandler)
+	apiGroup.GET("/disasters/:id/dispatch", middleware.JWTAuthMiddleware, GetDispatchBoardHandler)
+	apiGroup.POST("/disasters/:id/dispatch", middleware.JWTAuthMiddleware, middleware.RequirePermission(authz.Dispatch), AssignDispatchHandler)
+
+	// Dispatch endpoints
+	apiGroup.GET("/dispatch/assignments/me", middleware.JWTAuthMiddleware, ListMyAssignmentsHandler)
+	apiGroup.POST("/dispatch/assignments/:id/status", middleware.JWTAuthMiddleware, UpdateAssignmentStatusHandler)
+
+	// Resource endpoints
+	apiGroup.GET("/resources/categories", GetResourceCategoriesHandler)
+	apiGroup.GET("/resources/nearby", GetNearbyResourcesHandler)
+	apiGroup.GET("/resources/ranked", GetRankedResourcesHandler)
+
+	// Map tile endpoints
+	apiGroup.GET("/tiles/:layer/:z/:x/:y", GetTileHandler)
+	return r, nil
+}
+
+// grpcError responds with the HTTP status matching the gRPC status of a failed call.
+// Throttled calls get a Retry-After header when the status says when to retry. Internal errors are
+// logged and answered with a generic message, so database and service details don't leak to clients.
+func grpcError(ctx *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.FailedPrecondition, codes.AlreadyExists, codes.Aborted:
+		code = http.StatusConflict
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+		if retryAfter, ok := retryDelay(err); ok {
+			// Round up, so clients don't retry a moment too early
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	default:
+		logs.L().Errorw("Request failed", "method", ctx.Request.Method, "path", ctx.FullPath(), "error", err)
+		ctx.JSON(code, response.JSONResponse{Error: "internal server error"})
+		return
+	}
+	ctx.JSON(code, response.JSONResponse{Error: status.Convert(err).Message()})
+}
+
+// unavailable responds with 503 when a client for a backend service can't be created, so one unreachable
+// service fails its requests rather than taking the gateway down.
+func unavailable(ctx *gin.Context, err error) {
+	logs.L().Errorw("Failed to create gRPC client", "method", ctx.Request.Method, "path", ctx.FullPath(), "error", err)
+	ctx.JSON(http.StatusServiceUnavailable, response.JSONResponse{Error: "service unavailable"})
+}
+
+// retryDelay returns the delay of the RetryInfo detail of a gRPC status error, if any.
+func retryDelay(err error) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
 }
 
 // HealthCheckHandler responds with a simple status message.
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/gin-gonic/gin"
+)
+
+type mfaChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func toMFAChallengeResponse(pbRes *pbu.LoginUserResponse) *mfaChallengeResponse {
+	return &mfaChallengeResponse{
+		MFARequired: true,
+		MFAToken:    pbRes.GetMfaToken(),
+		ExpiresIn:   pbRes.GetMfaExpiresIn(),
+	}
+}
+
+type verifyMFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// VerifyMFALoginHandler completes a login that required MFA, given the challenge token and a TOTP or recovery code.
+func VerifyMFALoginHandler(ctx *gin.Context) {
+	var req verifyMFALoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.VerifyMfaLoginRequest{MfaToken: req.MFAToken, Code: req.Code}
+
+	pbRes, err := userClient.Client.VerifyMfaLogin(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	setAuthCookies(ctx, pbRes)
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toLoginResponse(pbRes)})
+}
+
+type mfaEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// BeginMFAEnrollmentHandler generates a TOTP secret for the current user. MFA is enabled once a code is confirmed.
+func BeginMFAEnrollmentHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.BeginMfaEnrollmentRequest{UserId: ctx.GetString("user_id")}
+
+	pbRes, err := userClient.Client.BeginMfaEnrollment(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: &mfaEnrollmentResponse{
+		Secret:          pbRes.GetSecret(),
+		ProvisioningURI: pbRes.GetProvisioningUri(),
+	}})
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmMFAEnrollmentHandler enables MFA for the current user and returns their recovery codes.
+// Recovery codes are only ever shown here and when regenerated.
+func ConfirmMFAEnrollmentHandler(ctx *gin.Context) {
+	var req mfaCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ConfirmMfaEnrollmentRequest{UserId: ctx.GetString("user_id"), Code: req.Code}
+
+	pbRes, err := userClient.Client.ConfirmMfaEnrollment(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: &recoveryCodesResponse{RecoveryCodes: pbRes.GetCodes()}})
+}
+
+// DisableMFAHandler turns off MFA for the current user, given a TOTP or recovery code. Every session of the
+// user is signed out, so the auth cookies are cleared.
+func DisableMFAHandler(ctx *gin.Context) {
+	var req mfaCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.DisableMfaRequest{UserId: ctx.GetString("user_id"), Code: req.Code}
+
+	if _, err := userClient.Client.DisableMfa(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	clearAuthCookies(ctx)
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "MFA disabled"})
+}
+
+// RegenerateRecoveryCodesHandler replaces the current user's recovery codes, given a TOTP or recovery code.
+func RegenerateRecoveryCodesHandler(ctx *gin.Context) {
+	var req mfaCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RegenerateRecoveryCodesRequest{UserId: ctx.GetString("user_id"), Code: req.Code}
+
+	pbRes, err := userClient.Client.RegenerateRecoveryCodes(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: &recoveryCodesResponse{RecoveryCodes: pbRes.GetCodes()}})
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type deliveriesQuery struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// GetNotificationSettingsHandler retrieves how the current user wants to be notified.
+func GetNotificationSettingsHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.GetNotificationSettingsRequest{UserId: ctx.GetString("user_id")}
+
+	pbRes, err := userClient.Client.GetNotificationSettings(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toNotificationSettings(pbRes)})
+}
+
+// UpdateNotificationSettingsHandler replaces how the current user wants to be notified.
+func UpdateNotificationSettingsHandler(ctx *gin.Context) {
+	var req types.NotificationSettings
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.UpdateNotificationSettingsRequest{
+		UserId: ctx.GetString("user_id"),
+		Settings: &pbu.NotificationSettings{
+			Channels:       req.Channels,
+			Phone:          req.Phone,
+			PushTokens:     req.PushTokens,
+			WebhookUrl:     req.WebhookURL,
+			Locale:         req.Locale,
+			ReportDelivery: req.ReportDelivery,
+		},
+	}
+
+	pbRes, err := userClient.Client.UpdateNotificationSettings(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toNotificationSettings(pbRes)})
+}
+
+// ListDeliveriesHandler lists the most recent notifications sent to the current user, with their delivery status.
+func ListDeliveriesHandler(ctx *gin.Context) {
+	var query deliveriesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListDeliveriesRequest{UserId: ctx.GetString("user_id"), Limit: query.Limit}
+
+	pbRes, err := userClient.Client.ListDeliveries(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	deliveries := make([]*types.Delivery, 0, len(pbRes.GetDeliveries()))
+	for _, d := range pbRes.GetDeliveries() {
+		id, _ := bson.ObjectIDFromHex(d.GetId())
+		userID, _ := bson.ObjectIDFromHex(d.GetUserId())
+		delivery := &types.Delivery{
+			ID:        id,
+			UserID:    userID,
+			Kind:      d.GetKind(),
+			Status:    d.GetStatus(),
+			Channel:   d.GetChannel(),
+			Attempts:  []types.DeliveryAttempt{},
+			CreatedAt: d.GetCreatedAt().AsTime(),
+			UpdatedAt: d.GetUpdatedAt().AsTime(),
+		}
+		for _, a := range d.GetAttempts() {
+			delivery.Attempts = append(delivery.Attempts, types.DeliveryAttempt{
+				Channel: a.GetChannel(),
+				Error:   a.GetError(),
+				At:      a.GetAt().AsTime(),
+			})
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: deliveries})
+}
+
+func toNotificationSettings(s *pbu.NotificationSettings) *types.NotificationSettings {
+	return &types.NotificationSettings{
+		Channels:       s.GetChannels(),
+		Phone:          s.GetPhone(),
+		PushTokens:     s.GetPushTokens(),
+		WebhookURL:     s.GetWebhookUrl(),
+		Locale:         s.GetLocale(),
+		ReportDelivery: s.GetReportDelivery(),
+	}
+}
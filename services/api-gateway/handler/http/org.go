@@ -0,0 +1,443 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type createOrganizationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type inviteOrgMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type acceptOrgInviteRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type setOrgMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type createTeamRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type organization struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type orgMember struct {
+	OrgID    string    `json:"org_id"`
+	UserID   string    `json:"user_id"`
+	Name     string    `json:"name,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Role     string    `json:"role"`
+	TeamIDs  []string  `json:"team_ids"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type team struct {
+	ID          string    `json:"id"`
+	OrgID       string    `json:"org_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateOrganizationHandler creates an organization with the current user as its first org admin.
+func CreateOrganizationHandler(ctx *gin.Context) {
+	var req createOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.CreateOrganizationRequest{
+		ActorId:     ctx.GetString("user_id"),
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	pbRes, err := userClient.Client.CreateOrganization(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toOrganization(pbRes)})
+}
+
+// GetOrganizationHandler retrieves an organization by ID.
+func GetOrganizationHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbRes, err := userClient.Client.GetOrganization(ctx, &pbu.GetOrganizationRequest{Id: ctx.Param("id")})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toOrganization(pbRes)})
+}
+
+// ListMyOrganizationsHandler lists the organizations the current user belongs to, with the user's membership.
+func ListMyOrganizationsHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbRes, err := userClient.Client.ListOrganizations(ctx, &pbu.ListOrganizationsRequest{UserId: ctx.GetString("user_id")})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	type orgMembership struct {
+		Organization *organization `json:"organization"`
+		Membership   *orgMember    `json:"membership"`
+	}
+	memberships := make([]orgMembership, 0, len(pbRes.GetMemberships()))
+	for _, m := range pbRes.GetMemberships() {
+		memberships = append(memberships, orgMembership{
+			Organization: toOrganization(m.GetOrganization()),
+			Membership:   toOrgMember(m.GetMembership()),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: memberships})
+}
+
+// ListOrgMembersHandler lists the members of an organization, or of one of its teams with team_id=.
+// Only members of the organization may list them.
+func ListOrgMembersHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListOrgMembersRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		TeamId:  ctx.Query("team_id"),
+	}
+
+	pbRes, err := userClient.Client.ListOrgMembers(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	members := make([]*orgMember, 0, len(pbRes.GetMembers()))
+	for _, m := range pbRes.GetMembers() {
+		members = append(members, toOrgMember(m))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: members})
+}
+
+// InviteOrgMemberHandler invites an email address to join an organization. Org admins only.
+// The invite code is emailed to the invitee and returned once.
+func InviteOrgMemberHandler(ctx *gin.Context) {
+	var req inviteOrgMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.InviteOrgMemberRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		Email:   req.Email,
+		Role:    req.Role,
+	}
+
+	pbRes, err := userClient.Client.InviteOrgMember(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: gin.H{
+		"id":         pbRes.GetId(),
+		"org_id":     pbRes.GetOrgId(),
+		"email":      pbRes.GetEmail(),
+		"role":       pbRes.GetRole(),
+		"code":       pbRes.GetCode(),
+		"expires_at": pbRes.GetExpiresAt().AsTime(),
+	}})
+}
+
+// AcceptOrgInviteHandler joins the current user to an organization with an invite code sent to their email.
+func AcceptOrgInviteHandler(ctx *gin.Context) {
+	var req acceptOrgInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.AcceptOrgInviteRequest{UserId: ctx.GetString("user_id"), Code: req.Code}
+
+	pbRes, err := userClient.Client.AcceptOrgInvite(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toOrgMember(pbRes)})
+}
+
+// SetOrgMemberRoleHandler changes the role of a member within an organization. Org admins only.
+func SetOrgMemberRoleHandler(ctx *gin.Context) {
+	var req setOrgMemberRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.SetOrgMemberRoleRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		UserId:  ctx.Param("user_id"),
+		Role:    req.Role,
+	}
+
+	pbRes, err := userClient.Client.SetOrgMemberRole(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toOrgMember(pbRes)})
+}
+
+// RemoveOrgMemberHandler removes a member from an organization. Org admins may remove anyone,
+// other members may only leave.
+func RemoveOrgMemberHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RemoveOrgMemberRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		UserId:  ctx.Param("user_id"),
+	}
+
+	if _, err := userClient.Client.RemoveOrgMember(ctx, pbReq); err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateTeamHandler creates a team within an organization. Coordinators and org admins only.
+func CreateTeamHandler(ctx *gin.Context) {
+	var req createTeamRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.CreateTeamRequest{
+		ActorId:     ctx.GetString("user_id"),
+		OrgId:       ctx.Param("id"),
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	pbRes, err := userClient.Client.CreateTeam(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toTeam(pbRes)})
+}
+
+// ListTeamsHandler lists the teams of an organization. Only members of the organization may list them.
+func ListTeamsHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListTeamsRequest{ActorId: ctx.GetString("user_id"), OrgId: ctx.Param("id")}
+
+	pbRes, err := userClient.Client.ListTeams(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	teams := make([]*team, 0, len(pbRes.GetTeams()))
+	for _, t := range pbRes.GetTeams() {
+		teams = append(teams, toTeam(t))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: teams})
+}
+
+// AddTeamMemberHandler adds a member of an organization to one of its teams. Coordinators and org admins only.
+func AddTeamMemberHandler(ctx *gin.Context) {
+	updateTeamMember(ctx, true)
+}
+
+// RemoveTeamMemberHandler removes a member of an organization from one of its teams. Coordinators and org
+// admins only.
+func RemoveTeamMemberHandler(ctx *gin.Context) {
+	updateTeamMember(ctx, false)
+}
+
+func updateTeamMember(ctx *gin.Context, add bool) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.TeamMemberRequest{
+		ActorId: ctx.GetString("user_id"),
+		OrgId:   ctx.Param("id"),
+		TeamId:  ctx.Param("team_id"),
+		UserId:  ctx.Param("user_id"),
+	}
+
+	var pbRes *pbu.OrgMember
+	if add {
+		pbRes, err = userClient.Client.AddTeamMember(ctx, pbReq)
+	} else {
+		pbRes, err = userClient.Client.RemoveTeamMember(ctx, pbReq)
+	}
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toOrgMember(pbRes)})
+}
+
+// requireOrgMember checks that the current user belongs to an organization before attributing work to it,
+// responding with an error if not.
+func requireOrgMember(ctx *gin.Context, orgID string) bool {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return false
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.GetOrgMembershipRequest{OrgId: orgID, UserId: ctx.GetString("user_id")}
+
+	if _, err := userClient.Client.GetOrgMembership(ctx, pbReq); err != nil {
+		if status.Code(err) == codes.NotFound {
+			ctx.JSON(http.StatusForbidden, response.JSONResponse{Error: "not a member of the organization"})
+			return false
+		}
+		grpcError(ctx, err)
+		return false
+	}
+	return true
+}
+
+func toOrganization(o *pbu.Organization) *organization {
+	return &organization{
+		ID:          o.GetId(),
+		Name:        o.GetName(),
+		Description: o.GetDescription(),
+		CreatedBy:   o.GetCreatedBy(),
+		CreatedAt:   o.GetCreatedAt().AsTime(),
+	}
+}
+
+func toOrgMember(m *pbu.OrgMember) *orgMember {
+	return &orgMember{
+		OrgID:    m.GetOrgId(),
+		UserID:   m.GetUserId(),
+		Name:     m.GetName(),
+		Email:    m.GetEmail(),
+		Role:     m.GetRole(),
+		TeamIDs:  m.GetTeamIds(),
+		JoinedAt: m.GetJoinedAt().AsTime(),
+	}
+}
+
+func toTeam(t *pbu.Team) *team {
+	return &team{
+		ID:          t.GetId(),
+		OrgID:       t.GetOrgId(),
+		Name:        t.GetName(),
+		Description: t.GetDescription(),
+		CreatedBy:   t.GetCreatedBy(),
+		CreatedAt:   t.GetCreatedAt().AsTime(),
+	}
+}
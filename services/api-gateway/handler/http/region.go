@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+)
+
+type watchRegionRequest struct {
+	Name     string            `json:"name" binding:"required"`
+	Location types.Coordinates `json:"location" binding:"required"`
+	Radius   int64             `json:"radius" binding:"required,min=1,max=50000"`
+}
+
+// ListRefreshRegionsHandler reports the refresh status of every disaster and watch-listed region.
+func ListRefreshRegionsHandler(ctx *gin.Context) {
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbRes, err := resourceClient.Client.ListRefreshRegions(ctx, &pbr.ListRefreshRegionsRequest{})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	var regions []*types.RefreshRegion
+	for _, r := range pbRes.GetRegions() {
+		regions = append(regions, toRefreshRegion(r))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: regions})
+}
+
+// WatchRegionHandler adds a region whose resources are periodically refreshed.
+func WatchRegionHandler(ctx *gin.Context) {
+	var req watchRegionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.WatchRegionRequest{
+		Name:     req.Name,
+		Location: &pbr.Coordinates{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+		Within:   req.Radius,
+	}
+
+	pbRes, err := resourceClient.Client.WatchRegion(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toRefreshRegion(pbRes)})
+}
+
+// UnwatchRegionHandler stops refreshing a region.
+func UnwatchRegionHandler(ctx *gin.Context) {
+	regionID := ctx.Param("id")
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	_, err = resourceClient.Client.UnwatchRegion(ctx, &pbr.UnwatchRegionRequest{Id: regionID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TriggerRefreshHandler re-syncs a region immediately.
+func TriggerRefreshHandler(ctx *gin.Context) {
+	regionID := ctx.Param("id")
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbRes, err := resourceClient.Client.TriggerRefresh(ctx, &pbr.TriggerRefreshRequest{Id: regionID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toRefreshRegion(pbRes)})
+}
+
+// toRefreshRegion converts a protobuf refresh region to its JSON representation.
+func toRefreshRegion(r *pbr.RefreshRegion) *types.RefreshRegion {
+	region := &types.RefreshRegion{
+		ID:   r.GetId(),
+		Kind: r.GetKind(),
+		Name: r.GetName(),
+		Location: types.Coordinates{
+			Latitude:  r.GetLocation().GetLatitude(),
+			Longitude: r.GetLocation().GetLongitude(),
+		},
+		Radius:     int(r.GetWithin()),
+		LastStatus: r.GetLastStatus(),
+		LastError:  r.GetLastError(),
+		Found:      int(r.GetFound()),
+		Closed:     r.GetClosed(),
+	}
+	if r.GetExpiresAt() != nil {
+		region.ExpiresAt = r.GetExpiresAt().AsTime()
+	}
+	if r.GetLastRefreshAt() != nil {
+		region.LastRefreshAt = r.GetLastRefreshAt().AsTime()
+	}
+	return region
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/taxonomy"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// DefaultResourceRadius is the search radius (in meters) used when none is given.
+const DefaultResourceRadius = 10000
+
+var resourceTaxonomy *taxonomy.Taxonomy
+
+// InitResourceTaxonomy sets the resource taxonomy used to validate category filters.
+func InitResourceTaxonomy(t *taxonomy.Taxonomy) {
+	resourceTaxonomy = t
+}
+
+// parseCategories reads the repeated "category" query parameter and validates it against the taxonomy.
+func parseCategories(ctx *gin.Context) ([]string, error) {
+	categories := ctx.QueryArray("category")
+	for _, c := range categories {
+		if !resourceTaxonomy.Has(c) {
+			return nil, fmt.Errorf("unknown resource category: %s", c)
+		}
+	}
+	return categories, nil
+}
+
+// GetResourceCategoriesHandler lists the resource categories available for filtering.
+func GetResourceCategoriesHandler(ctx *gin.Context) {
+	type category struct {
+		Name  string `json:"name"`
+		Label string `json:"label"`
+	}
+
+	categories := make([]category, 0, len(resourceTaxonomy.Categories))
+	for _, c := range resourceTaxonomy.Categories {
+		categories = append(categories, category{Name: c.Name, Label: c.Label})
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: categories})
+}
+
+type resourcePageQuery struct {
+	Radius      int64 `form:"radius" binding:"omitempty,min=1,max=100000"`
+	PerCategory int32 `form:"per_category" binding:"omitempty,min=1"`
+	Limit       int32 `form:"limit" binding:"omitempty,min=1,max=500"`
+	Offset      int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+type nearbyResourcesQuery struct {
+	Latitude  *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Longitude *float64 `form:"lon" binding:"required,min=-180,max=180"`
+	resourcePageQuery
+}
+
+// newResourcesRequest builds a nearby resources request from the page query and category filter.
+func newResourcesRequest(lat, lon float64, page *resourcePageQuery, categories []string) *pbr.GetResourcesRequest {
+	within := page.Radius
+	if within == 0 {
+		within = DefaultResourceRadius
+	}
+
+	return &pbr.GetResourcesRequest{
+		Location:    &pbr.Coordinates{Latitude: lat, Longitude: lon},
+		Within:      within,
+		Categories:  categories,
+		PerCategory: page.PerCategory,
+		Limit:       page.Limit,
+		Offset:      page.Offset,
+	}
+}
+
+// toResources converts protobuf resources to their JSON representation.
+func toResources(pbResources []*pbr.Resource) []types.Resource {
+	var resources []types.Resource
+	for _, r := range pbResources {
+		resources = append(resources, *toResource(r))
+	}
+	return resources
+}
+
+// toResource converts a protobuf resource to its JSON representation.
+func toResource(r *pbr.Resource) *types.Resource {
+	oid, _ := bson.ObjectIDFromHex(r.GetId())
+	return &types.Resource{
+		ID:          oid,
+		Name:        r.GetName(),
+		AmenityType: r.GetAmenityType(),
+		Location: &types.Location{
+			Type: "Point",
+			Coordinates: []float64{
+				r.GetLocation().GetLongitude(),
+				r.GetLocation().GetLatitude(),
+			},
+		},
+		Distance:  r.GetDistance(),
+		Inventory: r.GetInventory(),
+		Status:    r.GetStatus(),
+		OrgID:     r.GetOrgId(),
+	}
+}
+
+// GetNearbyResourcesHandler retrieves resources around a location, nearest first.
+func GetNearbyResourcesHandler(ctx *gin.Context) {
+	var query nearbyResourcesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	categories, err := parseCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := newResourcesRequest(*query.Latitude, *query.Longitude, &query.resourcePageQuery, categories)
+	pbRes, err := resourceClient.Client.GetNearbyResources(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toResources(pbRes.GetResources())})
+}
+
+type rankedResourcesQuery struct {
+	nearbyResourcesQuery
+	Geometry bool `form:"geometry"`
+}
+
+// GetRankedResourcesHandler retrieves resources around a location ranked by road travel time.
+func GetRankedResourcesHandler(ctx *gin.Context) {
+	var query rankedResourcesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	categories, err := parseCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	nearbyReq := newResourcesRequest(*query.Latitude, *query.Longitude, &query.resourcePageQuery, categories)
+	pbReq := &pbr.RankResourcesRequest{
+		Origin:          nearbyReq.GetLocation(),
+		Within:          nearbyReq.GetWithin(),
+		Categories:      nearbyReq.GetCategories(),
+		PerCategory:     nearbyReq.GetPerCategory(),
+		Limit:           nearbyReq.GetLimit(),
+		Offset:          nearbyReq.GetOffset(),
+		IncludeGeometry: query.Geometry,
+	}
+
+	pbRes, err := resourceClient.Client.RankResourcesByTravelTime(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	type rankedResource struct {
+		types.Resource
+		Reachable      bool        `json:"reachable"`
+		TravelDistance float64     `json:"travel_distance"`
+		TravelTime     float64     `json:"travel_time"`
+		Geometry       [][]float64 `json:"geometry,omitempty"` // [longitude, latitude] pairs
+	}
+
+	var resources []rankedResource
+	for _, r := range pbRes.GetResources() {
+		resource := rankedResource{
+			Resource:       *toResource(r.GetResource()),
+			Reachable:      r.GetReachable(),
+			TravelDistance: r.GetTravelDistance(),
+			TravelTime:     r.GetTravelTime(),
+		}
+		for _, p := range r.GetGeometry() {
+			resource.Geometry = append(resource.Geometry, []float64{p.GetLongitude(), p.GetLatitude()})
+		}
+		resources = append(resources, resource)
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: resources})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+)
+
+type blockRoadRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// BlockRoadHandler marks an OSM way as impassable for routing.
+func BlockRoadHandler(ctx *gin.Context) {
+	adminID := ctx.GetString("user_id")
+
+	wayID, err := strconv.ParseInt(ctx.Param("way_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Invalid way ID"})
+		return
+	}
+
+	var req blockRoadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.BlockRoadRequest{
+		WayId:   wayID,
+		Reason:  req.Reason,
+		AdminId: adminID,
+	}
+
+	pbRes, err := resourceClient.Client.BlockRoad(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toBlockedRoad(pbRes)})
+}
+
+// UnblockRoadHandler removes the block on an OSM way.
+func UnblockRoadHandler(ctx *gin.Context) {
+	wayID, err := strconv.ParseInt(ctx.Param("way_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Invalid way ID"})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	_, err = resourceClient.Client.UnblockRoad(ctx, &pbr.UnblockRoadRequest{WayId: wayID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListBlockedRoadsHandler lists all roads currently marked as blocked.
+func ListBlockedRoadsHandler(ctx *gin.Context) {
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbRes, err := resourceClient.Client.ListBlockedRoads(ctx, &pbr.ListBlockedRoadsRequest{})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	var roads []*types.BlockedRoad
+	for _, r := range pbRes.GetRoads() {
+		roads = append(roads, toBlockedRoad(r))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: roads})
+}
+
+// toBlockedRoad converts a protobuf blocked road to its JSON representation.
+func toBlockedRoad(r *pbr.BlockedRoad) *types.BlockedRoad {
+	return &types.BlockedRoad{
+		WayID:     r.GetWayId(),
+		Reason:    r.GetReason(),
+		BlockedBy: r.GetBlockedBy(),
+		CreatedAt: r.GetCreatedAt().AsTime(),
+	}
+}
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type setUserRoleRequest struct {
+	Role    string         `json:"role" binding:"required"`
+	Reason  string         `json:"reason"`
+	Regions []types.Region `json:"regions"` // scopes the role to these regions; omit for everywhere
+}
+
+// SetUserRoleHandler changes the role of a user. The change is recorded in the role audit log.
+func SetUserRoleHandler(ctx *gin.Context) {
+	var req setUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	role, err := types.ParseRole(req.Role)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+	if err := authz.ValidateRegions(req.Regions); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.SetUserRoleRequest{
+		ActorId: ctx.GetString("user_id"),
+		UserId:  ctx.Param("id"),
+		Role:    string(role),
+		Reason:  req.Reason,
+		Regions: toPbUserRegions(req.Regions),
+	}
+
+	pbRes, err := userClient.Client.SetUserRole(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toRoleChange(pbRes)})
+}
+
+// ListRoleChangesHandler lists the role change audit log of a user, most recent first.
+func ListRoleChangesHandler(ctx *gin.Context) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.ListRoleChangesRequest{
+		ActorId: ctx.GetString("user_id"),
+		UserId:  ctx.Param("id"),
+	}
+
+	pbRes, err := userClient.Client.ListRoleChanges(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	changes := make([]*types.RoleChange, 0, len(pbRes.GetChanges()))
+	for _, c := range pbRes.GetChanges() {
+		changes = append(changes, toRoleChange(c))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: changes})
+}
+
+type createInviteRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateInviteHandler generates an invite code that grants a role when redeemed at signup.
+// The code is only shown in this response.
+func CreateInviteHandler(ctx *gin.Context) {
+	var req createInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	role, err := types.ParseRole(req.Role)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.CreateInviteRequest{
+		ActorId: ctx.GetString("user_id"),
+		Role:    string(role),
+	}
+
+	pbRes, err := userClient.Client.CreateInvite(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: gin.H{
+		"id":         pbRes.GetId(),
+		"code":       pbRes.GetCode(),
+		"role":       pbRes.GetRole(),
+		"expires_at": pbRes.GetExpiresAt().AsTime().Format(time.RFC3339),
+	}})
+}
+
+func toRoleChange(c *pbu.RoleChange) *types.RoleChange {
+	id, _ := bson.ObjectIDFromHex(c.GetId())
+	return &types.RoleChange{
+		ID:        id,
+		UserID:    c.GetUserId(),
+		OldRole:   types.Role(c.GetOldRole()),
+		NewRole:   types.Role(c.GetNewRole()),
+		ChangedBy: c.GetChangedBy(),
+		Source:    c.GetSource(),
+		InviteID:  c.GetInviteId(),
+		Reason:    c.GetReason(),
+		Regions:   c.GetRegions(),
+		CreatedAt: c.GetCreatedAt().AsTime(),
+	}
+}
+
+func toPbUserRegions(regions []types.Region) []*pbu.Region {
+	var pbRegions []*pbu.Region
+	for _, r := range regions {
+		pbRegion := &pbu.Region{Name: r.Name}
+		for _, c := range r.Polygon {
+			pbRegion.Polygon = append(pbRegion.Polygon, &pbu.Point{Latitude: c.Latitude, Longitude: c.Longitude})
+		}
+		pbRegions = append(pbRegions, pbRegion)
+	}
+	return pbRegions
+}
+
+func toUserRegions(pbRegions []*pbu.Region) []types.Region {
+	var regions []types.Region
+	for _, r := range pbRegions {
+		region := types.Region{Name: r.GetName()}
+		for _, p := range r.GetPolygon() {
+			region.Polygon = append(region.Polygon, types.Coordinates{Latitude: p.GetLatitude(), Longitude: p.GetLongitude()})
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"net/http"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbd "github.com/cprakhar/relief-ops/shared/proto/disaster"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type createResourceRequest struct {
+	Name        string            `json:"name" binding:"required"`
+	AmenityType string            `json:"amenity_type" binding:"required"`
+	Location    types.Coordinates `json:"location" binding:"required"`
+	Inventory   map[string]int64  `json:"inventory"`
+	OrgID       string            `json:"org_id"` // organization running the resource, optional
+}
+
+type setInventoryRequest struct {
+	Item     string `json:"item" binding:"required"`
+	Quantity *int64 `json:"quantity" binding:"required,min=0"`
+}
+
+type declareNeedRequest struct {
+	Item     string `json:"item" binding:"required"`
+	Quantity int64  `json:"quantity" binding:"required,min=1"`
+	Urgency  string `json:"urgency" binding:"required"`
+}
+
+type commitSupplyRequest struct {
+	DepotID  string `json:"depot_id" binding:"required"`
+	Quantity int64  `json:"quantity" binding:"required,min=1"`
+}
+
+type matchNeedsQuery struct {
+	Radius int64 `form:"radius" binding:"omitempty,min=1,max=1000000"`
+}
+
+// CreateResourceHandler adds a manually managed resource, such as a supply depot.
+func CreateResourceHandler(ctx *gin.Context) {
+	var req createResourceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	if req.OrgID != "" {
+		userClient, err := grpcclient.NewUserServiceClient()
+		if err != nil {
+			unavailable(ctx, err)
+			return
+		}
+		defer userClient.Close()
+
+		if _, err := userClient.Client.GetOrganization(ctx, &pbu.GetOrganizationRequest{Id: req.OrgID}); err != nil {
+			grpcError(ctx, err)
+			return
+		}
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.CreateResourceRequest{
+		Name:        req.Name,
+		AmenityType: req.AmenityType,
+		Location:    &pbr.Coordinates{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+		Inventory:   req.Inventory,
+		OrgId:       req.OrgID,
+	}
+
+	pbRes, err := resourceClient.Client.CreateResource(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toResource(pbRes)})
+}
+
+// SetInventoryHandler sets the stock of a supply item held by a resource.
+func SetInventoryHandler(ctx *gin.Context) {
+	resourceID := ctx.Param("id")
+
+	var req setInventoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.SetInventoryRequest{
+		ResourceId: resourceID,
+		Item:       req.Item,
+		Quantity:   *req.Quantity,
+	}
+
+	pbRes, err := resourceClient.Client.SetInventory(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toResource(pbRes)})
+}
+
+// DeclareNeedHandler records a supply need at a disaster's location.
+func DeclareNeedHandler(ctx *gin.Context) {
+	adminID := ctx.GetString("user_id")
+	disasterID := ctx.Param("id")
+
+	var req declareNeedRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer disasterClient.Close()
+
+	disaster, err := disasterClient.Client.GetDisaster(ctx, &pbd.GetDisasterRequest{Id: disasterID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.DeclareNeedRequest{
+		DisasterId: disasterID,
+		Location: &pbr.Coordinates{
+			Latitude:  disaster.GetLocation().GetLatitude(),
+			Longitude: disaster.GetLocation().GetLongitude(),
+		},
+		Item:     req.Item,
+		Quantity: req.Quantity,
+		Urgency:  req.Urgency,
+		AdminId:  adminID,
+	}
+
+	pbRes, err := resourceClient.Client.DeclareNeed(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: toNeed(pbRes)})
+}
+
+// ListNeedsHandler lists the supply needs declared for a disaster.
+func ListNeedsHandler(ctx *gin.Context) {
+	disasterID := ctx.Param("id")
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbRes, err := resourceClient.Client.ListNeeds(ctx, &pbr.ListNeedsRequest{DisasterId: disasterID})
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	var needs []*types.Need
+	for _, n := range pbRes.GetNeeds() {
+		needs = append(needs, toNeed(n))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: needs})
+}
+
+// MatchNeedsHandler proposes which depots should ship what to cover a disaster's outstanding needs.
+func MatchNeedsHandler(ctx *gin.Context) {
+	disasterID := ctx.Param("id")
+
+	var query matchNeedsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.MatchNeedsRequest{
+		DisasterId: disasterID,
+		Within:     query.Radius,
+	}
+
+	pbRes, err := resourceClient.Client.MatchNeeds(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	type supplyProposal struct {
+		NeedID   string          `json:"need_id"`
+		Depot    *types.Resource `json:"depot"`
+		Item     string          `json:"item"`
+		Quantity int64           `json:"quantity"`
+		Distance float64         `json:"distance"`
+	}
+
+	var proposals []supplyProposal
+	for _, p := range pbRes.GetProposals() {
+		proposals = append(proposals, supplyProposal{
+			NeedID:   p.GetNeedId(),
+			Depot:    toResource(p.GetDepot()),
+			Item:     p.GetItem(),
+			Quantity: p.GetQuantity(),
+			Distance: p.GetDistance(),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: proposals})
+}
+
+// CommitSupplyHandler commits depot stock to a disaster's need.
+func CommitSupplyHandler(ctx *gin.Context) {
+	adminID := ctx.GetString("user_id")
+	disasterID := ctx.Param("id")
+	needID := ctx.Param("need_id")
+
+	var req commitSupplyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer resourceClient.Close()
+
+	pbReq := &pbr.CommitSupplyRequest{
+		DisasterId: disasterID,
+		NeedId:     needID,
+		DepotId:    req.DepotID,
+		Quantity:   req.Quantity,
+		AdminId:    adminID,
+	}
+
+	pbRes, err := resourceClient.Client.CommitSupply(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	oid, _ := bson.ObjectIDFromHex(pbRes.GetId())
+	commitment := &types.SupplyCommitment{
+		ID:          oid,
+		NeedID:      pbRes.GetNeedId(),
+		DisasterID:  pbRes.GetDisasterId(),
+		DepotID:     pbRes.GetDepotId(),
+		Item:        pbRes.GetItem(),
+		Quantity:    pbRes.GetQuantity(),
+		Distance:    pbRes.GetDistance(),
+		CommittedBy: pbRes.GetCommittedBy(),
+		CreatedAt:   pbRes.GetCreatedAt().AsTime(),
+	}
+
+	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: commitment})
+}
+
+// toNeed converts a protobuf need to its JSON representation.
+func toNeed(n *pbr.Need) *types.Need {
+	oid, _ := bson.ObjectIDFromHex(n.GetId())
+	return &types.Need{
+		ID:         oid,
+		DisasterID: n.GetDisasterId(),
+		Location: &types.Location{
+			Type:        "Point",
+			Coordinates: []float64{n.GetLocation().GetLongitude(), n.GetLocation().GetLatitude()},
+		},
+		Item:      n.GetItem(),
+		Quantity:  n.GetQuantity(),
+		Committed: n.GetCommitted(),
+		Urgency:   n.GetUrgency(),
+		CreatedBy: n.GetCreatedBy(),
+		CreatedAt: n.GetCreatedAt().AsTime(),
+		UpdatedAt: n.GetUpdatedAt().AsTime(),
+	}
+}
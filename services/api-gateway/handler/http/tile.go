@@ -0,0 +1,232 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/shared/mvt"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	pbd "github.com/cprakhar/relief-ops/shared/proto/disaster"
+	pbr "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// tileBuffer is how far (in tile coordinates) features around a tile are included,
+	// so symbols on tile edges are not clipped.
+	tileBuffer = 64
+
+	// clusterMaxZoom is the highest zoom level at which nearby features are clustered.
+	clusterMaxZoom = 13
+
+	// clusterCellSize is the size (in tile coordinates) of the clustering grid cells.
+	clusterCellSize = 256
+
+	mvtContentType = "application/vnd.mapbox-vector-tile"
+)
+
+var tileCache *tilecache.Cache
+
+// InitTileCache sets the cache used for encoded map tiles.
+func InitTileCache(c *tilecache.Cache) {
+	tileCache = c
+}
+
+// GetTileHandler serves a Mapbox Vector Tile of resources or approved disasters.
+// Features are clustered at low zoom levels; resources can be filtered by category.
+func GetTileHandler(ctx *gin.Context) {
+	layer := ctx.Param("layer")
+	if layer != tilecache.LayerResources && layer != tilecache.LayerDisasters {
+		ctx.JSON(http.StatusNotFound, response.JSONResponse{Error: "Unknown tile layer"})
+		return
+	}
+
+	tile, err := parseTile(ctx.Param("z"), ctx.Param("x"), ctx.Param("y"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	var categories []string
+	if layer == tilecache.LayerResources {
+		categories, err = parseCategories(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+			return
+		}
+		categories = slices.Clone(categories)
+		slices.Sort(categories)
+	}
+
+	logger := logs.L()
+	key := tilecache.Key{Layer: layer, Z: tile.Z, X: tile.X, Y: tile.Y, Variant: strings.Join(categories, ",")}
+
+	// The cache is best effort; a Redis failure only costs a re-render
+	version, err := tileCache.Version(ctx, layer)
+	if err != nil {
+		logger.Warnw("Failed to read tile cache version", "layer", layer, "error", err)
+	}
+	if err == nil {
+		cached, err := tileCache.Get(ctx, version, key)
+		if err != nil {
+			logger.Warnw("Failed to read cached tile", "layer", layer, "error", err)
+		}
+		if cached != nil {
+			ctx.Data(http.StatusOK, mvtContentType, cached)
+			return
+		}
+	}
+
+	var features []*mvt.Feature
+	switch layer {
+	case tilecache.LayerResources:
+		features, err = resourceFeatures(ctx, tile, categories)
+	case tilecache.LayerDisasters:
+		features, err = disasterFeatures(ctx, tile)
+	}
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	if tile.Z <= clusterMaxZoom {
+		features = mvt.Cluster(features, clusterCellSize)
+	}
+
+	data, err := mvt.Encode(&mvt.Layer{Name: layer, Features: features})
+	if err != nil {
+		logger.Errorw("Failed to encode tile", "layer", layer, "error", err)
+		ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: "internal server error"})
+		return
+	}
+
+	if err := tileCache.Set(ctx, version, key, data); err != nil {
+		logger.Warnw("Failed to cache tile", "layer", layer, "error", err)
+	}
+
+	ctx.Data(http.StatusOK, mvtContentType, data)
+}
+
+// parseTile parses the z/x/y path parameters of a tile request; y carries the ".mvt" extension.
+func parseTile(z, x, y string) (mvt.Tile, error) {
+	y, ok := strings.CutSuffix(y, ".mvt")
+	if !ok {
+		return mvt.Tile{}, fmt.Errorf("tile must have the .mvt extension")
+	}
+
+	var coords [3]uint32
+	for i, s := range []string{z, x, y} {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return mvt.Tile{}, fmt.Errorf("invalid tile coordinate: %s", s)
+		}
+		coords[i] = uint32(v)
+	}
+
+	tile := mvt.Tile{Z: coords[0], X: coords[1], Y: coords[2]}
+	return tile, tile.Validate()
+}
+
+// tileBounds returns the bounding box of a tile including its buffer.
+func tileBounds(tile mvt.Tile) types.Bounds {
+	minLon, minLat, maxLon, maxLat := tile.Bounds(tileBuffer)
+	return types.Bounds{MinLat: minLat, MinLon: minLon, MaxLat: maxLat, MaxLon: maxLon}
+}
+
+// resourceFeatures retrieves the open resources within a tile as tile features.
+func resourceFeatures(ctx *gin.Context, tile mvt.Tile, categories []string) ([]*mvt.Feature, error) {
+	resourceClient, err := grpcclient.NewResourceServiceClient()
+	if err != nil {
+		logs.L().Errorw("Failed to create gRPC client", "service", "resource", "error", err)
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+	defer resourceClient.Close()
+
+	b := tileBounds(tile)
+	pbReq := &pbr.GetResourcesInBoundsRequest{
+		Bounds: &pbr.Bounds{
+			MinLat: b.MinLat,
+			MinLon: b.MinLon,
+			MaxLat: b.MaxLat,
+			MaxLon: b.MaxLon,
+		},
+		Categories: categories,
+	}
+
+	pbRes, err := resourceClient.Client.GetResourcesInBounds(ctx, pbReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var features []*mvt.Feature
+	for _, r := range pbRes.GetResources() {
+		x, y := tile.Project(r.GetLocation().GetLatitude(), r.GetLocation().GetLongitude())
+		if !tile.Contains(x, y, tileBuffer) {
+			continue
+		}
+		features = append(features, &mvt.Feature{
+			X: x,
+			Y: y,
+			Properties: map[string]any{
+				"id":       r.GetId(),
+				"name":     r.GetName(),
+				"category": r.GetAmenityType(),
+			},
+		})
+	}
+	return features, nil
+}
+
+// disasterFeatures retrieves the approved disasters within a tile as tile features.
+func disasterFeatures(ctx *gin.Context, tile mvt.Tile) ([]*mvt.Feature, error) {
+	disasterClient, err := grpcclient.NewDisasterServiceClient()
+	if err != nil {
+		logs.L().Errorw("Failed to create gRPC client", "service", "disaster", "error", err)
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+	defer disasterClient.Close()
+
+	b := tileBounds(tile)
+	pbReq := &pbd.ListDisastersRequest{
+		Status: types.DisasterApproved,
+		Bounds: &pbd.Bounds{
+			MinLat: b.MinLat,
+			MinLon: b.MinLon,
+			MaxLat: b.MaxLat,
+			MaxLon: b.MaxLon,
+		},
+	}
+
+	pbRes, err := disasterClient.Client.ListDisasters(ctx, pbReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var features []*mvt.Feature
+	for _, d := range pbRes.GetDisasters() {
+		x, y := tile.Project(d.GetLocation().GetLatitude(), d.GetLocation().GetLongitude())
+		if !tile.Contains(x, y, tileBuffer) {
+			continue
+		}
+		features = append(features, &mvt.Feature{
+			X: x,
+			Y: y,
+			Properties: map[string]any{
+				"id":         d.GetId(),
+				"title":      d.GetTitle(),
+				"tags":       strings.Join(d.GetTags(), ","),
+				"created_at": d.GetCreatedAt().AsTime().Unix(),
+			},
+		})
+	}
+	return features, nil
+}
@@ -1,10 +1,11 @@
 package http
 
 import (
-	"log"
 	"net/http"
+	"net/url"
 
 	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/services/api-gateway/middleware"
 	"github.com/cprakhar/relief-ops/shared/env"
 	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
 	"github.com/cprakhar/relief-ops/shared/response"
@@ -14,15 +15,24 @@ import (
 	"github.com/markbates/goth/providers/github"
 	"github.com/markbates/goth/providers/google"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const CookieName = "auth_token"
+const (
+	CookieName        = "auth_token"
+	RefreshCookieName = "refresh_token"
 
+	// refreshCookiePath limits the refresh cookie to the auth endpoints that consume it.
+	refreshCookiePath = "/api/auth"
+)
+
+// registerUserRequest signs up a user with the default role, or with the role of an admin-issued invite.
 type registerUserRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
-	Name     string `json:"name" binding:"required"`
-	Role     string `json:"role" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,min=8"`
+	Name       string `json:"name" binding:"required"`
+	InviteCode string `json:"invite_code"`
 }
 
 // RegisterUserHandler handles user registration requests.
@@ -35,25 +45,27 @@ func RegisterUserHandler(ctx *gin.Context) {
 
 	userClient, err := grpcclient.NewUserServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer userClient.Close()
 
 	pbReq := &pbu.RegisterUserRequest{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
-		Role:     req.Role,
+		Name:       req.Name,
+		Email:      req.Email,
+		Password:   req.Password,
+		InviteCode: req.InviteCode,
 	}
 
 	pbRes, err := userClient.Client.RegisterUser(ctx, pbReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: err.Error()})
+		grpcError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, response.JSONResponse{Data: gin.H{
 		"user_id": pbRes.GetId(),
+		"role":    pbRes.GetRole(),
 	}})
 }
 
@@ -72,43 +84,114 @@ func LoginUserHandler(ctx *gin.Context) {
 
 	userClient, err := grpcclient.NewUserServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer userClient.Close()
 
 	pbReq := &pbu.LoginUserRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		ClientIp: ctx.ClientIP(),
 	}
 
 	pbRes, err := userClient.Client.LoginUser(ctx, pbReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: err.Error()})
+		grpcError(ctx, err)
 		return
 	}
 
-	token := pbRes.GetToken()
-	user := pbRes.GetUser()
+	if pbRes.GetMfaRequired() {
+		ctx.JSON(http.StatusOK, response.JSONResponse{Data: toMFAChallengeResponse(pbRes)})
+		return
+	}
+
+	setAuthCookies(ctx, pbRes)
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toLoginResponse(pbRes)})
+}
+
+type loginResponse struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int64     `json:"expires_in"`
+	User         *pbu.User `json:"user"`
+}
+
+func toLoginResponse(pbRes *pbu.LoginUserResponse) *loginResponse {
+	return &loginResponse{
+		Token:        pbRes.GetToken(),
+		RefreshToken: pbRes.GetRefreshToken(),
+		ExpiresIn:    pbRes.GetExpiresIn(),
+		User:         pbRes.GetUser(),
+	}
+}
 
+// setAuthCookies sets the access token and refresh token cookies, each expiring along with its token.
+func setAuthCookies(ctx *gin.Context, pbRes *pbu.LoginUserResponse) {
+	ctx.SetSameSite(http.SameSiteStrictMode)
 	ctx.SetCookie(CookieName,
-		token,
-		3600*24*7, // 7 days
+		pbRes.GetToken(),
+		int(pbRes.GetExpiresIn()),
 		"/",
 		"",
 		false,
 		true,
 	)
-	ctx.SetSameSite(http.SameSiteStrictMode)
+	ctx.SetCookie(RefreshCookieName,
+		pbRes.GetRefreshToken(),
+		int(pbRes.GetRefreshExpiresIn()),
+		refreshCookiePath,
+		"",
+		false,
+		true,
+	)
+}
+
+// clearAuthCookies removes the access token and refresh token cookies.
+func clearAuthCookies(ctx *gin.Context) {
+	ctx.SetCookie(CookieName, "", -1, "/", "", false, true)
+	ctx.SetCookie(RefreshCookieName, "", -1, refreshCookiePath, "", false, true)
+}
 
-	responseData := struct {
-		Token string    `json:"token"`
-		User  *pbu.User `json:"user"`
-	}{
-		Token: token,
-		User:  user,
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshTokenHandler exchanges a refresh token, taken from the refresh cookie or the request body,
+// for a new access token and refresh token.
+func RefreshTokenHandler(ctx *gin.Context) {
+	refreshToken, err := ctx.Cookie(RefreshCookieName)
+	if err != nil || refreshToken == "" {
+		var req refreshTokenRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+			ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Refresh token is required"})
+			return
+		}
+		refreshToken = req.RefreshToken
 	}
 
-	ctx.JSON(http.StatusOK, response.JSONResponse{Data: responseData})
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.RefreshTokenRequest{RefreshToken: refreshToken}
+
+	pbRes, err := userClient.Client.RefreshToken(ctx, pbReq)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			clearAuthCookies(ctx)
+			ctx.JSON(http.StatusUnauthorized, response.JSONResponse{Error: status.Convert(err).Message()})
+			return
+		}
+		grpcError(ctx, err)
+		return
+	}
+
+	setAuthCookies(ctx, pbRes)
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toLoginResponse(pbRes)})
 }
 
 // GetCurrentUserHandler retrieves the currently authenticated user's details.
@@ -121,7 +204,8 @@ func GetCurrentUserHandler(ctx *gin.Context) {
 
 	userClient, err := grpcclient.NewUserServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer userClient.Close()
 
@@ -139,11 +223,14 @@ func GetCurrentUserHandler(ctx *gin.Context) {
 	}
 
 	user := &types.User{
-		ID:        oid,
-		Name:      pbRes.GetName(),
-		Email:     pbRes.GetEmail(),
-		Role:      pbRes.GetRole(),
-		AvatarURL: pbRes.GetAvatarUrl(),
+		ID:            oid,
+		Name:          pbRes.GetName(),
+		Email:         pbRes.GetEmail(),
+		Role:          types.Role(pbRes.GetRole()),
+		AvatarURL:     pbRes.GetAvatarUrl(),
+		EmailVerified: pbRes.GetEmailVerified(),
+		Regions:       toUserRegions(pbRes.GetRegions()),
+		MFA:           types.MFA{Enabled: pbRes.GetMfaEnabled()},
 	}
 
 	ctx.JSON(http.StatusOK, response.JSONResponse{Data: user})
@@ -177,16 +264,47 @@ func InitOAuthProviders(oauthCfg *OAuthProvidersConfig) {
 	)
 }
 
-// LogoutUserHandler handles user logout by clearing the auth cookie.
+// JWKSHandler serves the public keys tokens are signed with as a JSON Web Key Set. The set is served
+// bare rather than in the usual response envelope, as JWKS clients expect.
+func JWKSHandler(ctx *gin.Context) {
+	set, err := middleware.JWKS().Set()
+	if err != nil {
+		ctx.JSON(http.StatusServiceUnavailable, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, set)
+}
+
+// LogoutUserHandler handles user logout by revoking the auth and refresh tokens and clearing their cookies.
+// With ?all=true, every other session of the user is logged out as well.
 func LogoutUserHandler(ctx *gin.Context) {
-	ctx.SetCookie(CookieName,
-		"",
-		-1,
-		"/",
-		"",
-		false,
-		true,
-	)
+	token, _ := ctx.Cookie(CookieName)
+	refreshToken, _ := ctx.Cookie(RefreshCookieName)
+
+	if token != "" || refreshToken != "" {
+		userClient, err := grpcclient.NewUserServiceClient()
+		if err != nil {
+			unavailable(ctx, err)
+			return
+		}
+		defer userClient.Close()
+
+		pbReq := &pbu.RevokeTokenRequest{
+			Token:        token,
+			RefreshToken: refreshToken,
+			AllSessions:  ctx.Query("all") == "true",
+		}
+
+		// A token that is already invalid needs no revoking
+		if _, err := userClient.Client.RevokeToken(ctx, pbReq); err != nil && status.Code(err) != codes.Unauthenticated {
+			ctx.JSON(http.StatusInternalServerError, response.JSONResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	clearAuthCookies(ctx)
 
 	ctx.JSON(http.StatusOK, response.JSONResponse{Data: "Logged out successfully"})
 }
@@ -204,11 +322,6 @@ func OAuthSignInHandler(ctx *gin.Context) {
 
 func OAuthCallbackHandler(ctx *gin.Context) {
 	provider := ctx.Query("provider")
-	role := ctx.Query("role")
-
-	if role == "" {
-		role = "user"
-	}
 
 	if provider == "" {
 		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "Provider is required"})
@@ -223,7 +336,8 @@ func OAuthCallbackHandler(ctx *gin.Context) {
 
 	userClient, err := grpcclient.NewUserServiceClient()
 	if err != nil {
-		log.Fatal(err)
+		unavailable(ctx, err)
+		return
 	}
 	defer userClient.Close()
 
@@ -231,7 +345,6 @@ func OAuthCallbackHandler(ctx *gin.Context) {
 		Email:     user.Email,
 		Name:      user.Name,
 		AvatarUrl: user.AvatarURL,
-		Role:      role,
 	}
 
 	pbRes, err := userClient.Client.OAuthSignIn(ctx, pbReq)
@@ -240,19 +353,16 @@ func OAuthCallbackHandler(ctx *gin.Context) {
 		return
 	}
 
-	token := pbRes.GetToken()
+	webURL := env.GetString("WEB_URL", "http://localhost:3000")
 
-	ctx.SetCookie(CookieName,
-		token,
-		3600*24*7, // 7 days
-		"/",
-		"",
-		false,
-		true,
-	)
-	ctx.SetSameSite(http.SameSiteStrictMode)
+	// The provider only vouched for the first factor, so the web app collects the code
+	if pbRes.GetMfaRequired() {
+		ctx.Redirect(http.StatusTemporaryRedirect, webURL+"/login/mfa?mfa_token="+url.QueryEscape(pbRes.GetMfaToken()))
+		return
+	}
 
-	webURL := env.GetString("WEB_URL", "http://localhost:3000")
+	token := pbRes.GetToken()
+	setAuthCookies(ctx, pbRes)
 
 	ctx.Redirect(http.StatusTemporaryRedirect, webURL+"/oauth-success?token="+token)
 }
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	pbu "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/response"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type volunteerProfile struct {
+	UserID       string                     `json:"user_id,omitempty"`
+	Name         string                     `json:"name,omitempty"`
+	Skills       []string                   `json:"skills"`
+	Languages    []string                   `json:"languages"`
+	Vehicles     []string                   `json:"vehicles"`
+	Availability []types.AvailabilityWindow `json:"availability"`
+	TimeZone     string                     `json:"time_zone"`
+	Home         *types.Coordinates         `json:"home,omitempty"`
+	Distance     float64                    `json:"distance,omitempty"` // meters, search results only
+	UpdatedAt    *time.Time                 `json:"updated_at,omitempty"`
+}
+
+// GetVolunteerVocabularyHandler lists the skills and vehicles volunteer profiles and searches use.
+func GetVolunteerVocabularyHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: gin.H{
+		"skills":   types.VolunteerSkills,
+		"vehicles": types.Vehicles,
+	}})
+}
+
+// GetMyVolunteerProfileHandler retrieves the current user's volunteer profile.
+func GetMyVolunteerProfileHandler(ctx *gin.Context) {
+	getVolunteerProfile(ctx, ctx.GetString("user_id"))
+}
+
+// GetVolunteerProfileHandler retrieves the volunteer profile of a user, for dispatchers.
+func GetVolunteerProfileHandler(ctx *gin.Context) {
+	getVolunteerProfile(ctx, ctx.Param("id"))
+}
+
+func getVolunteerProfile(ctx *gin.Context, userID string) {
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.GetVolunteerProfileRequest{ActorId: ctx.GetString("user_id"), UserId: userID}
+
+	pbRes, err := userClient.Client.GetVolunteerProfile(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toVolunteerProfile(pbRes)})
+}
+
+// UpdateMyVolunteerProfileHandler replaces the current user's volunteer profile. Only volunteers have one.
+func UpdateMyVolunteerProfileHandler(ctx *gin.Context) {
+	var req volunteerProfile
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbReq := &pbu.UpdateVolunteerProfileRequest{
+		UserId:  ctx.GetString("user_id"),
+		Profile: toPbVolunteerProfile(&req),
+	}
+
+	pbRes, err := userClient.Client.UpdateVolunteerProfile(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: toVolunteerProfile(pbRes)})
+}
+
+type findVolunteersQuery struct {
+	Latitude    *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Longitude   *float64 `form:"lon" binding:"required,min=-180,max=180"`
+	Radius      int32    `form:"radius" binding:"omitempty,min=1"`
+	AvailableAt string   `form:"available_at"` // RFC 3339 time, or now
+	Limit       int32    `form:"limit" binding:"omitempty,min=1"`
+}
+
+// FindVolunteersHandler searches volunteers around a location, nearest first. Repeat skill= for volunteers
+// with every skill, and language= or vehicle= for volunteers with any of them.
+func FindVolunteersHandler(ctx *gin.Context) {
+	var query findVolunteersQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: err.Error()})
+		return
+	}
+
+	pbReq := &pbu.FindVolunteersRequest{
+		ActorId:   ctx.GetString("user_id"),
+		Location:  &pbu.Point{Latitude: *query.Latitude, Longitude: *query.Longitude},
+		Within:    query.Radius,
+		Skills:    ctx.QueryArray("skill"),
+		Languages: ctx.QueryArray("language"),
+		Vehicles:  ctx.QueryArray("vehicle"),
+		Limit:     query.Limit,
+	}
+
+	switch query.AvailableAt {
+	case "":
+	case "now":
+		pbReq.AvailableAt = timestamppb.Now()
+	default:
+		t, err := time.Parse(time.RFC3339, query.AvailableAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, response.JSONResponse{Error: "available_at must be an RFC 3339 time or now"})
+			return
+		}
+		pbReq.AvailableAt = timestamppb.New(t)
+	}
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		unavailable(ctx, err)
+		return
+	}
+	defer userClient.Close()
+
+	pbRes, err := userClient.Client.FindVolunteers(ctx, pbReq)
+	if err != nil {
+		grpcError(ctx, err)
+		return
+	}
+
+	volunteers := make([]*volunteerProfile, 0, len(pbRes.GetVolunteers()))
+	for _, v := range pbRes.GetVolunteers() {
+		volunteers = append(volunteers, toVolunteerProfile(v))
+	}
+
+	ctx.JSON(http.StatusOK, response.JSONResponse{Data: volunteers})
+}
+
+func toVolunteerProfile(p *pbu.VolunteerProfile) *volunteerProfile {
+	profile := &volunteerProfile{
+		UserID:    p.GetUserId(),
+		Name:      p.GetName(),
+		Skills:    p.GetSkills(),
+		Languages: p.GetLanguages(),
+		Vehicles:  p.GetVehicles(),
+		TimeZone:  p.GetTimeZone(),
+		Distance:  p.GetDistance(),
+	}
+	for _, w := range p.GetAvailability() {
+		profile.Availability = append(profile.Availability, types.AvailabilityWindow{
+			Day:   time.Weekday(w.GetDay()),
+			Start: int(w.GetStart()),
+			End:   int(w.GetEnd()),
+		})
+	}
+	if home := p.GetHome(); home != nil {
+		profile.Home = &types.Coordinates{Latitude: home.GetLatitude(), Longitude: home.GetLongitude()}
+	}
+	if p.GetUpdatedAt() != nil {
+		updatedAt := p.GetUpdatedAt().AsTime()
+		profile.UpdatedAt = &updatedAt
+	}
+	return profile
+}
+
+func toPbVolunteerProfile(p *volunteerProfile) *pbu.VolunteerProfile {
+	pbProfile := &pbu.VolunteerProfile{
+		Skills:    p.Skills,
+		Languages: p.Languages,
+		Vehicles:  p.Vehicles,
+		TimeZone:  p.TimeZone,
+	}
+	for _, w := range p.Availability {
+		pbProfile.Availability = append(pbProfile.Availability, &pbu.AvailabilityWindow{
+			Day:   int32(w.Day),
+			Start: int32(w.Start),
+			End:   int32(w.End),
+		})
+	}
+	if p.Home != nil {
+		pbProfile.Home = &pbu.Point{Latitude: p.Home.Latitude, Longitude: p.Home.Longitude}
+	}
+	return pbProfile
+}
@@ -10,20 +10,24 @@ import (
 )
 
 type httpServer struct {
-	addr   string
-	webURL string
+	addr           string
+	webURL         string
+	trustedProxies string
 }
 
 // newHTTPServer creates and returns a new HTTP server.
-func newHTTPServer(addr string, web string) *httpServer {
-	return &httpServer{addr: addr, webURL: web}
+func newHTTPServer(addr, web, trustedProxies string) *httpServer {
+	return &httpServer{addr: addr, webURL: web, trustedProxies: trustedProxies}
 }
 
 // run starts the HTTP server and listens for incoming requests.
 func (s *httpServer) run(ctx context.Context) error {
 	logger := logs.L()
 
-	h := handlerhttp.NewHttpHandler(s.webURL)
+	h, err := handlerhttp.NewHttpHandler(s.webURL, s.trustedProxies)
+	if err != nil {
+		return err
+	}
 	srv := &http.Server{
 		Addr:    s.addr,
 		Handler: h,
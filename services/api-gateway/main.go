@@ -8,15 +8,21 @@ import (
 	"syscall"
 
 	"github.com/cprakhar/relief-ops/services/api-gateway/handler/http"
+	"github.com/cprakhar/relief-ops/services/api-gateway/middleware"
+	"github.com/cprakhar/relief-ops/shared/db"
 	"github.com/cprakhar/relief-ops/shared/env"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/observe/traces"
+	"github.com/cprakhar/relief-ops/shared/taxonomy"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
 )
 
 var (
 	addr        = env.GetString("API_GATEWAY_ADDR", ":8080")
 	webURL      = env.GetString("WEB_URL", "http://localhost:3000")
 	environment = env.GetString("ENVIRONMENT", "development")
+	// Proxies or load balancers (IPs or CIDRs) whose X-Forwarded-For is trusted for client IPs
+	trustedProxies = env.GetString("TRUSTED_PROXIES", "")
 	// OAuth configuration
 	// Google OAuth configuration
 	googleClientID     = env.GetString("GOOGLE_CLIENT_ID", "")
@@ -27,6 +33,27 @@ var (
 	githubClientSecret = env.GetString("GITHUB_CLIENT_SECRET", "")
 	githubRedirectURL  = env.GetString("GITHUB_REDIRECT_URL", "http://localhost:8080/api/auth/callback?provider=github")
 
+	// Resource taxonomy configuration (empty uses the embedded default)
+	taxonomyFile = env.GetString("RESOURCE_TAXONOMY_FILE", "")
+
+	// Redis configuration
+	redisAddr     = env.GetString("REDIS_ADDR", "redis-db:6379")
+	redisUsername = env.GetString("REDIS_USERNAME", "")
+	redisMaxConn  = env.GetInt("REDIS_MAX_CONN", 10)
+	redisMinIdle  = env.GetInt("REDIS_MIN_IDLE", 2)
+	redisMaxIdle  = env.GetInt("REDIS_MAX_IDLE", 5)
+	redisPassword = env.GetString("REDIS_PASSWORD", "")
+	redisDB       = env.GetInt("REDIS_DB", 0)
+
+	// JWT signing key cache configuration
+	jwksTTL = env.GetTimeDuration("JWKS_CACHE_TTL", middleware.DefaultJWKSTTL)
+
+	// Whether admins must sign in with MFA to use admin routes
+	adminMFARequired = env.GetBool("ADMIN_MFA_REQUIRED", false)
+
+	// Map tile cache configuration
+	tileCacheTTL = env.GetTimeDuration("TILE_CACHE_TTL", tilecache.DefaultTTL)
+
 	// OTLP configuration
 	otlpEndpoint = env.GetString("OTLP_ENDPOINT", "otel-collector:4317")
 	otlpInsecure = env.GetBool("OTLP_INSECURE", true)
@@ -87,8 +114,35 @@ func main() {
 	// Initialize OAuth providers
 	http.InitOAuthProviders(oauthCfg)
 
+	middleware.InitJWKS(jwksTTL)
+	middleware.InitMFAPolicy(adminMFARequired)
+
+	// Load the resource taxonomy used for category filters
+	resourceTaxonomy, err := taxonomy.Load(taxonomyFile)
+	if err != nil {
+		logger.Fatalw("Failed to load resource taxonomy", "error", err)
+	}
+	http.InitResourceTaxonomy(resourceTaxonomy)
+
+	redisCfg := &db.RedisConfig{
+		Addr:           redisAddr,
+		Username:       redisUsername,
+		Password:       redisPassword,
+		DB:             int(redisDB),
+		MaxActiveConns: int(redisMaxConn),
+		MaxIdleConns:   int(redisMaxIdle),
+		MinIdleConns:   int(redisMinIdle),
+	}
+	if err := db.InitRedis(redisCfg); err != nil {
+		logger.Fatalw("Failed to connect to Redis", "error", err)
+	}
+	logger.Info("Connected to Redis")
+
+	http.InitTileCache(tilecache.New(db.GetRedisClient(), tileCacheTTL))
+	middleware.InitAPIKeyLimiter(db.GetRedisClient())
+
 	// Start HTTP server
-	httpServer := newHTTPServer(addr, webURL)
+	httpServer := newHTTPServer(addr, webURL, trustedProxies)
 
 	done := make(chan struct{})
 	go func() {
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"sync"
+	"time"
+
+	grpcclient "github.com/cprakhar/relief-ops/services/api-gateway/grpc_client"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/util"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultJWKSTTL is how long fetched signing keys are used before they are fetched again.
+	DefaultJWKSTTL = 10 * time.Minute
+
+	// jwksMinRefresh is the least time between fetches triggered by unknown key IDs,
+	// so tokens with made-up key IDs cannot flood the user service.
+	jwksMinRefresh = 30 * time.Second
+
+	jwksFetchTimeout = 5 * time.Second
+)
+
+// JWKSCache caches the user service's token signing keys so tokens can be verified locally.
+// Keys are re-fetched once stale, or early when a token names an unknown key, e.g., after a key rotation.
+type JWKSCache struct {
+	ttl     time.Duration
+	fetches singleflight.Group
+
+	mu        sync.Mutex
+	set       *util.JSONWebKeySet
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+var jwks = NewJWKSCache(DefaultJWKSTTL)
+
+// NewJWKSCache creates an empty signing key cache.
+func NewJWKSCache(ttl time.Duration) *JWKSCache {
+	if ttl <= 0 {
+		ttl = DefaultJWKSTTL
+	}
+	return &JWKSCache{ttl: ttl}
+}
+
+// InitJWKS sets how long the signing keys used by JWTAuthMiddleware are cached.
+func InitJWKS(ttl time.Duration) {
+	jwks = NewJWKSCache(ttl)
+}
+
+// JWKS returns the signing key cache used by JWTAuthMiddleware.
+func JWKS() *JWKSCache {
+	return jwks
+}
+
+// PublicKey returns the public key with the given ID.
+func (c *JWKSCache) PublicKey(kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	stale := time.Since(c.fetchedAt) > c.ttl
+	key, ok := c.keys[kid]
+	throttled := time.Since(c.fetchedAt) <= jwksMinRefresh
+	c.mu.Unlock()
+	if !stale && ok {
+		return key, nil
+	}
+
+	if stale || !throttled {
+		if err := c.refresh(); err != nil {
+			// Keep verifying with the keys at hand while the user service is unreachable
+			logs.L().Warnw("Failed to refresh signing keys", "error", err)
+		}
+	}
+
+	c.mu.Lock()
+	key, ok = c.keys[kid]
+	c.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return key, nil
+}
+
+// Set returns the cached signing keys as a JSON Web Key Set.
+func (c *JWKSCache) Set() (*util.JSONWebKeySet, error) {
+	c.mu.Lock()
+	set := c.set
+	stale := time.Since(c.fetchedAt) > c.ttl
+	c.mu.Unlock()
+	if set != nil && !stale {
+		return set, nil
+	}
+
+	err := c.refresh()
+	c.mu.Lock()
+	set = c.set
+	c.mu.Unlock()
+	if err != nil {
+		if set == nil {
+			return nil, err
+		}
+		logs.L().Warnw("Failed to refresh signing keys", "error", err)
+	}
+	return set, nil
+}
+
+// refresh fetches the signing keys from the user service. Concurrent refreshes share one fetch, made without
+// holding c.mu, so requests verifying with cached keys don't wait on a slow user service.
+func (c *JWKSCache) refresh() error {
+	_, err, _ := c.fetches.Do("jwks", func() (any, error) {
+		// Failed attempts count too, so an unreachable user service is not retried on every request
+		c.mu.Lock()
+		c.fetchedAt = time.Now()
+		c.mu.Unlock()
+
+		set, keys, err := fetchJWKS()
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.set = set
+		c.keys = keys
+		c.mu.Unlock()
+		return nil, nil
+	})
+	return err
+}
+
+// fetchJWKS fetches the signing keys from the user service.
+func fetchJWKS() (*util.JSONWebKeySet, map[string]crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+
+	userClient, err := grpcclient.NewUserServiceClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer userClient.Close()
+
+	pbRes, err := userClient.Client.GetJwks(ctx, &pb.GetJwksRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set := &util.JSONWebKeySet{Keys: make([]*util.JSONWebKey, 0, len(pbRes.GetKeys()))}
+	keys := make(map[string]crypto.PublicKey, len(pbRes.GetKeys()))
+	for _, k := range pbRes.GetKeys() {
+		jwk := &util.JSONWebKey{
+			Kid: k.GetKid(),
+			Kty: k.GetKty(),
+			Alg: k.GetAlg(),
+			Use: k.GetUse(),
+			Crv: k.GetCrv(),
+			X:   k.GetX(),
+			N:   k.GetN(),
+			E:   k.GetE(),
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+		keys[jwk.Kid] = key
+	}
+	return set, keys, nil
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cprakhar/relief-ops/services/disaster-service/service"
+	"github.com/cprakhar/relief-ops/shared/events"
+	"github.com/cprakhar/relief-ops/shared/messaging"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+)
+
+type resourceConsumer struct {
+	kafkaClient *messaging.KafkaClient
+	svc         service.DisasterService
+}
+
+// NewResourceConsumer creates a new instance of resourceConsumer.
+func NewResourceConsumer(kc *messaging.KafkaClient, svc service.DisasterService) *resourceConsumer {
+	return &resourceConsumer{kafkaClient: kc, svc: svc}
+}
+
+// Consumer starts consuming messages from the specified topics.
+func (rc *resourceConsumer) Consumer(ctx context.Context, topics []string) error {
+	return rc.kafkaClient.Consume(ctx, topics, func(ctx context.Context, eventType, key string, value []byte) error {
+		switch eventType {
+		case events.ResourceEventFound:
+			if err := rc.handleResourcesFound(ctx, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown event type: %s", eventType)
+		}
+		return nil
+	})
+}
+
+// handleResourcesFound stores the resources found around a disaster on the disaster.
+func (rc *resourceConsumer) handleResourcesFound(ctx context.Context, value []byte) error {
+	var payload events.ResourceEventFoundPayload
+	if err := json.Unmarshal(value, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	if err := rc.svc.SetResourceSnapshot(ctx, payload.DisasterID, payload.Radius, payload.Resources, payload.Counts, payload.FoundAt); err != nil {
+		return fmt.Errorf("failed to store resource snapshot: %w", err)
+	}
+
+	logs.L().Infow("Resource snapshot stored", "disaster_id", payload.DisasterID, "radius", payload.Radius, "resources", len(payload.Resources), "total", payload.Total)
+	return nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/disaster-service/repo"
+	"github.com/cprakhar/relief-ops/services/disaster-service/service"
+	"github.com/cprakhar/relief-ops/shared/events"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	pb "github.com/cprakhar/relief-ops/shared/proto/disaster"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dispatchStatus maps dispatch errors to gRPC status errors.
+func dispatchStatus(err error, action string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidAssignee),
+		errors.Is(err, service.ErrInvalidTransition):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrForbidden):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, repo.ErrNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, service.ErrDisasterNotApproved),
+		errors.Is(err, repo.ErrAlreadyDispatched):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrStatusConflict):
+		return status.Errorf(codes.Aborted, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+	}
+}
+
+// AssignDispatch dispatches a volunteer, mobile resource or team to an approved disaster.
+func (h *gRPCHandler) AssignDispatch(ctx context.Context, req *pb.AssignDispatchRequest) (*pb.Assignment, error) {
+	assignment := &types.Assignment{
+		DisasterID:    req.GetDisasterID(),
+		AssigneeType:  req.GetAssigneeType(),
+		AssigneeID:    req.GetAssigneeID(),
+		AssigneeName:  req.GetAssigneeName(),
+		AssigneeOrgID: req.GetAssigneeOrgID(),
+		Notes:         req.GetNotes(),
+		AssignedBy:    req.GetAdminID(),
+	}
+
+	actor := toPrincipal(req.GetAdminID(), req.GetAdminRole(), req.GetAdminRegions())
+	if _, err := h.svc.AssignDispatch(ctx, assignment, actor); err != nil {
+		return nil, dispatchStatus(err, "assign dispatch")
+	}
+
+	h.publishDispatchUpdate(ctx, assignment)
+	return toPbAssignment(assignment), nil
+}
+
+// UpdateAssignmentStatus records an acknowledgement or progress update on an assignment.
+func (h *gRPCHandler) UpdateAssignmentStatus(ctx context.Context, req *pb.UpdateAssignmentStatusRequest) (*pb.Assignment, error) {
+	actor := toPrincipal(req.GetActorID(), req.GetActorRole(), req.GetActorRegions())
+	assignment, err := h.svc.UpdateAssignmentStatus(ctx, req.GetId(), req.GetStatus(), actor, req.GetActorTeamIDs())
+	if err != nil {
+		return nil, dispatchStatus(err, "update assignment status")
+	}
+
+	h.publishDispatchUpdate(ctx, assignment)
+	return toPbAssignment(assignment), nil
+}
+
+// GetDispatchBoard lists all assignments for a disaster.
+func (h *gRPCHandler) GetDispatchBoard(ctx context.Context, req *pb.GetDispatchBoardRequest) (*pb.GetDispatchBoardResponse, error) {
+	assignments, err := h.svc.GetDispatchBoard(ctx, req.GetDisasterID())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get dispatch board: %v", err)
+	}
+
+	var pbAssignments []*pb.Assignment
+	for _, a := range assignments {
+		pbAssignments = append(pbAssignments, toPbAssignment(a))
+	}
+
+	return &pb.GetDispatchBoardResponse{Assignments: pbAssignments}, nil
+}
+
+// ListAssignments lists the assignments of a volunteer, mobile resource or team.
+func (h *gRPCHandler) ListAssignments(ctx context.Context, req *pb.ListAssignmentsRequest) (*pb.ListAssignmentsResponse, error) {
+	assignments, err := h.svc.ListAssignments(ctx, req.GetAssigneeType(), req.GetAssigneeID(), req.GetTeamIDs(), req.GetActiveOnly())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list assignments: %v", err)
+	}
+
+	var pbAssignments []*pb.Assignment
+	for _, a := range assignments {
+		pbAssignments = append(pbAssignments, toPbAssignment(a))
+	}
+
+	return &pb.ListAssignmentsResponse{Assignments: pbAssignments}, nil
+}
+
+// publishDispatchUpdate emits the latest status change of an assignment.
+// The change is already stored, so a failed publish is logged rather than returned.
+func (h *gRPCHandler) publishDispatchUpdate(ctx context.Context, a *types.Assignment) {
+	logger := logs.L()
+
+	latest := a.History[len(a.History)-1]
+	msg := &events.DispatchEventUpdatedPayload{
+		AssignmentID: a.ID.Hex(),
+		DisasterID:   a.DisasterID,
+		AssigneeType: a.AssigneeType,
+		AssigneeID:   a.AssigneeID,
+		Status:       latest.Status,
+		ChangedBy:    latest.ChangedBy,
+		ChangedAt:    latest.ChangedAt,
+	}
+
+	value, err := json.Marshal(msg)
+	if err != nil {
+		logger.Errorw("Failed to marshal dispatch event", "error", err, "assignment_id", msg.AssignmentID)
+		return
+	}
+
+	if err := h.kafkaClient.Produce(ctx, events.DispatchEventUpdated, a.DisasterID, value); err != nil {
+		logger.Errorw("Failed to publish dispatch event", "error", err, "assignment_id", msg.AssignmentID)
+	}
+}
+
+// toPbAssignment converts an assignment to its protobuf representation.
+func toPbAssignment(a *types.Assignment) *pb.Assignment {
+	var history []*pb.AssignmentProgress
+	for _, p := range a.History {
+		history = append(history, &pb.AssignmentProgress{
+			Status:    p.Status,
+			ChangedBy: p.ChangedBy,
+			ChangedAt: timestamppb.New(p.ChangedAt),
+		})
+	}
+
+	return &pb.Assignment{
+		Id:            a.ID.Hex(),
+		DisasterID:    a.DisasterID,
+		AssigneeType:  a.AssigneeType,
+		AssigneeID:    a.AssigneeID,
+		AssigneeName:  a.AssigneeName,
+		AssigneeOrgID: a.AssigneeOrgID,
+		Status:        a.Status,
+		Active:        a.Active,
+		Notes:         a.Notes,
+		AssignedBy:    a.AssignedBy,
+		History:       history,
+		CreatedAt:     timestamppb.New(a.CreatedAt),
+		UpdatedAt:     timestamppb.New(a.UpdatedAt),
+	}
+}
@@ -3,8 +3,12 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
 
+	"github.com/cprakhar/relief-ops/services/disaster-service/repo"
 	"github.com/cprakhar/relief-ops/services/disaster-service/service"
+	"github.com/cprakhar/relief-ops/shared/authz"
 	"github.com/cprakhar/relief-ops/shared/events"
 	"github.com/cprakhar/relief-ops/shared/messaging"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
@@ -50,6 +54,8 @@ func (h *gRPCHandler) ReportDisaster(ctx context.Context, req *pb.ReportDisaster
 		},
 		VolunteerID: req.GetVolunteerID(),
 		ImageURLs:   req.GetImageURLs(),
+		OrgID:       req.GetOrgID(),
+		Severity:    req.GetSeverity(),
 	}
 
 	// Step 1: Create the disaster in the database
@@ -59,10 +65,13 @@ func (h *gRPCHandler) ReportDisaster(ctx context.Context, req *pb.ReportDisaster
 	}
 
 	// Step 2: Try to publish to Kafka with compensation logic
+	// The resource service picks the search radius from the hazard tags and result density
 	msg := &events.DisasterEventCreatedPayload{
 		DisasterID:  disasterID,
+		Title:       disaster.Title,
+		Severity:    disaster.Severity,
 		Location:    disaster.Location,
-		Range:       10000,
+		Tags:        disaster.Tags,
 		VolunteerID: disaster.VolunteerID,
 	}
 
@@ -72,7 +81,7 @@ func (h *gRPCHandler) ReportDisaster(ctx context.Context, req *pb.ReportDisaster
 	}
 
 	// Notify resource service to find resources around the disaster location
-	logger.Infow("Notifying resource service to find resources", "disaster_id", disasterID, "location", disaster.Location, "range", 10000)
+	logger.Infow("Notifying resource service to find resources", "disaster_id", disasterID, "location", disaster.Location, "tags", disaster.Tags)
 	if err := h.kafkaClient.Produce(ctx, events.ResourceCommandFind, disasterID, value); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to produce resource find command: %v", err)
 	}
@@ -88,50 +97,50 @@ func (h *gRPCHandler) GetDisaster(ctx context.Context, req *pb.GetDisasterReques
 	disasterID := req.GetId()
 	disaster, err := h.svc.GetDisaster(ctx, disasterID)
 	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "disaster not found")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get disaster: %v", err)
 	}
 
-	return &pb.GetDisasterResponse{
-		Id:          disaster.ID.Hex(),
-		Title:       disaster.Title,
-		Description: disaster.Description,
-		Tags:        disaster.Tags,
-		VolunteerID: disaster.VolunteerID,
-		CreatedAt:   timestamppb.New(disaster.CreatedAt),
-		UpdatedAt:   timestamppb.New(disaster.UpdatedAt),
-		ImageURLs:   disaster.ImageURLs,
-		Location: &pb.Coordinates{
-			Latitude:  disaster.Location.Latitude,
-			Longitude: disaster.Location.Longitude,
-		},
-		Status: disaster.Status,
-	}, nil
+	pbDisaster := toPbDisaster(disaster)
+	for _, r := range disaster.Resources {
+		pbDisaster.Resources = append(pbDisaster.Resources, &pb.Resource{
+			Id:   r.ID,
+			Name: r.Name,
+			Type: r.Category,
+			Location: &pb.Coordinates{
+				Latitude:  r.Location.Latitude,
+				Longitude: r.Location.Longitude,
+			},
+			Distance: r.Distance,
+		})
+	}
+
+	return pbDisaster, nil
 }
 
-// ListDisasters retrieves all disasters, optionally filtered by status.
+// ListDisasters retrieves all disasters, optionally filtered by status and bounding box.
 func (h *gRPCHandler) ListDisasters(ctx context.Context, req *pb.ListDisastersRequest) (*pb.ListDisastersResponse, error) {
-	disasters, err := h.svc.GetAllDisasters(ctx, req.GetStatus())
+	var bounds *types.Bounds
+	if b := req.GetBounds(); b != nil {
+		bounds = &types.Bounds{
+			MinLat: b.GetMinLat(),
+			MinLon: b.GetMinLon(),
+			MaxLat: b.GetMaxLat(),
+			MaxLon: b.GetMaxLon(),
+		}
+	}
+
+	disasters, err := h.svc.GetAllDisasters(ctx, req.GetStatus(), bounds)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list disasters: %v", err)
 	}
 
+	// Listed disasters carry resource counts only; the linked resources come with GetDisaster
 	var pbDisasters []*pb.GetDisasterResponse
 	for _, d := range disasters {
-		pbDisasters = append(pbDisasters, &pb.GetDisasterResponse{
-			Id:          d.ID.Hex(),
-			Title:       d.Title,
-			Description: d.Description,
-			Tags:        d.Tags,
-			VolunteerID: d.VolunteerID,
-			CreatedAt:   timestamppb.New(d.CreatedAt),
-			UpdatedAt:   timestamppb.New(d.UpdatedAt),
-			ImageURLs:   d.ImageURLs,
-			Location: &pb.Coordinates{
-				Latitude:  d.Location.Latitude,
-				Longitude: d.Location.Longitude,
-			},
-			Status: d.Status,
-		})
+		pbDisasters = append(pbDisasters, toPbDisaster(d))
 	}
 
 	return &pb.ListDisastersResponse{Disasters: pbDisasters}, nil
@@ -139,12 +148,90 @@ func (h *gRPCHandler) ListDisasters(ctx context.Context, req *pb.ListDisastersRe
 
 // ReviewDisaster handles the review of a reported disaster.
 func (h *gRPCHandler) ReviewDisaster(ctx context.Context, req *pb.ReviewDisasterRequest) (*pb.ReviewDisasterResponse, error) {
-	if err := h.svc.UpdateStatus(ctx, req.GetId(), req.GetStatus()); err != nil {
+	reviewer := toPrincipal(req.GetAdminID(), req.GetAdminRole(), req.GetAdminRegions())
+	disaster, err := h.svc.ReviewDisaster(ctx, req.GetId(), req.GetStatus(), reviewer)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update disaster status: %v", err)
 	}
 
+	if disaster.Status == types.DisasterApproved {
+		h.publishDisasterApproved(ctx, disaster, req.GetAdminID())
+	}
+
 	return &pb.ReviewDisasterResponse{
 		Id:     req.GetId(),
 		Status: req.GetStatus(),
 	}, nil
 }
+
+// publishDisasterApproved announces an approved disaster, e.g., to alert users subscribed to its area.
+// The approval is already stored, so a failed publish is logged rather than returned.
+func (h *gRPCHandler) publishDisasterApproved(ctx context.Context, d *types.Disaster, approvedBy string) {
+	logger := logs.L()
+
+	msg := &events.DisasterEventApprovedPayload{
+		DisasterID: d.ID.Hex(),
+		Title:      d.Title,
+		Location:   d.Location,
+		Tags:       d.Tags,
+		OrgID:      d.OrgID,
+		ApprovedBy: approvedBy,
+		ApprovedAt: time.Now(),
+	}
+
+	value, err := json.Marshal(msg)
+	if err != nil {
+		logger.Errorw("Failed to marshal disaster approved event", "error", err, "disaster_id", msg.DisasterID)
+		return
+	}
+
+	if err := h.kafkaClient.Produce(ctx, events.DisasterEventApproved, msg.DisasterID, value); err != nil {
+		logger.Errorw("Failed to publish disaster approved event", "error", err, "disaster_id", msg.DisasterID)
+	}
+}
+
+// toPrincipal converts the identity of the user a request is made by to an authorization principal.
+func toPrincipal(userID, role string, pbRegions []*pb.Region) *authz.Principal {
+	principal := &authz.Principal{UserID: userID, Role: types.Role(role)}
+	for _, r := range pbRegions {
+		region := types.Region{Name: r.GetName()}
+		for _, c := range r.GetPolygon() {
+			region.Polygon = append(region.Polygon, types.Coordinates{Latitude: c.GetLatitude(), Longitude: c.GetLongitude()})
+		}
+		principal.Regions = append(principal.Regions, region)
+	}
+	return principal
+}
+
+// toPbDisaster converts a disaster to its protobuf representation, without its linked resources.
+func toPbDisaster(d *types.Disaster) *pb.GetDisasterResponse {
+	pbDisaster := &pb.GetDisasterResponse{
+		Id:          d.ID.Hex(),
+		Title:       d.Title,
+		Description: d.Description,
+		Tags:        d.Tags,
+		VolunteerID: d.VolunteerID,
+		CreatedAt:   timestamppb.New(d.CreatedAt),
+		UpdatedAt:   timestamppb.New(d.UpdatedAt),
+		ImageURLs:   d.ImageURLs,
+		Location: &pb.Coordinates{
+			Latitude:  d.Location.Latitude,
+			Longitude: d.Location.Longitude,
+		},
+		Status:         d.Status,
+		ResourceCounts: d.ResourceCounts,
+		SearchRadius:   int64(d.SearchRadius),
+		OrgID:          d.OrgID,
+		Severity:       d.Severity,
+	}
+	if !d.ResourcesFoundAt.IsZero() {
+		pbDisaster.ResourcesFoundAt = timestamppb.New(d.ResourcesFoundAt)
+	}
+	return pbDisaster
+}
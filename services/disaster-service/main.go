@@ -5,16 +5,20 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/cprakhar/relief-ops/services/disaster-service/event"
 	"github.com/cprakhar/relief-ops/services/disaster-service/repo"
 	"github.com/cprakhar/relief-ops/services/disaster-service/service"
 	"github.com/cprakhar/relief-ops/shared/db"
 	"github.com/cprakhar/relief-ops/shared/env"
+	"github.com/cprakhar/relief-ops/shared/events"
 	"github.com/cprakhar/relief-ops/shared/messaging"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/observe/traces"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
 )
 
 var (
@@ -31,6 +35,15 @@ var (
 	mongoMaxPool = uint64(10)
 	mongoMinPool = uint64(2)
 
+	// Redis configuration
+	redisAddr     = env.GetString("REDIS_ADDR", "redis-db:6379")
+	redisUsername = env.GetString("REDIS_USERNAME", "")
+	redisMaxConn  = env.GetInt("REDIS_MAX_CONN", 10)
+	redisMinIdle  = env.GetInt("REDIS_MIN_IDLE", 2)
+	redisMaxIdle  = env.GetInt("REDIS_MAX_IDLE", 5)
+	redisPassword = env.GetString("REDIS_PASSWORD", "")
+	redisDB       = env.GetInt("REDIS_DB", 0)
+
 	// OTLP configuration
 	otlpEndpoint = env.GetString("OTLP_ENDPOINT", "otel-collector:4317")
 	otlpInsecure = env.GetBool("OTLP_INSECURE", true)
@@ -71,6 +84,20 @@ func main() {
 	}()
 	logger.Info("Tracing initialized")
 
+	redisCfg := &db.RedisConfig{
+		Addr:           redisAddr,
+		Username:       redisUsername,
+		Password:       redisPassword,
+		DB:             int(redisDB),
+		MaxActiveConns: int(redisMaxConn),
+		MaxIdleConns:   int(redisMaxIdle),
+		MinIdleConns:   int(redisMinIdle),
+	}
+	if err := db.InitRedis(redisCfg); err != nil {
+		logger.Fatalw("Failed to connect to Redis", "error", err)
+	}
+	logger.Info("Connected to Redis")
+
 	// Initialize MongoDB client
 	mongoCfg := &db.MongoDBConfig{
 		URI:        mongoURI,
@@ -106,20 +133,40 @@ func main() {
 	if err != nil {
 		logger.Fatalw("Failed to create disaster repository", "error", err)
 	}
-	userService := service.NewDisasterService(userRepo)
+	dispatchRepo, err := repo.NewDispatchRepo(ctx, mongoClient.Database().Collection("assignments"))
+	if err != nil {
+		logger.Fatalw("Failed to create dispatch repository", "error", err)
+	}
+	// Map tiles are rendered by the API gateway; the service only invalidates them
+	tileCache := tilecache.New(db.GetRedisClient(), tilecache.DefaultTTL)
+	userService := service.NewDisasterService(userRepo, dispatchRepo, tileCache)
+
+	// Initialize and start the resource consumer
+	topics := []string{events.ResourceEventFound}
+	resourceConsumer := event.NewResourceConsumer(kafkaClient, userService)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := resourceConsumer.Consumer(ctx, topics); err != nil {
+			logger.Errorw("Error in resource consumer", "error", err)
+		}
+	}()
 
 	// Initialize and run the gRPC server
 	gRPCServer := newgRPCServer(addr, userService, kafkaClient)
 
-	done := make(chan struct{})
+	wg.Add(1)
 	go func() {
-		defer close(done)
+		defer wg.Done()
 		logger.Infow("Disaster service running", "addr", addr)
 		if err := gRPCServer.run(ctx); err != nil {
 			logger.Errorw("gRPC server error", "error", err)
 		}
 	}()
 	<-ctx.Done()
-	<-done
+	wg.Wait()
 	logger.Info("Disaster service stopped")
 }
@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var (
+	ErrAlreadyDispatched = fmt.Errorf("assignee already has an active assignment")
+	ErrStatusConflict    = fmt.Errorf("assignment status changed concurrently")
+)
+
+type mongodbDispatchRepo struct {
+	db *mongo.Collection
+}
+
+// DispatchRepo defines the interface for dispatch assignment repository operations.
+type DispatchRepo interface {
+	Create(ctx context.Context, assignment *types.Assignment) (string, error)
+	GetByID(ctx context.Context, id string) (*types.Assignment, error)
+	ListByDisaster(ctx context.Context, disasterID string) ([]*types.Assignment, error)
+	ListByAssignee(ctx context.Context, assigneeType, assigneeID string, teamIDs []string, activeOnly bool) ([]*types.Assignment, error)
+	UpdateStatus(ctx context.Context, id, from string, progress types.AssignmentProgress) (*types.Assignment, error)
+}
+
+// NewDispatchRepo creates a new instance of mongodbDispatchRepo.
+func NewDispatchRepo(ctx context.Context, db *mongo.Collection) (DispatchRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "disaster_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("disaster_id_created_at"),
+		},
+		{
+			// An assignee can only be dispatched to one disaster at a time
+			Keys: bson.D{{Key: "assignee_type", Value: 1}, {Key: "assignee_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"active": true}).
+				SetName("assignee_active_unique"),
+		},
+	}
+
+	if _, err := db.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	return &mongodbDispatchRepo{db: db}, nil
+}
+
+// Create adds a new assignment.
+func (r *mongodbDispatchRepo) Create(ctx context.Context, assignment *types.Assignment) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	assignment.CreatedAt = time.Now()
+	assignment.UpdatedAt = time.Now()
+	assignment.Active = assignment.Status != types.DispatchReleased
+
+	res, err := r.db.InsertOne(ctx, assignment)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", ErrAlreadyDispatched
+		}
+		return "", err
+	}
+
+	assignment.ID = res.InsertedID.(bson.ObjectID)
+	return assignment.ID.Hex(), nil
+}
+
+// GetByID retrieves an assignment by its ID.
+func (r *mongodbDispatchRepo) GetByID(ctx context.Context, id string) (*types.Assignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var assignment types.Assignment
+	if err := r.db.FindOne(ctx, bson.M{"_id": oid}).Decode(&assignment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// ListByDisaster retrieves all assignments for a disaster, oldest first.
+func (r *mongodbDispatchRepo) ListByDisaster(ctx context.Context, disasterID string) ([]*types.Assignment, error) {
+	return r.find(ctx, bson.M{"disaster_id": disasterID})
+}
+
+// ListByAssignee retrieves the assignments of a volunteer, resource or team along with those of the given teams,
+// optionally only unreleased ones.
+func (r *mongodbDispatchRepo) ListByAssignee(ctx context.Context, assigneeType, assigneeID string, teamIDs []string, activeOnly bool) ([]*types.Assignment, error) {
+	filter := bson.M{"assignee_type": assigneeType, "assignee_id": assigneeID}
+	if len(teamIDs) > 0 {
+		filter = bson.M{"$or": bson.A{
+			filter,
+			bson.M{"assignee_type": types.AssigneeTeam, "assignee_id": bson.M{"$in": teamIDs}},
+		}}
+	}
+	if activeOnly {
+		filter["active"] = true
+	}
+	return r.find(ctx, filter)
+}
+
+// UpdateStatus moves an assignment from one status to another and appends the change to its history.
+// It fails with ErrStatusConflict if the assignment is no longer in the expected status.
+func (r *mongodbDispatchRepo) UpdateStatus(ctx context.Context, id, from string, progress types.AssignmentProgress) (*types.Assignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	filter := bson.M{"_id": oid, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     progress.Status,
+			"active":     progress.Status != types.DispatchReleased,
+			"updated_at": progress.ChangedAt,
+		},
+		"$push": bson.M{"history": progress},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var assignment types.Assignment
+	if err := r.db.FindOneAndUpdate(ctx, filter, update, opts).Decode(&assignment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrStatusConflict
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *mongodbDispatchRepo) find(ctx context.Context, filter bson.M) ([]*types.Assignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var assignments []*types.Assignment
+	if err := cursor.All(ctx, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
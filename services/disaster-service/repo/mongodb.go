@@ -25,9 +25,10 @@ type mongodbDisasterRepo struct {
 type DisasterRepo interface {
 	Create(ctx context.Context, disaster *types.Disaster) (string, error)
 	GetByID(ctx context.Context, id string) (*types.Disaster, error)
-	GetAll(ctx context.Context, status string) ([]*types.Disaster, error)
+	GetAll(ctx context.Context, status string, bounds *types.Bounds) ([]*types.Disaster, error)
 	Delete(ctx context.Context, disasterID string) error
 	UpdateStatus(ctx context.Context, disasterID, status string) error
+	SetResources(ctx context.Context, disasterID string, radius int, resources []types.ResourceSnapshot, counts map[string]int64, foundAt time.Time) error
 }
 
 // NewMongodbDisasterRepo creates a new instance of mongodbDisasterRepo.
@@ -42,7 +43,17 @@ func NewMongodbDisasterRepo(ctx context.Context, db *mongo.Collection) (Disaster
 			SetName("created_at_ttl"),
 	}
 
-	_, err := db.Indexes().CreateOne(ctx, ttlIndexModel)
+	// Create index used to find disasters within a bounding box, e.g., a map tile
+	boundsIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "location.latitude", Value: 1},
+			{Key: "location.longitude", Value: 1},
+		},
+		Options: options.Index().SetName("status_location"),
+	}
+
+	_, err := db.Indexes().CreateMany(ctx, []mongo.IndexModel{ttlIndexModel, boundsIndexModel})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create indexes: %v", err)
 	}
@@ -56,7 +67,7 @@ func (r *mongodbDisasterRepo) Create(ctx context.Context, disaster *types.Disast
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
-	disaster.Status = "pending"
+	disaster.Status = types.DisasterPending
 	disaster.CreatedAt = time.Now()
 	disaster.UpdatedAt = time.Now()
 
@@ -73,8 +84,13 @@ func (r *mongodbDisasterRepo) Delete(ctx context.Context, disasterID string) err
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
+	oid, err := bson.ObjectIDFromHex(disasterID)
+	if err != nil {
+		return ErrNotFound
+	}
+
 	filter := bson.M{
-		"_id": disasterID,
+		"_id": oid,
 	}
 
 	res := r.db.FindOneAndDelete(ctx, filter)
@@ -90,13 +106,21 @@ func (r *mongodbDisasterRepo) GetByID(ctx context.Context, id string) (*types.Di
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
 	var disaster types.Disaster
 	filter := bson.M{
-		"_id": id,
+		"_id": oid,
 	}
 
-	err := r.db.FindOne(ctx, filter).Decode(&disaster)
+	err = r.db.FindOne(ctx, filter).Decode(&disaster)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 	return &disaster, nil
@@ -114,8 +138,13 @@ func (r *mongodbDisasterRepo) UpdateStatus(ctx context.Context, disasterID, stat
 		},
 	}
 
+	oid, err := bson.ObjectIDFromHex(disasterID)
+	if err != nil {
+		return ErrNotFound
+	}
+
 	filter := bson.M{
-		"_id": disasterID,
+		"_id": oid,
 	}
 
 	res := r.db.FindOneAndUpdate(ctx, filter, update)
@@ -127,8 +156,8 @@ func (r *mongodbDisasterRepo) UpdateStatus(ctx context.Context, disasterID, stat
 	}
 }
 
-// GetAll retrieves all disaster entries, optionally filtered by status.
-func (r *mongodbDisasterRepo) GetAll(ctx context.Context, status string) ([]*types.Disaster, error) {
+// GetAll retrieves all disaster entries, optionally filtered by status and bounding box.
+func (r *mongodbDisasterRepo) GetAll(ctx context.Context, status string, bounds *types.Bounds) ([]*types.Disaster, error) {
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
@@ -136,6 +165,10 @@ func (r *mongodbDisasterRepo) GetAll(ctx context.Context, status string) ([]*typ
 	if status != "" {
 		filter["status"] = status
 	}
+	if bounds != nil {
+		filter["location.latitude"] = bson.M{"$gte": bounds.MinLat, "$lte": bounds.MaxLat}
+		filter["location.longitude"] = bson.M{"$gte": bounds.MinLon, "$lte": bounds.MaxLon}
+	}
 
 	cursor, err := r.db.Find(ctx, filter)
 	if err != nil {
@@ -158,3 +191,37 @@ func (r *mongodbDisasterRepo) GetAll(ctx context.Context, status string) ([]*typ
 
 	return disasters, nil
 }
+
+// SetResources stores the snapshot of resources found around a disaster and the radius they were searched within.
+// Snapshots older than the stored one are ignored, so redelivered events cannot roll it back,
+// as are snapshots of disasters that no longer exist.
+func (r *mongodbDisasterRepo) SetResources(ctx context.Context, disasterID string, radius int, resources []types.ResourceSnapshot, counts map[string]int64, foundAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(disasterID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	filter := bson.M{
+		"_id": oid,
+		"$or": bson.A{
+			bson.M{"resources_found_at": bson.M{"$exists": false}},
+			bson.M{"resources_found_at": bson.M{"$lte": foundAt}},
+		},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"search_radius":      radius,
+			"resources":          resources,
+			"resource_counts":    counts,
+			"resources_found_at": foundAt,
+			"updated_at":         time.Now(),
+		},
+	}
+
+	_, err = r.db.UpdateOne(ctx, filter, update)
+	return err
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/cprakhar/relief-ops/services/disaster-service/repo"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
 	"github.com/cprakhar/relief-ops/shared/types"
 )
 
 type disasterService struct {
-	repo repo.DisasterRepo
+	repo     repo.DisasterRepo
+	dispatch repo.DispatchRepo
+	tiles    *tilecache.Cache
 }
 
 // DisasterService defines the interface for disaster service operations.
@@ -16,28 +22,68 @@ type DisasterService interface {
 	CreateDisaster(ctx context.Context, disaster *types.Disaster) (string, error)
 	DeleteDisaster(ctx context.Context, disasterID string) error
 	GetDisaster(ctx context.Context, disasterID string) (*types.Disaster, error)
-	GetAllDisasters(ctx context.Context, status string) ([]*types.Disaster, error)
+	GetAllDisasters(ctx context.Context, status string, bounds *types.Bounds) ([]*types.Disaster, error)
 	UpdateStatus(ctx context.Context, disasterID, status string) error
+	ReviewDisaster(ctx context.Context, disasterID, status string, reviewer *authz.Principal) (*types.Disaster, error)
+	SetResourceSnapshot(ctx context.Context, disasterID string, radius int, resources []types.ResourceSnapshot, counts map[string]int64, foundAt time.Time) error
+	AssignDispatch(ctx context.Context, assignment *types.Assignment, actor *authz.Principal) (string, error)
+	UpdateAssignmentStatus(ctx context.Context, assignmentID, status string, actor *authz.Principal, actorTeamIDs []string) (*types.Assignment, error)
+	GetDispatchBoard(ctx context.Context, disasterID string) ([]*types.Assignment, error)
+	ListAssignments(ctx context.Context, assigneeType, assigneeID string, teamIDs []string, activeOnly bool) ([]*types.Assignment, error)
 }
 
 // NewDisasterService creates a new instance of disasterService.
-func NewDisasterService(r repo.DisasterRepo) *disasterService {
-	return &disasterService{repo: r}
+// The tile cache is optional; when set, cached disaster tiles are invalidated as disasters change.
+func NewDisasterService(r repo.DisasterRepo, dr repo.DispatchRepo, tc *tilecache.Cache) *disasterService {
+	return &disasterService{repo: r, dispatch: dr, tiles: tc}
 }
 
 // CreateDisaster creates a new disaster entry.
 func (s *disasterService) CreateDisaster(ctx context.Context, disaster *types.Disaster) (string, error) {
+	if disaster.Severity == "" {
+		disaster.Severity = types.SeverityMedium
+	}
 	return s.repo.Create(ctx, disaster)
 }
 
 // DeleteDisaster deletes a disaster entry by its ID.
 func (s *disasterService) DeleteDisaster(ctx context.Context, disasterID string) error {
-	return s.repo.Delete(ctx, disasterID)
+	if err := s.repo.Delete(ctx, disasterID); err != nil {
+		return err
+	}
+	s.invalidateTiles(ctx)
+	return nil
 }
 
 // UpdateStatus updates the status of a disaster entry.
 func (s *disasterService) UpdateStatus(ctx context.Context, disasterID, status string) error {
-	return s.repo.UpdateStatus(ctx, disasterID, status)
+	if err := s.repo.UpdateStatus(ctx, disasterID, status); err != nil {
+		return err
+	}
+	s.invalidateTiles(ctx)
+	return nil
+}
+
+// ReviewDisaster approves or rejects a reported disaster on behalf of a reviewer allowed to review
+// disasters at its location. It returns the disaster with its new status.
+func (s *disasterService) ReviewDisaster(ctx context.Context, disasterID, status string, reviewer *authz.Principal) (*types.Disaster, error) {
+	disaster, err := s.repo.GetByID(ctx, disasterID)
+	if err != nil {
+		return nil, err
+	}
+	if !authz.DefaultPolicy.AllowedAt(reviewer, authz.ReviewDisasters, disaster.Location) {
+		return nil, ErrForbidden
+	}
+	if err := s.UpdateStatus(ctx, disasterID, status); err != nil {
+		return nil, err
+	}
+	disaster.Status = status
+	return disaster, nil
+}
+
+// SetResourceSnapshot links the resources found around a disaster to it, along with the search radius.
+func (s *disasterService) SetResourceSnapshot(ctx context.Context, disasterID string, radius int, resources []types.ResourceSnapshot, counts map[string]int64, foundAt time.Time) error {
+	return s.repo.SetResources(ctx, disasterID, radius, resources, counts, foundAt)
 }
 
 // GetDisaster retrieves a disaster entry by its ID.
@@ -45,7 +91,15 @@ func (s *disasterService) GetDisaster(ctx context.Context, disasterID string) (*
 	return s.repo.GetByID(ctx, disasterID)
 }
 
-// GetAllDisasters retrieves all disaster entries, optionally filtered by status.
-func (s *disasterService) GetAllDisasters(ctx context.Context, status string) ([]*types.Disaster, error) {
-	return s.repo.GetAll(ctx, status)
+// GetAllDisasters retrieves all disaster entries, optionally filtered by status and bounding box.
+func (s *disasterService) GetAllDisasters(ctx context.Context, status string, bounds *types.Bounds) ([]*types.Disaster, error) {
+	return s.repo.GetAll(ctx, status, bounds)
+}
+
+// invalidateTiles discards cached disaster tiles after a disaster changed.
+// A failure only delays the change showing on the map, so it is logged rather than returned.
+func (s *disasterService) invalidateTiles(ctx context.Context) {
+	if err := s.tiles.Invalidate(ctx, tilecache.LayerDisasters); err != nil {
+		logs.L().Warnw("Failed to invalidate disaster tiles", "error", err)
+	}
 }
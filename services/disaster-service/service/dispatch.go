@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+var (
+	ErrDisasterNotApproved = errors.New("disaster is not approved")
+	ErrInvalidAssignee     = errors.New("invalid assignee")
+	ErrInvalidTransition   = errors.New("invalid assignment status transition")
+	ErrForbidden           = errors.New("permission denied")
+)
+
+// dispatchTransitions lists the statuses an assignment may move to from each status.
+// An assignment can be released at any point; released is final.
+var dispatchTransitions = map[string][]string{
+	types.DispatchAssigned:     {types.DispatchAcknowledged, types.DispatchReleased},
+	types.DispatchAcknowledged: {types.DispatchEnRoute, types.DispatchReleased},
+	types.DispatchEnRoute:      {types.DispatchOnScene, types.DispatchReleased},
+	types.DispatchOnScene:      {types.DispatchReleased},
+}
+
+// AssignDispatch dispatches a volunteer, mobile resource or team to an approved disaster on behalf of an actor
+// allowed to dispatch at the disaster's location.
+func (s *disasterService) AssignDispatch(ctx context.Context, assignment *types.Assignment, actor *authz.Principal) (string, error) {
+	if !slices.Contains(types.AssigneeTypes, assignment.AssigneeType) {
+		return "", fmt.Errorf("%w: unknown assignee type %q", ErrInvalidAssignee, assignment.AssigneeType)
+	}
+	if assignment.AssigneeID == "" {
+		return "", fmt.Errorf("%w: missing assignee id", ErrInvalidAssignee)
+	}
+	switch {
+	case assignment.AssigneeType == types.AssigneeTeam && assignment.AssigneeOrgID == "":
+		return "", fmt.Errorf("%w: missing organization of the team", ErrInvalidAssignee)
+	case assignment.AssigneeType != types.AssigneeTeam:
+		assignment.AssigneeOrgID = ""
+	}
+
+	disaster, err := s.repo.GetByID(ctx, assignment.DisasterID)
+	if err != nil {
+		return "", err
+	}
+	if !authz.DefaultPolicy.AllowedAt(actor, authz.Dispatch, disaster.Location) {
+		return "", ErrForbidden
+	}
+	if disaster.Status != types.DisasterApproved {
+		return "", ErrDisasterNotApproved
+	}
+
+	assignment.Status = types.DispatchAssigned
+	assignment.History = []types.AssignmentProgress{{
+		Status:    types.DispatchAssigned,
+		ChangedBy: assignment.AssignedBy,
+		ChangedAt: time.Now(),
+	}}
+
+	return s.dispatch.Create(ctx, assignment)
+}
+
+// UpdateAssignmentStatus moves an assignment along its lifecycle. Volunteers may update their own assignments
+// and those of their teams; anyone allowed to dispatch at the disaster's location may update any of its
+// assignments. Mobile resources have no account, so dispatchers act for them.
+func (s *disasterService) UpdateAssignmentStatus(ctx context.Context, assignmentID, status string, actor *authz.Principal, actorTeamIDs []string) (*types.Assignment, error) {
+	assignment, err := s.dispatch.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var own bool
+	switch assignment.AssigneeType {
+	case types.AssigneeVolunteer:
+		own = assignment.AssigneeID == actor.UserID
+	case types.AssigneeTeam:
+		own = slices.Contains(actorTeamIDs, assignment.AssigneeID)
+	}
+	if !own {
+		if !authz.DefaultPolicy.Has(actor.Role, authz.Dispatch) {
+			return nil, ErrForbidden
+		}
+		disaster, err := s.repo.GetByID(ctx, assignment.DisasterID)
+		if err != nil {
+			return nil, err
+		}
+		if !authz.DefaultPolicy.AllowedAt(actor, authz.Dispatch, disaster.Location) {
+			return nil, ErrForbidden
+		}
+	}
+
+	if !slices.Contains(dispatchTransitions[assignment.Status], status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, assignment.Status, status)
+	}
+
+	progress := types.AssignmentProgress{
+		Status:    status,
+		ChangedBy: actor.UserID,
+		ChangedAt: time.Now(),
+	}
+	return s.dispatch.UpdateStatus(ctx, assignmentID, assignment.Status, progress)
+}
+
+// GetDispatchBoard retrieves all assignments for a disaster, oldest first.
+func (s *disasterService) GetDispatchBoard(ctx context.Context, disasterID string) ([]*types.Assignment, error) {
+	return s.dispatch.ListByDisaster(ctx, disasterID)
+}
+
+// ListAssignments retrieves the assignments of a volunteer, mobile resource or team, along with those of the
+// given teams, e.g., the teams a volunteer belongs to.
+func (s *disasterService) ListAssignments(ctx context.Context, assigneeType, assigneeID string, teamIDs []string, activeOnly bool) ([]*types.Assignment, error) {
+	return s.dispatch.ListByAssignee(ctx, assigneeType, assigneeID, teamIDs, activeOnly)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cprakhar/relief-ops/shared/events"
 	"github.com/cprakhar/relief-ops/shared/messaging"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
 )
 
 type disasterConsumer struct {
@@ -46,11 +47,17 @@ func (dc *disasterConsumer) handleFindResources(ctx context.Context, value []byt
 		return fmt.Errorf("failed to unmarshal payload: %w", err)
 	}
 
-	logger.Infow("Finding resources", "disaster_id", payload.DisasterID, "location", payload.Location, "range", payload.Range)
-	if err := dc.svc.SaveResources(ctx, payload.Range, payload.Location.Latitude, payload.Location.Longitude); err != nil {
+	logger.Infow("Finding resources", "disaster_id", payload.DisasterID, "location", payload.Location, "range", payload.Range, "tags", payload.Tags)
+	region, err := dc.svc.TrackDisaster(ctx, payload.DisasterID, payload.Tags, payload.Range, payload.Location.Latitude, payload.Location.Longitude)
+	if err != nil {
 		return fmt.Errorf("failed to save resources: %w", err)
 	}
-	logger.Infow("Resources saved successfully", "disaster_id", payload.DisasterID)
+	logger.Infow("Resources saved successfully", "disaster_id", payload.DisasterID, "radius", region.Radius, "found", region.Found, "closed", region.Closed)
+
+	// Let the disaster service link the matched resources to the disaster
+	if err := dc.publishResourcesFound(ctx, payload.DisasterID, region); err != nil {
+		return err
+	}
 
 	// If Successful, Notify User Service to notify admin to review
 	if err := dc.kafkaClient.Produce(ctx, events.UserNotifyAdminReview, payload.DisasterID, value); err != nil {
@@ -59,3 +66,42 @@ func (dc *disasterConsumer) handleFindResources(ctx context.Context, value []byt
 	logger.Infow("Notified user service for admin review", "disaster_id", payload.DisasterID)
 	return nil
 }
+
+// publishResourcesFound publishes the resources matched around a disaster.
+func (dc *disasterConsumer) publishResourcesFound(ctx context.Context, disasterID string, region *types.RefreshRegion) error {
+	summary, err := dc.svc.SummarizeRegion(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to summarize resources: %w", err)
+	}
+
+	found := &events.ResourceEventFoundPayload{
+		DisasterID: disasterID,
+		Radius:     region.Radius,
+		Counts:     summary.Counts,
+		Total:      summary.Total,
+		FoundAt:    region.LastRefreshAt,
+	}
+	for _, r := range summary.Resources {
+		found.Resources = append(found.Resources, types.ResourceSnapshot{
+			ID:       r.ID.Hex(),
+			Name:     r.Name,
+			Category: r.AmenityType,
+			Location: types.Coordinates{
+				Latitude:  r.Location.Coordinates[1],
+				Longitude: r.Location.Coordinates[0],
+			},
+			Distance: r.Distance,
+		})
+	}
+
+	value, err := json.Marshal(found)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resources found payload: %w", err)
+	}
+
+	if err := dc.kafkaClient.Produce(ctx, events.ResourceEventFound, disasterID, value); err != nil {
+		return fmt.Errorf("failed to publish resources found: %w", err)
+	}
+	logs.L().Infow("Published resources found", "disaster_id", disasterID, "total", summary.Total)
+	return nil
+}
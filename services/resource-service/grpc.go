@@ -34,7 +34,7 @@ func (s *gRPCServer) run(ctx context.Context) error {
 
 	// Create a new gRPC server
 	srv := grpc.NewServer(traces.WithTracingInterceptors()...)
-	handler.NewResourcegRPCHandler(srv, s.svc)
+	handler.NewResourcegRPCHandler(srv, s.svc, s.kc)
 
 	// Listen for incoming requests in a separate goroutine
 	errChan := make(chan error, 1)
@@ -2,51 +2,236 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/cprakhar/relief-ops/services/resource-service/repo"
 	"github.com/cprakhar/relief-ops/services/resource-service/service"
+	"github.com/cprakhar/relief-ops/shared/messaging"
 	pb "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type gRPCHandler struct {
 	pb.UnimplementedResourceServiceServer
-	svc service.ResourceService
+	svc         service.ResourceService
+	kafkaClient *messaging.KafkaClient
 }
 
 type GrpcHandler interface {
 	GetNearbyResources(ctx context.Context, req *pb.GetResourcesRequest) (*pb.GetResourcesResponse, error)
+	GetResource(ctx context.Context, req *pb.GetResourceRequest) (*pb.Resource, error)
+	RankResourcesByTravelTime(ctx context.Context, req *pb.RankResourcesRequest) (*pb.RankResourcesResponse, error)
+	BlockRoad(ctx context.Context, req *pb.BlockRoadRequest) (*pb.BlockedRoad, error)
+	UnblockRoad(ctx context.Context, req *pb.UnblockRoadRequest) (*pb.UnblockRoadResponse, error)
+	ListBlockedRoads(ctx context.Context, req *pb.ListBlockedRoadsRequest) (*pb.ListBlockedRoadsResponse, error)
+	CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.Resource, error)
+	SetInventory(ctx context.Context, req *pb.SetInventoryRequest) (*pb.Resource, error)
+	DeclareNeed(ctx context.Context, req *pb.DeclareNeedRequest) (*pb.Need, error)
+	ListNeeds(ctx context.Context, req *pb.ListNeedsRequest) (*pb.ListNeedsResponse, error)
+	MatchNeeds(ctx context.Context, req *pb.MatchNeedsRequest) (*pb.MatchNeedsResponse, error)
+	CommitSupply(ctx context.Context, req *pb.CommitSupplyRequest) (*pb.SupplyCommitment, error)
+	ListRefreshRegions(ctx context.Context, req *pb.ListRefreshRegionsRequest) (*pb.ListRefreshRegionsResponse, error)
+	WatchRegion(ctx context.Context, req *pb.WatchRegionRequest) (*pb.RefreshRegion, error)
+	UnwatchRegion(ctx context.Context, req *pb.UnwatchRegionRequest) (*pb.UnwatchRegionResponse, error)
+	TriggerRefresh(ctx context.Context, req *pb.TriggerRefreshRequest) (*pb.RefreshRegion, error)
+	GetResourcesInBounds(ctx context.Context, req *pb.GetResourcesInBoundsRequest) (*pb.GetResourcesResponse, error)
 }
 
 // NewResourcegRPCHandler registers the gRPC handler for the ResourceService.
-func NewResourcegRPCHandler(srv *grpc.Server, svc service.ResourceService) {
-	handler := &gRPCHandler{svc: svc}
+func NewResourcegRPCHandler(srv *grpc.Server, svc service.ResourceService, kc *messaging.KafkaClient) {
+	handler := &gRPCHandler{svc: svc, kafkaClient: kc}
 	pb.RegisterResourceServiceServer(srv, handler)
 }
 
-// GetNearbyResources handles requests to fetch nearby resources based on given coordinates and radius.
+// GetNearbyResources handles requests to fetch nearby resources based on given coordinates and radius, nearest first.
 func (h *gRPCHandler) GetNearbyResources(ctx context.Context, req *pb.GetResourcesRequest) (*pb.GetResourcesResponse, error) {
-	within := req.GetWithin()
-	lon, lat := req.GetLocation().GetLongitude(), req.GetLocation().GetLatitude()
+	q := &repo.NearbyQuery{
+		Lat:          req.GetLocation().GetLatitude(),
+		Lon:          req.GetLocation().GetLongitude(),
+		RadiusMeters: int(req.GetWithin()),
+		AmenityTypes: req.GetCategories(),
+		PerCategory:  int(req.GetPerCategory()),
+		Limit:        int(req.GetLimit()),
+		Offset:       int(req.GetOffset()),
+	}
 
-	resources, err := h.svc.GetNearbyResources(ctx, lat, lon, int(within))
+	resources, err := h.svc.GetNearbyResources(ctx, q)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, service.ErrUnknownCategory) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get nearby resources: %v", err)
 	}
 
 	var pbResources []*pb.Resource
 	for _, r := range resources {
-		pbResource := &pb.Resource{
-			Name:        r.Name,
-			AmenityType: r.AmenityType,
-			Location: &pb.Coordinates{
-				Latitude:  r.Location.Coordinates[1], // GeoJSON format is [longitude, latitude]
-				Longitude: r.Location.Coordinates[0],
-			},
+		pbResources = append(pbResources, toPbResource(r))
+	}
+
+	return &pb.GetResourcesResponse{
+		Resources: pbResources,
+	}, nil
+}
+
+// GetResourcesInBounds handles requests to fetch open resources within a bounding box, e.g., a map tile.
+func (h *gRPCHandler) GetResourcesInBounds(ctx context.Context, req *pb.GetResourcesInBoundsRequest) (*pb.GetResourcesResponse, error) {
+	b := types.Bounds{
+		MinLat: req.GetBounds().GetMinLat(),
+		MinLon: req.GetBounds().GetMinLon(),
+		MaxLat: req.GetBounds().GetMaxLat(),
+		MaxLon: req.GetBounds().GetMaxLon(),
+	}
+	if b.MinLat > b.MaxLat || b.MinLon > b.MaxLon {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bounds")
+	}
+
+	resources, err := h.svc.GetResourcesInBounds(ctx, b, req.GetCategories(), int(req.GetLimit()))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownCategory) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 		}
-		pbResources = append(pbResources, pbResource)
+		return nil, status.Errorf(codes.Internal, "failed to get resources in bounds: %v", err)
+	}
+
+	var pbResources []*pb.Resource
+	for _, r := range resources {
+		pbResources = append(pbResources, toPbResource(r))
 	}
 
 	return &pb.GetResourcesResponse{
 		Resources: pbResources,
 	}, nil
 }
+
+// GetResource retrieves a resource by its ID.
+func (h *gRPCHandler) GetResource(ctx context.Context, req *pb.GetResourceRequest) (*pb.Resource, error) {
+	resource, err := h.svc.GetResource(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "resource %s not found", req.GetId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get resource: %v", err)
+	}
+
+	return toPbResource(resource), nil
+}
+
+// RankResourcesByTravelTime ranks nearby resources by estimated travel time over the road network.
+func (h *gRPCHandler) RankResourcesByTravelTime(ctx context.Context, req *pb.RankResourcesRequest) (*pb.RankResourcesResponse, error) {
+	q := &repo.NearbyQuery{
+		Lat:          req.GetOrigin().GetLatitude(),
+		Lon:          req.GetOrigin().GetLongitude(),
+		RadiusMeters: int(req.GetWithin()),
+		AmenityTypes: req.GetCategories(),
+		PerCategory:  int(req.GetPerCategory()),
+		Limit:        int(req.GetLimit()),
+		Offset:       int(req.GetOffset()),
+	}
+
+	ranked, err := h.svc.RankResourcesByTravelTime(ctx, q, req.GetIncludeGeometry())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownCategory):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, service.ErrRoutingUnavailable):
+			return nil, status.Errorf(codes.Unavailable, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to rank resources: %v", err)
+		}
+	}
+
+	var pbRanked []*pb.RankedResource
+	for _, r := range ranked {
+		pbResource := &pb.RankedResource{
+			Resource:       toPbResource(r.Resource),
+			Reachable:      r.Reachable,
+			TravelDistance: r.Distance,
+			TravelTime:     r.Duration,
+		}
+		for _, p := range r.Geometry {
+			pbResource.Geometry = append(pbResource.Geometry, &pb.Coordinates{Latitude: p.Lat, Longitude: p.Lon})
+		}
+		pbRanked = append(pbRanked, pbResource)
+	}
+
+	return &pb.RankResourcesResponse{Resources: pbRanked}, nil
+}
+
+// BlockRoad marks an OSM way as impassable for routing.
+func (h *gRPCHandler) BlockRoad(ctx context.Context, req *pb.BlockRoadRequest) (*pb.BlockedRoad, error) {
+	if req.GetWayId() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "way_id is required")
+	}
+
+	road := &types.BlockedRoad{
+		WayID:     req.GetWayId(),
+		Reason:    req.GetReason(),
+		BlockedBy: req.GetAdminId(),
+	}
+	if err := h.svc.BlockRoad(ctx, road); err != nil {
+		if errors.Is(err, service.ErrUnknownWay) {
+			return nil, status.Errorf(codes.NotFound, "road %d: %v", req.GetWayId(), err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to block road: %v", err)
+	}
+
+	return toPbBlockedRoad(road), nil
+}
+
+// UnblockRoad removes the block on an OSM way.
+func (h *gRPCHandler) UnblockRoad(ctx context.Context, req *pb.UnblockRoadRequest) (*pb.UnblockRoadResponse, error) {
+	if err := h.svc.UnblockRoad(ctx, req.GetWayId()); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "road %d is not blocked", req.GetWayId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unblock road: %v", err)
+	}
+
+	return &pb.UnblockRoadResponse{WayId: req.GetWayId()}, nil
+}
+
+// ListBlockedRoads lists all roads currently marked as blocked.
+func (h *gRPCHandler) ListBlockedRoads(ctx context.Context, req *pb.ListBlockedRoadsRequest) (*pb.ListBlockedRoadsResponse, error) {
+	roads, err := h.svc.ListBlockedRoads(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list blocked roads: %v", err)
+	}
+
+	var pbRoads []*pb.BlockedRoad
+	for _, r := range roads {
+		pbRoads = append(pbRoads, toPbBlockedRoad(r))
+	}
+
+	return &pb.ListBlockedRoadsResponse{Roads: pbRoads}, nil
+}
+
+// toPbResource converts a resource to its protobuf representation.
+func toPbResource(r *types.Resource) *pb.Resource {
+	return &pb.Resource{
+		Id:          r.ID.Hex(),
+		Name:        r.Name,
+		AmenityType: r.AmenityType,
+		Location: &pb.Coordinates{
+			Latitude:  r.Location.Coordinates[1], // GeoJSON format is [longitude, latitude]
+			Longitude: r.Location.Coordinates[0],
+		},
+		Distance:  r.Distance,
+		Inventory: r.Inventory,
+		Status:    r.Status,
+		OrgId:     r.OrgID,
+	}
+}
+
+// toPbBlockedRoad converts a blocked road to its protobuf representation.
+func toPbBlockedRoad(r *types.BlockedRoad) *pb.BlockedRoad {
+	return &pb.BlockedRoad{
+		WayId:     r.WayID,
+		Reason:    r.Reason,
+		BlockedBy: r.BlockedBy,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/services/resource-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListRefreshRegions reports the refresh status of every disaster and watch-listed region.
+func (h *gRPCHandler) ListRefreshRegions(ctx context.Context, req *pb.ListRefreshRegionsRequest) (*pb.ListRefreshRegionsResponse, error) {
+	regions, err := h.svc.ListRefreshRegions(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list refresh regions: %v", err)
+	}
+
+	var pbRegions []*pb.RefreshRegion
+	for _, r := range regions {
+		pbRegions = append(pbRegions, toPbRefreshRegion(r))
+	}
+
+	return &pb.ListRefreshRegionsResponse{Regions: pbRegions}, nil
+}
+
+// WatchRegion adds a region whose resources are periodically refreshed.
+func (h *gRPCHandler) WatchRegion(ctx context.Context, req *pb.WatchRegionRequest) (*pb.RefreshRegion, error) {
+	region, err := h.svc.WatchRegion(ctx, req.GetName(), int(req.GetWithin()), req.GetLocation().GetLatitude(), req.GetLocation().GetLongitude())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRegion) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to watch region: %v", err)
+	}
+
+	return toPbRefreshRegion(region), nil
+}
+
+// UnwatchRegion stops refreshing a region.
+func (h *gRPCHandler) UnwatchRegion(ctx context.Context, req *pb.UnwatchRegionRequest) (*pb.UnwatchRegionResponse, error) {
+	if err := h.svc.UnwatchRegion(ctx, req.GetId()); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "region %s not found", req.GetId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unwatch region: %v", err)
+	}
+
+	return &pb.UnwatchRegionResponse{Id: req.GetId()}, nil
+}
+
+// TriggerRefresh re-syncs a region immediately. A failed sync is reported in the returned region status.
+func (h *gRPCHandler) TriggerRefresh(ctx context.Context, req *pb.TriggerRefreshRequest) (*pb.RefreshRegion, error) {
+	region, err := h.svc.RefreshRegion(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "region %s not found", req.GetId())
+		}
+		if region == nil {
+			return nil, status.Errorf(codes.Internal, "failed to refresh region: %v", err)
+		}
+	}
+
+	return toPbRefreshRegion(region), nil
+}
+
+// toPbRefreshRegion converts a refresh region to its protobuf representation.
+func toPbRefreshRegion(r *types.RefreshRegion) *pb.RefreshRegion {
+	pbRegion := &pb.RefreshRegion{
+		Id:   r.ID,
+		Kind: r.Kind,
+		Name: r.Name,
+		Location: &pb.Coordinates{
+			Latitude:  r.Location.Latitude,
+			Longitude: r.Location.Longitude,
+		},
+		Within:     int64(r.Radius),
+		LastStatus: r.LastStatus,
+		LastError:  r.LastError,
+		Found:      int64(r.Found),
+		Closed:     r.Closed,
+	}
+	if !r.ExpiresAt.IsZero() {
+		pbRegion.ExpiresAt = timestamppb.New(r.ExpiresAt)
+	}
+	if !r.LastRefreshAt.IsZero() {
+		pbRegion.LastRefreshAt = timestamppb.New(r.LastRefreshAt)
+	}
+	return pbRegion
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/services/resource-service/service"
+	"github.com/cprakhar/relief-ops/shared/events"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	pb "github.com/cprakhar/relief-ops/shared/proto/resource"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// supplyStatus maps supply errors to gRPC status errors.
+func supplyStatus(err error, action string) error {
+	switch {
+	case errors.Is(err, service.ErrUnknownCategory),
+		errors.Is(err, service.ErrUnknownSupplyItem),
+		errors.Is(err, service.ErrInvalidUrgency),
+		errors.Is(err, service.ErrInvalidQuantity),
+		errors.Is(err, service.ErrNeedMismatch):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repo.ErrNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, repo.ErrInsufficientStock),
+		errors.Is(err, repo.ErrOverCommitted):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+	}
+}
+
+// CreateResource adds a manually managed resource, such as a supply depot.
+func (h *gRPCHandler) CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.Resource, error) {
+	if req.GetName() == "" || req.GetLocation() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "name and location are required")
+	}
+
+	resource := &types.Resource{
+		Name:        req.GetName(),
+		AmenityType: req.GetAmenityType(),
+		Location: &types.Location{
+			Type:        "Point",
+			Coordinates: []float64{req.GetLocation().GetLongitude(), req.GetLocation().GetLatitude()},
+		},
+		Inventory: req.GetInventory(),
+		OrgID:     req.GetOrgId(),
+	}
+
+	if _, err := h.svc.CreateResource(ctx, resource); err != nil {
+		return nil, supplyStatus(err, "create resource")
+	}
+
+	return toPbResource(resource), nil
+}
+
+// SetInventory sets the stock of a supply item held by a resource.
+func (h *gRPCHandler) SetInventory(ctx context.Context, req *pb.SetInventoryRequest) (*pb.Resource, error) {
+	resource, err := h.svc.SetInventory(ctx, req.GetResourceId(), req.GetItem(), req.GetQuantity())
+	if err != nil {
+		return nil, supplyStatus(err, "set inventory")
+	}
+
+	return toPbResource(resource), nil
+}
+
+// DeclareNeed records a supply need for a disaster.
+func (h *gRPCHandler) DeclareNeed(ctx context.Context, req *pb.DeclareNeedRequest) (*pb.Need, error) {
+	if req.GetDisasterId() == "" || req.GetLocation() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "disaster_id and location are required")
+	}
+
+	need := &types.Need{
+		DisasterID: req.GetDisasterId(),
+		Location: &types.Location{
+			Type:        "Point",
+			Coordinates: []float64{req.GetLocation().GetLongitude(), req.GetLocation().GetLatitude()},
+		},
+		Item:      req.GetItem(),
+		Quantity:  req.GetQuantity(),
+		Urgency:   req.GetUrgency(),
+		CreatedBy: req.GetAdminId(),
+	}
+
+	if _, err := h.svc.DeclareNeed(ctx, need); err != nil {
+		return nil, supplyStatus(err, "declare need")
+	}
+
+	return toPbNeed(need), nil
+}
+
+// ListNeeds lists all needs declared for a disaster.
+func (h *gRPCHandler) ListNeeds(ctx context.Context, req *pb.ListNeedsRequest) (*pb.ListNeedsResponse, error) {
+	needs, err := h.svc.ListNeeds(ctx, req.GetDisasterId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list needs: %v", err)
+	}
+
+	var pbNeeds []*pb.Need
+	for _, n := range needs {
+		pbNeeds = append(pbNeeds, toPbNeed(n))
+	}
+
+	return &pb.ListNeedsResponse{Needs: pbNeeds}, nil
+}
+
+// MatchNeeds proposes which depots should ship what to cover a disaster's outstanding needs.
+func (h *gRPCHandler) MatchNeeds(ctx context.Context, req *pb.MatchNeedsRequest) (*pb.MatchNeedsResponse, error) {
+	proposals, err := h.svc.MatchNeeds(ctx, req.GetDisasterId(), int(req.GetWithin()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to match needs: %v", err)
+	}
+
+	var pbProposals []*pb.SupplyProposal
+	for _, p := range proposals {
+		pbProposals = append(pbProposals, &pb.SupplyProposal{
+			NeedId:     p.NeedID,
+			DisasterId: p.DisasterID,
+			Depot:      toPbResource(p.Depot),
+			Item:       p.Item,
+			Quantity:   p.Quantity,
+			Distance:   p.Distance,
+		})
+	}
+
+	return &pb.MatchNeedsResponse{Proposals: pbProposals}, nil
+}
+
+// CommitSupply reserves depot stock against a need and publishes the commitment.
+func (h *gRPCHandler) CommitSupply(ctx context.Context, req *pb.CommitSupplyRequest) (*pb.SupplyCommitment, error) {
+	logger := logs.L()
+
+	commitment, err := h.svc.CommitSupply(ctx, req.GetDisasterId(), req.GetNeedId(), req.GetDepotId(), req.GetQuantity(), req.GetAdminId())
+	if err != nil {
+		return nil, supplyStatus(err, "commit supply")
+	}
+
+	msg := &events.SupplyEventCommittedPayload{
+		CommitmentID: commitment.ID.Hex(),
+		DisasterID:   commitment.DisasterID,
+		NeedID:       commitment.NeedID,
+		DepotID:      commitment.DepotID,
+		Item:         commitment.Item,
+		Quantity:     commitment.Quantity,
+		Distance:     commitment.Distance,
+		CommittedBy:  commitment.CommittedBy,
+	}
+
+	// The commitment is already stored, so a failed publish is logged rather than returned
+	value, err := json.Marshal(msg)
+	if err != nil {
+		logger.Errorw("Failed to marshal supply commitment event", "error", err, "commitment_id", msg.CommitmentID)
+	} else if err := h.kafkaClient.Produce(ctx, events.SupplyEventCommitted, commitment.DisasterID, value); err != nil {
+		logger.Errorw("Failed to publish supply commitment event", "error", err, "commitment_id", msg.CommitmentID)
+	}
+
+	return toPbSupplyCommitment(commitment), nil
+}
+
+// toPbNeed converts a need to its protobuf representation.
+func toPbNeed(n *types.Need) *pb.Need {
+	return &pb.Need{
+		Id:         n.ID.Hex(),
+		DisasterId: n.DisasterID,
+		Location: &pb.Coordinates{
+			Latitude:  n.Location.Coordinates[1],
+			Longitude: n.Location.Coordinates[0],
+		},
+		Item:      n.Item,
+		Quantity:  n.Quantity,
+		Committed: n.Committed,
+		Urgency:   n.Urgency,
+		CreatedBy: n.CreatedBy,
+		CreatedAt: timestamppb.New(n.CreatedAt),
+		UpdatedAt: timestamppb.New(n.UpdatedAt),
+	}
+}
+
+// toPbSupplyCommitment converts a supply commitment to its protobuf representation.
+func toPbSupplyCommitment(c *types.SupplyCommitment) *pb.SupplyCommitment {
+	return &pb.SupplyCommitment{
+		Id:          c.ID.Hex(),
+		NeedId:      c.NeedID,
+		DisasterId:  c.DisasterID,
+		DepotId:     c.DepotID,
+		Item:        c.Item,
+		Quantity:    c.Quantity,
+		Distance:    c.Distance,
+		CommittedBy: c.CommittedBy,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cprakhar/relief-ops/services/resource-service/event"
 	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/services/resource-service/routing"
 	"github.com/cprakhar/relief-ops/services/resource-service/service"
 	"github.com/cprakhar/relief-ops/shared/db"
 	"github.com/cprakhar/relief-ops/shared/env"
@@ -18,6 +19,8 @@ import (
 	"github.com/cprakhar/relief-ops/shared/messaging"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/observe/traces"
+	"github.com/cprakhar/relief-ops/shared/taxonomy"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
 )
 
 var (
@@ -35,6 +38,26 @@ var (
 	mongoMaxPool = uint64(env.GetInt("MONGODB_MAX_POOL", 10))
 	mongoMinPool = uint64(env.GetInt("MONGODB_MIN_POOL", 2))
 
+	// Redis configuration
+	redisAddr     = env.GetString("REDIS_ADDR", "redis-db:6379")
+	redisUsername = env.GetString("REDIS_USERNAME", "")
+	redisMaxConn  = env.GetInt("REDIS_MAX_CONN", 10)
+	redisMinIdle  = env.GetInt("REDIS_MIN_IDLE", 2)
+	redisMaxIdle  = env.GetInt("REDIS_MAX_IDLE", 5)
+	redisPassword = env.GetString("REDIS_PASSWORD", "")
+	redisDB       = env.GetInt("REDIS_DB", 0)
+
+	// Resource taxonomy configuration (empty uses the embedded default)
+	taxonomyFile = env.GetString("RESOURCE_TAXONOMY_FILE", "")
+
+	// Road network extract (OSM XML, optionally gzipped) used for travel-time routing; empty disables routing
+	roadGraphFile = env.GetString("ROAD_GRAPH_FILE", "")
+
+	// OSM resource refresh configuration
+	refreshInterval       = env.GetTimeDuration("RESOURCE_REFRESH_INTERVAL", 24*time.Hour)
+	refreshCheckEvery     = env.GetTimeDuration("RESOURCE_REFRESH_CHECK_EVERY", 10*time.Minute)
+	refreshDisasterWindow = env.GetTimeDuration("RESOURCE_REFRESH_DISASTER_WINDOW", 30*24*time.Hour)
+
 	// OTLP configuration
 	otlpEndpoint = env.GetString("OTLP_ENDPOINT", "otel-collector:4317")
 	otlpInsecure = env.GetBool("OTLP_INSECURE", true)
@@ -75,6 +98,20 @@ func main() {
 	}()
 	logger.Info("Tracing initialized")
 
+	redisCfg := &db.RedisConfig{
+		Addr:           redisAddr,
+		Username:       redisUsername,
+		Password:       redisPassword,
+		DB:             int(redisDB),
+		MaxActiveConns: int(redisMaxConn),
+		MaxIdleConns:   int(redisMaxIdle),
+		MinIdleConns:   int(redisMinIdle),
+	}
+	if err := db.InitRedis(redisCfg); err != nil {
+		logger.Fatalw("Failed to connect to Redis", "error", err)
+	}
+	logger.Info("Connected to Redis")
+
 	// Initialize MongoDB client
 	mongoCfg := &db.MongoDBConfig{
 		URI:        mongoURI,
@@ -105,11 +142,52 @@ func main() {
 	defer kafkaClient.Close()
 	logger.Info("Kafka client initialized")
 
+	// Load the resource taxonomy
+	resourceTaxonomy, err := taxonomy.Load(taxonomyFile)
+	if err != nil {
+		logger.Fatalw("Failed to load resource taxonomy", "error", err)
+	}
+	logger.Infow("Resource taxonomy loaded", "categories", resourceTaxonomy.Names())
+
 	resourceRepo, err := repo.NewResourceRepo(ctx, mongoClient)
 	if err != nil {
 		logger.Fatalw("Failed to create resource repository", "error", err)
 	}
-	resourceService := service.NewResourceService(resourceRepo)
+	blockedRoadRepo, err := repo.NewBlockedRoadRepo(ctx, mongoClient.Database().Collection("blocked_roads"))
+	if err != nil {
+		logger.Fatalw("Failed to create blocked road repository", "error", err)
+	}
+	supplyRepo, err := repo.NewSupplyRepo(ctx, mongoClient.Database().Collection("needs"), mongoClient.Database().Collection("supply_commitments"))
+	if err != nil {
+		logger.Fatalw("Failed to create supply repository", "error", err)
+	}
+	refreshRegionRepo, err := repo.NewRefreshRegionRepo(ctx, mongoClient.Database().Collection("refresh_regions"))
+	if err != nil {
+		logger.Fatalw("Failed to create refresh region repository", "error", err)
+	}
+
+	// Load the road graph for travel-time routing
+	var roadGraph *routing.Graph
+	if roadGraphFile != "" {
+		roadGraph, err = routing.LoadOSMFile(roadGraphFile)
+		if err != nil {
+			logger.Fatalw("Failed to load road graph", "error", err, "file", roadGraphFile)
+		}
+		logger.Infow("Road graph loaded", "file", roadGraphFile, "nodes", roadGraph.NodeCount())
+	} else {
+		logger.Warn("ROAD_GRAPH_FILE not set, travel-time routing disabled")
+	}
+
+	refreshCfg := &service.RefreshConfig{
+		Interval:       refreshInterval,
+		CheckEvery:     refreshCheckEvery,
+		DisasterWindow: refreshDisasterWindow,
+	}
+
+	// Map tiles are rendered by the API gateway; the service only invalidates them
+	tileCache := tilecache.New(db.GetRedisClient(), tilecache.DefaultTTL)
+
+	resourceService := service.NewResourceService(resourceRepo, blockedRoadRepo, supplyRepo, refreshRegionRepo, resourceTaxonomy, roadGraph, refreshCfg, tileCache)
 
 	// Initialize and start the disaster consumer
 	topics := []string{events.ResourceCommandFind}
@@ -125,6 +203,16 @@ func main() {
 		}
 	}()
 
+	// Start the background refresher for disaster and watch-listed regions
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger.Infow("Resource refresher running", "interval", refreshInterval, "check_every", refreshCheckEvery)
+		if err := resourceService.RunRefresher(ctx); err != nil {
+			logger.Errorw("Error in resource refresher", "error", err)
+		}
+	}()
+
 	gRPCServer := newgRPCServer(addr, resourceService, kafkaClient)
 	wg.Add(1)
 	go func() {
@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbBlockedRoadRepo struct {
+	db *mongo.Collection
+}
+
+// BlockedRoadRepo defines the interface for roads marked impassable by admins.
+type BlockedRoadRepo interface {
+	Block(ctx context.Context, road *types.BlockedRoad) error
+	Unblock(ctx context.Context, wayID int64) error
+	List(ctx context.Context) ([]*types.BlockedRoad, error)
+}
+
+// NewBlockedRoadRepo creates a new instance of mongodbBlockedRoadRepo.
+func NewBlockedRoadRepo(ctx context.Context, db *mongo.Collection) (BlockedRoadRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "way_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("way_id_unique"),
+	}
+
+	if _, err := db.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	return &mongodbBlockedRoadRepo{db: db}, nil
+}
+
+// Block marks a road as blocked, replacing any existing block on the same way.
+func (r *mongodbBlockedRoadRepo) Block(ctx context.Context, road *types.BlockedRoad) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	road.CreatedAt = time.Now()
+
+	filter := bson.M{"way_id": road.WayID}
+	_, err := r.db.ReplaceOne(ctx, filter, road, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Unblock removes the block on a road.
+func (r *mongodbBlockedRoadRepo) Unblock(ctx context.Context, wayID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"way_id": wayID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves all blocked roads.
+func (r *mongodbBlockedRoadRepo) List(ctx context.Context) ([]*types.BlockedRoad, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	cursor, err := r.db.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var roads []*types.BlockedRoad
+	for cursor.Next(ctx) {
+		var road types.BlockedRoad
+		if err := cursor.Decode(&road); err != nil {
+			return nil, err
+		}
+		roads = append(roads, &road)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return roads, nil
+}
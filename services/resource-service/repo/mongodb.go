@@ -2,17 +2,27 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
+	"github.com/cprakhar/relief-ops/shared/geo"
 	"github.com/cprakhar/relief-ops/shared/types"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-var QueryTimeout = 5 * time.Second
+var (
+	QueryTimeout         = 5 * time.Second
+	ErrNotFound          = fmt.Errorf("record not found")
+	ErrInsufficientStock = fmt.Errorf("insufficient stock")
+)
+
+// errIndexNotFound is the MongoDB server error code for dropping a missing index.
+const errIndexNotFound = 27
 
 type mongodbResourceRepo struct {
 	db *mongo.Collection
@@ -20,7 +30,26 @@ type mongodbResourceRepo struct {
 
 type ResourceRepo interface {
 	AddResources(ctx context.Context, resources []*types.Resource) error
-	GetNearbyResources(ctx context.Context, lat, lon float64, radiusMeters int) ([]*types.Resource, error)
+	GetNearbyResources(ctx context.Context, q *NearbyQuery) ([]*types.Resource, error)
+	Create(ctx context.Context, resource *types.Resource) (string, error)
+	GetByID(ctx context.Context, id string) (*types.Resource, error)
+	SetInventory(ctx context.Context, id, item string, quantity int64) (*types.Resource, error)
+	AdjustInventory(ctx context.Context, id, item string, delta int64) error
+	GetStockedNearby(ctx context.Context, lat, lon float64, radiusMeters int, item string) ([]*types.Resource, error)
+	CloseMissing(ctx context.Context, lat, lon float64, radiusMeters int, seenSince time.Time) (int64, error)
+	GetInBounds(ctx context.Context, b types.Bounds, amenityTypes []string, limit int) ([]*types.Resource, error)
+	CountNearbyByCategory(ctx context.Context, lat, lon float64, radiusMeters int) (map[string]int64, error)
+}
+
+// NearbyQuery describes a distance-ordered search for resources around a point.
+type NearbyQuery struct {
+	Lat          float64
+	Lon          float64
+	RadiusMeters int
+	AmenityTypes []string // optional category filter
+	PerCategory  int      // nearest N resources per category, 0 for no per-category limit
+	Limit        int
+	Offset       int
 }
 
 // NewResourceRepo creates a new instance of mongodbResourceRepo.
@@ -34,15 +63,35 @@ func NewResourceRepo(ctx context.Context, db *mongo.Collection) (ResourceRepo, e
 		Options: options.Index().SetName("location_2dsphere"),
 	}
 
-	// Create TTL index on created_at field to auto-expire documents after 30 days
-	ttlIndexModel := mongo.IndexModel{
-		Keys: bson.D{{Key: "created_at", Value: 1}},
-		Options: options.Index().
-			SetExpireAfterSeconds(3600 * 24 * 30). // 30 days
-			SetName("created_at_ttl"),
+	// Create index on OSM element ID used for upserts
+	osmIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "osm_id", Value: 1}},
+		Options: options.Index().SetName("osm_id").SetSparse(true),
+	}
+
+	// Create index on the key resources were upserted by before OSM IDs were recorded
+	legacyIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}, {Key: "amenity_type", Value: 1}},
+		Options: options.Index().SetName("name_amenity_type"),
+	}
+
+	// Create index used to close resources that vanished upstream
+	lastSeenIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "last_seen_at", Value: 1}},
+		Options: options.Index().SetName("status_last_seen_at"),
 	}
 
-	indexModel := []mongo.IndexModel{geoIndexModel, ttlIndexModel}
+	// Drop the previous TTL indexes; stale OSM resources are now closed by the refresher instead of deleted
+	for _, name := range []string{"created_at_ttl", "created_at_ttl_osm"} {
+		if err := db.Indexes().DropOne(ctx, name); err != nil {
+			var cmdErr mongo.CommandError
+			if !errors.As(err, &cmdErr) || !cmdErr.HasErrorCode(errIndexNotFound) {
+				return nil, fmt.Errorf("failed to drop legacy TTL index: %v", err)
+			}
+		}
+	}
+
+	indexModel := []mongo.IndexModel{geoIndexModel, osmIndexModel, legacyIndexModel, lastSeenIndexModel}
 	_, err := db.Indexes().CreateMany(ctx, indexModel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create indexes: %v", err)
@@ -64,18 +113,38 @@ func (r *mongodbResourceRepo) AddResources(ctx context.Context, resources []*typ
 
 	var operations []mongo.WriteModel
 	for _, resource := range resources {
+		// Unnamed features (e.g., water taps) are only distinguishable by their OSM element ID
 		filter := bson.M{
 			"name":         resource.Name,
 			"amenity_type": resource.AmenityType,
 		}
+		if resource.OSMID != "" {
+			// OSM resources saved before their IDs were recorded are matched by their legacy key and adopt
+			// the ID, rather than being duplicated by the first refresh
+			filter = bson.M{"$or": bson.A{
+				bson.M{"osm_id": resource.OSMID},
+				bson.M{
+					"name":         resource.Name,
+					"amenity_type": resource.AmenityType,
+					"osm_id":       bson.M{"$exists": false},
+					"manual":       bson.M{"$ne": true},
+				},
+			}}
+		}
 
 		update := bson.M{
 			"$set": bson.M{
+				"osm_id":       resource.OSMID,
 				"name":         resource.Name,
 				"amenity_type": resource.AmenityType,
 				"location":     resource.Location,
+				"status":       types.ResourceOpen,
+				"last_seen_at": now,
 				"updated_at":   now,
 			},
+			"$unset": bson.M{
+				"closed_at": "",
+			},
 			"$setOnInsert": bson.M{
 				"created_at": now,
 			},
@@ -102,28 +171,49 @@ func (r *mongodbResourceRepo) AddResources(ctx context.Context, resources []*typ
 	return nil
 }
 
-// GetNearbyResources retrieves resources within a certain radius (in meters) of given coordinates.
-func (r *mongodbResourceRepo) GetNearbyResources(ctx context.Context, lat, lon float64, radiusMeters int) ([]*types.Resource, error) {
+// GetNearbyResources retrieves resources within a certain radius (in meters) of given coordinates,
+// ordered by distance. Each resource carries its distance in meters from the query point.
+func (r *mongodbResourceRepo) GetNearbyResources(ctx context.Context, q *NearbyQuery) ([]*types.Resource, error) {
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
-	filter := bson.M{
-		"location": bson.M{
-			"$near": bson.M{
-				"$geometry": bson.M{
-					"type":        "Point",
-					"coordinates": []float64{lon, lat}, // GeoJSON format is [longitude, latitude]
-				},
-				"$maxDistance": radiusMeters,
-			},
+	geoNear := bson.M{
+		"near": bson.M{
+			"type":        "Point",
+			"coordinates": []float64{q.Lon, q.Lat}, // GeoJSON format is [longitude, latitude]
 		},
+		"distanceField": "distance",
+		"maxDistance":   q.RadiusMeters,
+		"spherical":     true,
+		"key":           "location",
+	}
+	query := bson.M{"status": bson.M{"$ne": types.ResourceClosed}}
+	if len(q.AmenityTypes) > 0 {
+		query["amenity_type"] = bson.M{"$in": q.AmenityTypes}
+	}
+	geoNear["query"] = query
+
+	pipeline := mongo.Pipeline{{{Key: "$geoNear", Value: geoNear}}}
+
+	// Keep only the nearest N resources of each category; $geoNear output is already sorted by distance
+	if q.PerCategory > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$group", Value: bson.M{"_id": "$amenity_type", "docs": bson.M{"$push": "$$ROOT"}}}},
+			bson.D{{Key: "$project", Value: bson.M{"docs": bson.M{"$slice": bson.A{"$docs", q.PerCategory}}}}},
+			bson.D{{Key: "$unwind", Value: "$docs"}},
+			bson.D{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$docs"}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "distance", Value: 1}, {Key: "_id", Value: 1}}}},
+		)
 	}
 
-	findOpts := options.Find().
-		SetLimit(100). // Limit to 100 results
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if q.Offset > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: q.Offset}})
+	}
+	if q.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: q.Limit}})
+	}
 
-	cursor, err := r.db.Find(ctx, filter, findOpts)
+	cursor, err := r.db.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -144,3 +234,267 @@ func (r *mongodbResourceRepo) GetNearbyResources(ctx context.Context, lat, lon f
 
 	return resources, nil
 }
+
+// Create adds a manually created resource, e.g., a supply depot.
+func (r *mongodbResourceRepo) Create(ctx context.Context, resource *types.Resource) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	resource.Manual = true
+	resource.CreatedAt = time.Now()
+	resource.UpdatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, resource)
+	if err != nil {
+		return "", err
+	}
+
+	resource.ID = res.InsertedID.(bson.ObjectID)
+	return resource.ID.Hex(), nil
+}
+
+// GetByID retrieves a resource by its ID.
+func (r *mongodbResourceRepo) GetByID(ctx context.Context, id string) (*types.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var resource types.Resource
+	if err := r.db.FindOne(ctx, bson.M{"_id": oid}).Decode(&resource); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// SetInventory sets the stock of a supply item held by a resource and returns the updated resource.
+func (r *mongodbResourceRepo) SetInventory(ctx context.Context, id, item string, quantity int64) (*types.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"inventory." + item: quantity,
+			"updated_at":        time.Now(),
+		},
+	}
+
+	var resource types.Resource
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.db.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update, opts).Decode(&resource); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// AdjustInventory atomically changes the stock of a supply item by delta.
+// A negative delta fails with ErrInsufficientStock if it would take the stock below zero.
+func (r *mongodbResourceRepo) AdjustInventory(ctx context.Context, id, item string, delta int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	filter := bson.M{"_id": oid}
+	if delta < 0 {
+		filter["inventory."+item] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"inventory." + item: delta},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	res, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+// GetStockedNearby retrieves resources holding stock of an item within a radius, nearest first.
+func (r *mongodbResourceRepo) GetStockedNearby(ctx context.Context, lat, lon float64, radiusMeters int, item string) ([]*types.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{{{Key: "$geoNear", Value: bson.M{
+		"near": bson.M{
+			"type":        "Point",
+			"coordinates": []float64{lon, lat}, // GeoJSON format is [longitude, latitude]
+		},
+		"distanceField": "distance",
+		"maxDistance":   radiusMeters,
+		"spherical":     true,
+		"key":           "location",
+		"query": bson.M{
+			"inventory." + item: bson.M{"$gt": 0},
+			"status":            bson.M{"$ne": types.ResourceClosed},
+		},
+	}}}}
+
+	cursor, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var resources []*types.Resource
+	if err := cursor.All(ctx, &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// CloseMissing marks OSM resources within a radius as closed if they were not seen since the given time,
+// i.e., they vanished upstream. It returns the number of resources closed.
+func (r *mongodbResourceRepo) CloseMissing(ctx context.Context, lat, lon float64, radiusMeters int, seenSince time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"osm_id": bson.M{"$exists": true, "$ne": ""},
+		"status": bson.M{"$ne": types.ResourceClosed},
+		"location": bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{bson.A{lon, lat}, float64(radiusMeters) / geo.EarthRadius},
+		}},
+		"$or": bson.A{
+			bson.M{"last_seen_at": bson.M{"$lt": seenSince}},
+			bson.M{"last_seen_at": bson.M{"$exists": false}},
+		},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":     types.ResourceClosed,
+			"closed_at":  now,
+			"updated_at": now,
+		},
+	}
+
+	res, err := r.db.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CountNearbyByCategory counts the open resources of each category within a radius.
+func (r *mongodbResourceRepo) CountNearbyByCategory(ctx context.Context, lat, lon float64, radiusMeters int) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	match := bson.M{
+		"status": bson.M{"$ne": types.ResourceClosed},
+		"location": bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{bson.A{lon, lat}, float64(radiusMeters) / geo.EarthRadius},
+		}},
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$amenity_type", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Category string `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(groups))
+	for _, g := range groups {
+		counts[g.Category] = g.Count
+	}
+	return counts, nil
+}
+
+// GetInBounds retrieves open resources within a bounding box, optionally restricted to the given categories.
+// The result may include resources slightly outside the box; callers needing exact bounds must filter.
+func (r *mongodbResourceRepo) GetInBounds(ctx context.Context, b types.Bounds, amenityTypes []string, limit int) ([]*types.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	filter := bson.M{"status": bson.M{"$ne": types.ResourceClosed}}
+	if len(amenityTypes) > 0 {
+		filter["amenity_type"] = bson.M{"$in": amenityTypes}
+	}
+	// Polygon edges must span less than 180 degrees; wider boxes cover most of the globe anyway
+	if b.MaxLon-b.MinLon < 180 {
+		filter["location"] = bson.M{"$geoWithin": bson.M{"$geometry": boundsPolygon(b)}}
+	}
+
+	opts := options.Find()
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var resources []*types.Resource
+	if err := cursor.All(ctx, &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// boundsPolygon converts a bounding box to a GeoJSON polygon. Polygon edges are geodesics, which bow
+// towards the pole between their vertices, so an edge bowing into the box is moved towards the equator
+// until its midpoint lies on the box's latitude.
+func boundsPolygon(b types.Bounds) bson.M {
+	halfSpan := (b.MaxLon - b.MinLon) / 2 * math.Pi / 180
+	expand := func(lat float64) float64 {
+		return math.Atan(math.Tan(lat*math.Pi/180)*math.Cos(halfSpan)) * 180 / math.Pi
+	}
+
+	minLat, maxLat := b.MinLat, b.MaxLat
+	if minLat > 0 {
+		minLat = expand(minLat)
+	}
+	if maxLat < 0 {
+		maxLat = expand(maxLat)
+	}
+
+	return bson.M{
+		"type": "Polygon",
+		"coordinates": bson.A{bson.A{
+			bson.A{b.MinLon, minLat},
+			bson.A{b.MaxLon, minLat},
+			bson.A{b.MaxLon, maxLat},
+			bson.A{b.MinLon, maxLat},
+			bson.A{b.MinLon, minLat},
+		}},
+	}
+}
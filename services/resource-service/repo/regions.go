@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbRefreshRegionRepo struct {
+	db *mongo.Collection
+}
+
+// RefreshRegionRepo defines the interface for regions whose OSM resources are periodically re-synced.
+type RefreshRegionRepo interface {
+	Upsert(ctx context.Context, region *types.RefreshRegion) error
+	GetByID(ctx context.Context, id string) (*types.RefreshRegion, error)
+	List(ctx context.Context) ([]*types.RefreshRegion, error)
+	ListDue(ctx context.Context, refreshedBefore time.Time) ([]*types.RefreshRegion, error)
+	RecordRefresh(ctx context.Context, region *types.RefreshRegion) error
+	Delete(ctx context.Context, id string) error
+}
+
+// NewRefreshRegionRepo creates a new instance of mongodbRefreshRegionRepo.
+func NewRefreshRegionRepo(ctx context.Context, db *mongo.Collection) (RefreshRegionRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	// Disaster regions expire once the disaster is no longer considered active; watch regions never do
+	ttlIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().
+			SetExpireAfterSeconds(0).
+			SetName("expires_at_ttl"),
+	}
+
+	if _, err := db.Indexes().CreateOne(ctx, ttlIndexModel); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	return &mongodbRefreshRegionRepo{db: db}, nil
+}
+
+// Upsert creates or updates the area of a region, keeping its refresh history.
+func (r *mongodbRefreshRegionRepo) Upsert(ctx context.Context, region *types.RefreshRegion) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	set := bson.M{
+		"kind":     region.Kind,
+		"name":     region.Name,
+		"location": region.Location,
+		"radius":   region.Radius,
+	}
+	if !region.ExpiresAt.IsZero() {
+		set["expires_at"] = region.ExpiresAt
+	}
+
+	update := bson.M{
+		"$set": set,
+		"$setOnInsert": bson.M{
+			"last_refresh_at": region.LastRefreshAt,
+			"last_status":     region.LastStatus,
+			"found":           region.Found,
+			"closed":          region.Closed,
+			"created_at":      time.Now(),
+		},
+	}
+
+	_, err := r.db.UpdateOne(ctx, bson.M{"_id": region.ID}, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+// GetByID retrieves a region by its ID.
+func (r *mongodbRefreshRegionRepo) GetByID(ctx context.Context, id string) (*types.RefreshRegion, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	var region types.RefreshRegion
+	if err := r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&region); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &region, nil
+}
+
+// List retrieves all regions, least recently refreshed first.
+func (r *mongodbRefreshRegionRepo) List(ctx context.Context) ([]*types.RefreshRegion, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// ListDue retrieves regions last refreshed before the given time, least recently refreshed first.
+func (r *mongodbRefreshRegionRepo) ListDue(ctx context.Context, refreshedBefore time.Time) ([]*types.RefreshRegion, error) {
+	filter := bson.M{
+		"last_refresh_at": bson.M{"$lt": refreshedBefore},
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": time.Now()}},
+		},
+	}
+	return r.find(ctx, filter)
+}
+
+// RecordRefresh stores the outcome of a region's latest refresh.
+func (r *mongodbRefreshRegionRepo) RecordRefresh(ctx context.Context, region *types.RefreshRegion) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"last_refresh_at": region.LastRefreshAt,
+			"last_status":     region.LastStatus,
+			"last_error":      region.LastError,
+			"found":           region.Found,
+			"closed":          region.Closed,
+		},
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": region.ID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a region so it is no longer refreshed.
+func (r *mongodbRefreshRegionRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *mongodbRefreshRegionRepo) find(ctx context.Context, filter bson.M) ([]*types.RefreshRegion, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "last_refresh_at", Value: 1}})
+	cursor, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var regions []*types.RefreshRegion
+	if err := cursor.All(ctx, &regions); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var ErrOverCommitted = fmt.Errorf("commitment exceeds outstanding need")
+
+type mongodbSupplyRepo struct {
+	needs       *mongo.Collection
+	commitments *mongo.Collection
+}
+
+// SupplyRepo defines the interface for disaster needs and supply commitments.
+type SupplyRepo interface {
+	CreateNeed(ctx context.Context, need *types.Need) (string, error)
+	GetNeed(ctx context.Context, id string) (*types.Need, error)
+	ListNeeds(ctx context.Context, disasterID string) ([]*types.Need, error)
+	AddCommitted(ctx context.Context, needID string, delta int64) error
+	CreateCommitment(ctx context.Context, commitment *types.SupplyCommitment) (string, error)
+}
+
+// NewSupplyRepo creates a new instance of mongodbSupplyRepo.
+func NewSupplyRepo(ctx context.Context, needs, commitments *mongo.Collection) (SupplyRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	needIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "disaster_id", Value: 1}},
+		Options: options.Index().SetName("disaster_id"),
+	}
+	if _, err := needs.Indexes().CreateOne(ctx, needIndexModel); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	commitmentIndexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "need_id", Value: 1}},
+		Options: options.Index().SetName("need_id"),
+	}
+	if _, err := commitments.Indexes().CreateOne(ctx, commitmentIndexModel); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	return &mongodbSupplyRepo{needs: needs, commitments: commitments}, nil
+}
+
+// CreateNeed adds a new need for a disaster.
+func (r *mongodbSupplyRepo) CreateNeed(ctx context.Context, need *types.Need) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	need.CreatedAt = time.Now()
+	need.UpdatedAt = time.Now()
+
+	res, err := r.needs.InsertOne(ctx, need)
+	if err != nil {
+		return "", err
+	}
+
+	need.ID = res.InsertedID.(bson.ObjectID)
+	return need.ID.Hex(), nil
+}
+
+// GetNeed retrieves a need by its ID.
+func (r *mongodbSupplyRepo) GetNeed(ctx context.Context, id string) (*types.Need, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var need types.Need
+	if err := r.needs.FindOne(ctx, bson.M{"_id": oid}).Decode(&need); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &need, nil
+}
+
+// ListNeeds retrieves all needs declared for a disaster.
+func (r *mongodbSupplyRepo) ListNeeds(ctx context.Context, disasterID string) ([]*types.Need, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	cursor, err := r.needs.Find(ctx, bson.M{"disaster_id": disasterID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var needs []*types.Need
+	if err := cursor.All(ctx, &needs); err != nil {
+		return nil, err
+	}
+	return needs, nil
+}
+
+// AddCommitted atomically increases the committed quantity of a need.
+// It fails with ErrOverCommitted if the need would be committed beyond its quantity.
+func (r *mongodbSupplyRepo) AddCommitted(ctx context.Context, needID string, delta int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(needID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	filter := bson.M{
+		"_id":   oid,
+		"$expr": bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$committed", delta}}, "$quantity"}},
+	}
+	update := bson.M{
+		"$inc": bson.M{"committed": delta},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	res, err := r.needs.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrOverCommitted
+	}
+	return nil
+}
+
+// CreateCommitment records a supply commitment.
+func (r *mongodbSupplyRepo) CreateCommitment(ctx context.Context, commitment *types.SupplyCommitment) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	commitment.CreatedAt = time.Now()
+
+	res, err := r.commitments.InsertOne(ctx, commitment)
+	if err != nil {
+		return "", err
+	}
+
+	commitment.ID = res.InsertedID.(bson.ObjectID)
+	return commitment.ID.Hex(), nil
+}
@@ -0,0 +1,256 @@
+package routing
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cprakhar/relief-ops/shared/geo"
+)
+
+// roadSpeeds maps OSM highway classes to default travel speeds in km/h.
+// Ways with a highway class not listed here are not routable.
+var roadSpeeds = map[string]float64{
+	"motorway":       100,
+	"motorway_link":  60,
+	"trunk":          80,
+	"trunk_link":     50,
+	"primary":        60,
+	"primary_link":   40,
+	"secondary":      50,
+	"secondary_link": 35,
+	"tertiary":       40,
+	"tertiary_link":  30,
+	"unclassified":   30,
+	"residential":    25,
+	"living_street":  10,
+	"service":        15,
+	"road":           20,
+	"track":          10,
+}
+
+// gridCellSize is the size, in degrees, of the spatial index cells used to snap points to the graph.
+const gridCellSize = 0.01
+
+type cell struct {
+	x, y int
+}
+
+type edge struct {
+	to     int
+	wayID  int64
+	length float64 // meters
+	speed  float64 // meters per second
+}
+
+// Graph is a directed road network built from an OSM extract. It is immutable once loaded
+// and safe for concurrent use.
+type Graph struct {
+	lats  []float64
+	lons  []float64
+	edges [][]edge
+	grid  map[cell][]int
+	ways  map[int64]bool // IDs of the routable ways
+}
+
+// osmElement is the subset of an OSM XML node or way needed to build the graph.
+type osmElement struct {
+	ID   int64   `xml:"id,attr"`
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Refs []struct {
+		Ref int64 `xml:"ref,attr"`
+	} `xml:"nd"`
+	Tags []struct {
+		Key   string `xml:"k,attr"`
+		Value string `xml:"v,attr"`
+	} `xml:"tag"`
+}
+
+func (e *osmElement) tag(key string) string {
+	for _, t := range e.Tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// LoadOSMFile builds a road graph from an OSM XML extract (optionally gzip compressed).
+func LoadOSMFile(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open road graph: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip road graph: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return LoadOSM(r)
+}
+
+// LoadOSM builds a road graph from an OSM XML document.
+func LoadOSM(r io.Reader) (*Graph, error) {
+	coords := make(map[int64][2]float64)
+	var ways []*osmElement
+
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse road graph: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || (start.Name.Local != "node" && start.Name.Local != "way") {
+			continue
+		}
+
+		var el osmElement
+		if err := dec.DecodeElement(&el, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", start.Name.Local, err)
+		}
+
+		if start.Name.Local == "node" {
+			coords[el.ID] = [2]float64{el.Lat, el.Lon}
+		} else if _, ok := roadSpeeds[el.tag("highway")]; ok {
+			ways = append(ways, &el)
+		}
+	}
+
+	g := &Graph{grid: make(map[cell][]int), ways: make(map[int64]bool, len(ways))}
+	index := make(map[int64]int)
+	nodeIndex := func(id int64) (int, bool) {
+		if i, ok := index[id]; ok {
+			return i, true
+		}
+		c, ok := coords[id]
+		if !ok {
+			return 0, false // referenced node is outside the extract
+		}
+		i := len(g.lats)
+		index[id] = i
+		g.lats = append(g.lats, c[0])
+		g.lons = append(g.lons, c[1])
+		g.edges = append(g.edges, nil)
+		key := cellOf(c[0], c[1])
+		g.grid[key] = append(g.grid[key], i)
+		return i, true
+	}
+
+	for _, w := range ways {
+		speed := waySpeed(w) / 3.6 // km/h to m/s
+		forward, backward := wayDirections(w)
+
+		for i := 1; i < len(w.Refs); i++ {
+			a, okA := nodeIndex(w.Refs[i-1].Ref)
+			b, okB := nodeIndex(w.Refs[i].Ref)
+			if !okA || !okB {
+				continue
+			}
+			length := geo.Haversine(g.lats[a], g.lons[a], g.lats[b], g.lons[b])
+			if forward {
+				g.edges[a] = append(g.edges[a], edge{to: b, wayID: w.ID, length: length, speed: speed})
+			}
+			if backward {
+				g.edges[b] = append(g.edges[b], edge{to: a, wayID: w.ID, length: length, speed: speed})
+			}
+			g.ways[w.ID] = true
+		}
+	}
+
+	if len(g.lats) == 0 {
+		return nil, fmt.Errorf("road graph contains no routable ways")
+	}
+
+	return g, nil
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (g *Graph) NodeCount() int {
+	return len(g.lats)
+}
+
+// HasWay reports whether an OSM way is part of the road network.
+func (g *Graph) HasWay(wayID int64) bool {
+	return g.ways[wayID]
+}
+
+// waySpeed returns the travel speed in km/h, preferring a numeric maxspeed tag over the road class default.
+func waySpeed(w *osmElement) float64 {
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(w.tag("maxspeed"), " km/h"), 64); err == nil && v > 0 {
+		return v
+	}
+	return roadSpeeds[w.tag("highway")]
+}
+
+// wayDirections reports whether a way can be travelled along and against its node order.
+func wayDirections(w *osmElement) (forward, backward bool) {
+	switch w.tag("oneway") {
+	case "yes", "true", "1":
+		return true, false
+	case "-1", "reverse":
+		return false, true
+	case "no", "false", "0":
+		return true, true
+	}
+	// Motorways and roundabouts are implicitly one-way
+	if w.tag("highway") == "motorway" || w.tag("junction") == "roundabout" {
+		return true, false
+	}
+	return true, true
+}
+
+func cellOf(lat, lon float64) cell {
+	return cell{x: int(math.Floor(lon / gridCellSize)), y: int(math.Floor(lat / gridCellSize))}
+}
+
+// nearestNode returns the graph node closest to the given point within maxDistance meters.
+func (g *Graph) nearestNode(lat, lon, maxDistance float64) (int, float64, bool) {
+	center := cellOf(lat, lon)
+	// A cell is at least this many meters wide, which bounds how far the search must expand
+	cellMeters := gridCellSize * math.Pi / 180 * geo.EarthRadius * math.Max(math.Cos(lat*math.Pi/180), 0.01)
+	maxRing := int(math.Ceil(maxDistance/cellMeters)) + 1
+
+	best, bestDist := -1, math.Inf(1)
+	for ring := 0; ring <= maxRing; ring++ {
+		for x := center.x - ring; x <= center.x+ring; x++ {
+			for y := center.y - ring; y <= center.y+ring; y++ {
+				// Only visit the cells on the ring boundary
+				if ring > 0 && x != center.x-ring && x != center.x+ring && y != center.y-ring && y != center.y+ring {
+					continue
+				}
+				for _, n := range g.grid[cell{x: x, y: y}] {
+					if d := geo.Haversine(lat, lon, g.lats[n], g.lons[n]); d < bestDist {
+						best, bestDist = n, d
+					}
+				}
+			}
+		}
+		// Nodes in further rings are at least ring*cellMeters away
+		if best >= 0 && bestDist <= float64(ring)*cellMeters {
+			break
+		}
+	}
+
+	if best < 0 || bestDist > maxDistance {
+		return 0, 0, false
+	}
+	return best, bestDist, true
+}
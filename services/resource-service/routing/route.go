@@ -0,0 +1,136 @@
+package routing
+
+import (
+	"container/heap"
+	"math"
+)
+
+const (
+	// MaxSnapDistance is how far (in meters) a point may be from the road network and still be routed.
+	MaxSnapDistance = 2000.0
+	// OffRoadSpeed is the speed (in meters per second) assumed between a point and its nearest road node.
+	OffRoadSpeed = 15 / 3.6
+)
+
+// Point is a geographic coordinate in degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Route is the shortest travel-time path between two points on the road network.
+type Route struct {
+	Distance float64 // meters
+	Duration float64 // seconds
+	Geometry []Point // only set when geometry is requested
+}
+
+type queueItem struct {
+	node int
+	cost float64
+}
+
+type priorityQueue []queueItem
+
+func (pq priorityQueue) Len() int           { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool { return pq[i].cost < pq[j].cost }
+func (pq priorityQueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *priorityQueue) Push(x any)        { *pq = append(*pq, x.(queueItem)) }
+func (pq *priorityQueue) Pop() any {
+	old := *pq
+	item := old[len(old)-1]
+	*pq = old[:len(old)-1]
+	return item
+}
+
+// Routes computes the fastest route from origin to each target using Dijkstra's algorithm,
+// skipping any edge that belongs to a blocked OSM way. The search stops once every reachable
+// target is settled. The returned slice is aligned with targets; unreachable targets are nil.
+func (g *Graph) Routes(origin Point, targets []Point, blocked map[int64]bool, withGeometry bool) []*Route {
+	routes := make([]*Route, len(targets))
+
+	source, sourceSnap, ok := g.nearestNode(origin.Lat, origin.Lon, MaxSnapDistance)
+	if !ok {
+		return routes
+	}
+
+	// Map each graph node to the targets snapped onto it
+	pending := 0
+	targetNodes := make(map[int][]int)
+	targetSnaps := make([]float64, len(targets))
+	for i, t := range targets {
+		n, d, ok := g.nearestNode(t.Lat, t.Lon, MaxSnapDistance)
+		if !ok {
+			continue
+		}
+		targetNodes[n] = append(targetNodes[n], i)
+		targetSnaps[i] = d
+		pending++
+	}
+	if pending == 0 {
+		return routes
+	}
+
+	n := len(g.lats)
+	cost := make([]float64, n)
+	length := make([]float64, n)
+	prev := make([]int32, n)
+	settled := make([]bool, n)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	cost[source] = 0
+
+	pq := &priorityQueue{{node: source, cost: 0}}
+	for pq.Len() > 0 && pending > 0 {
+		item := heap.Pop(pq).(queueItem)
+		u := item.node
+		if settled[u] {
+			continue
+		}
+		settled[u] = true
+
+		for _, i := range targetNodes[u] {
+			route := &Route{
+				Distance: sourceSnap + length[u] + targetSnaps[i],
+				Duration: (sourceSnap+targetSnaps[i])/OffRoadSpeed + cost[u],
+			}
+			if withGeometry {
+				route.Geometry = g.path(prev, u, origin, targets[i])
+			}
+			routes[i] = route
+			pending--
+		}
+
+		for _, e := range g.edges[u] {
+			if blocked[e.wayID] || settled[e.to] {
+				continue
+			}
+			c := cost[u] + e.length/e.speed
+			if c < cost[e.to] {
+				cost[e.to] = c
+				length[e.to] = length[u] + e.length
+				prev[e.to] = int32(u)
+				heap.Push(pq, queueItem{node: e.to, cost: c})
+			}
+		}
+	}
+
+	return routes
+}
+
+// path reconstructs the route geometry from origin to target through the predecessor tree.
+func (g *Graph) path(prev []int32, node int, origin, target Point) []Point {
+	var nodes []Point
+	for n := int32(node); n >= 0; n = prev[n] {
+		nodes = append(nodes, Point{Lat: g.lats[n], Lon: g.lons[n]})
+	}
+
+	points := make([]Point, 0, len(nodes)+2)
+	points = append(points, origin)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		points = append(points, nodes[i])
+	}
+	return append(points, target)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,10 +11,22 @@ import (
 	"time"
 
 	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/services/resource-service/routing"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/taxonomy"
+	"github.com/cprakhar/relief-ops/shared/tilecache"
 	"github.com/cprakhar/relief-ops/shared/tools"
 	"github.com/cprakhar/relief-ops/shared/types"
 )
 
+var ErrUnknownCategory = errors.New("unknown resource category")
+
+const (
+	DefaultNearbyLimit = 50
+	MaxNearbyLimit     = 500
+	MaxBoundsLimit     = 5000
+)
+
 type OverpassResponse struct {
 	Elements []struct {
 		Type   string  `json:"type"`
@@ -24,53 +37,76 @@ type OverpassResponse struct {
 			Lat float64 `json:"lat"`
 			Lon float64 `json:"lon"`
 		} `json:"center,omitempty"`
-		Tags *struct {
-			Name    string `json:"name,omitempty"`
-			Amenity string `json:"amenity,omitempty"`
-		} `json:"tags,omitempty"`
+		Tags map[string]string `json:"tags,omitempty"`
 	} `json:"elements"`
+	// Remark is set when the query timed out or failed at runtime; the elements are then incomplete.
+	Remark string `json:"remark,omitempty"`
 }
 
 type resourceService struct {
-	repo repo.ResourceRepo
+	repo         repo.ResourceRepo
+	blockedRoads repo.BlockedRoadRepo
+	supply       repo.SupplyRepo
+	regions      repo.RefreshRegionRepo
+	taxonomy     *taxonomy.Taxonomy
+	graph        *routing.Graph
+	refresh      *RefreshConfig
+	tiles        *tilecache.Cache
+	radius       *RadiusPolicy
 }
 
 // ResourceService defines the interface for resource service operations.
 type ResourceService interface {
 	SaveResources(ctx context.Context, rg int, lat, lon float64) error
-	GetNearbyResources(ctx context.Context, lat, lon float64, radiusMeters int) ([]*types.Resource, error)
+	GetNearbyResources(ctx context.Context, q *repo.NearbyQuery) ([]*types.Resource, error)
+	GetResource(ctx context.Context, id string) (*types.Resource, error)
+	RankResourcesByTravelTime(ctx context.Context, q *repo.NearbyQuery, withGeometry bool) ([]*RankedResource, error)
+	BlockRoad(ctx context.Context, road *types.BlockedRoad) error
+	UnblockRoad(ctx context.Context, wayID int64) error
+	ListBlockedRoads(ctx context.Context) ([]*types.BlockedRoad, error)
+	CreateResource(ctx context.Context, resource *types.Resource) (string, error)
+	SetInventory(ctx context.Context, resourceID, item string, quantity int64) (*types.Resource, error)
+	DeclareNeed(ctx context.Context, need *types.Need) (string, error)
+	ListNeeds(ctx context.Context, disasterID string) ([]*types.Need, error)
+	MatchNeeds(ctx context.Context, disasterID string, radiusMeters int) ([]*SupplyProposal, error)
+	CommitSupply(ctx context.Context, disasterID, needID, depotID string, quantity int64, committedBy string) (*types.SupplyCommitment, error)
+	TrackDisasterRegion(ctx context.Context, disasterID string, rg int, lat, lon float64) (*types.RefreshRegion, error)
+	TrackDisaster(ctx context.Context, disasterID string, tags []string, radius int, lat, lon float64) (*types.RefreshRegion, error)
+	WatchRegion(ctx context.Context, name string, rg int, lat, lon float64) (*types.RefreshRegion, error)
+	UnwatchRegion(ctx context.Context, regionID string) error
+	ListRefreshRegions(ctx context.Context) ([]*types.RefreshRegion, error)
+	RefreshRegion(ctx context.Context, regionID string) (*types.RefreshRegion, error)
+	SummarizeRegion(ctx context.Context, region *types.RefreshRegion) (*RegionSummary, error)
+	RunRefresher(ctx context.Context) error
+	GetResourcesInBounds(ctx context.Context, b types.Bounds, categories []string, limit int) ([]*types.Resource, error)
 }
 
 // NewResourceService creates a new instance of resourceService.
-func NewResourceService(r repo.ResourceRepo) ResourceService {
-	return &resourceService{repo: r}
+// The road graph is optional; without it travel-time ranking is unavailable.
+// The tile cache is optional; when set, cached resource tiles are invalidated as resources change.
+func NewResourceService(r repo.ResourceRepo, br repo.BlockedRoadRepo, sr repo.SupplyRepo, rr repo.RefreshRegionRepo, t *taxonomy.Taxonomy, g *routing.Graph, rc *RefreshConfig, tc *tilecache.Cache) ResourceService {
+	return &resourceService{repo: r, blockedRoads: br, supply: sr, regions: rr, taxonomy: t, graph: g, refresh: rc, tiles: tc, radius: &DefaultRadiusPolicy}
 }
 
-// findResourcesWithinRadius queries the Overpass API to find resources within a given radius (in meters) of specified coordinates.
-func findResourcesWithinRadius(rg int, lat, lon float64) ([]*types.Resource, error) {
-	overpassURL := "http://overpass-api.de/api/interpreter"
+// buildOverpassQuery builds an Overpass QL query matching every tag filter in the taxonomy within a given radius.
+func buildOverpassQuery(t *taxonomy.Taxonomy, rg int, lat, lon float64) string {
+	keys, values := t.FiltersByKey()
 
-	amenities := []string{
-		types.Hospital,
-		types.FireStation,
-		types.Police,
-		types.Shelter,
-		types.Pharmacy,
+	var sb strings.Builder
+	sb.WriteString("[out:json][timeout:30];\n(\n")
+	for _, key := range keys {
+		union := strings.Join(values[key], "|")
+		fmt.Fprintf(&sb, "\tnwr[\"%s\"~\"^(%s)$\"](around:%d, %f, %f);\n", key, union, rg, lat, lon)
 	}
+	sb.WriteString(");\nout center;")
+	return sb.String()
+}
 
-	union := strings.Join(amenities, "|")
-	query := fmt.Sprintf(`
-		[out:json][timeout:30];
-		(
-			node["amenity"~"%s"](around:%d, %f, %f);
-			way["amenity"~"%s"](around:%d, %f, %f);
-			relation["amenity"~"%s"](around:%d, %f, %f);
-		);
-		out center;`,
-		union, rg, lat, lon,
-		union, rg, lat, lon,
-		union, rg, lat, lon,
-	)
+// findResourcesWithinRadius queries the Overpass API to find resources within a given radius (in meters) of specified coordinates.
+func findResourcesWithinRadius(t *taxonomy.Taxonomy, rg int, lat, lon float64) ([]*types.Resource, error) {
+	overpassURL := "http://overpass-api.de/api/interpreter"
+
+	query := buildOverpassQuery(t, rg, lat, lon)
 
 	res, err := http.Post(overpassURL, "text/plain", strings.NewReader(query))
 	if err != nil {
@@ -87,6 +123,10 @@ func findResourcesWithinRadius(rg int, lat, lon float64) ([]*types.Resource, err
 	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode Overpass API response: %w", err)
 	}
+	// Overpass answers 200 with partial elements when a query fails midway
+	if data.Remark != "" {
+		return nil, fmt.Errorf("overpass API returned an incomplete result: %s", data.Remark)
+	}
 
 	var resources []*types.Resource
 	for _, element := range data.Elements {
@@ -101,14 +141,15 @@ func findResourcesWithinRadius(rg int, lat, lon float64) ([]*types.Resource, err
 			continue // Skip if no coordinates are available
 		}
 
-		resourceType := ""
-		if element.Tags != nil {
-			resourceType = element.Tags.Amenity
+		category, ok := t.Classify(element.Tags)
+		if !ok {
+			continue // Skip elements that match no taxonomy category
 		}
 
 		resource := &types.Resource{
-			Name:        element.Tags.Name,
-			AmenityType: resourceType,
+			OSMID:       fmt.Sprintf("%s/%d", element.Type, element.ID),
+			Name:        element.Tags["name"],
+			AmenityType: category,
 			Location: &types.Location{
 				Type:        "Point",
 				Coordinates: []float64{lon, lat}, // Note: GeoJSON format is [longitude, latitude]
@@ -122,6 +163,15 @@ func findResourcesWithinRadius(rg int, lat, lon float64) ([]*types.Resource, err
 
 // SaveResources fetches resources from the Overpass API within a given radius and saves them to the repository.
 func (s *resourceService) SaveResources(ctx context.Context, rg int, lat, lon float64) error {
+	found, err := s.fetchAndSave(ctx, rg, lat, lon)
+	if found > 0 {
+		s.invalidateTiles(ctx)
+	}
+	return err
+}
+
+// fetchAndSave fetches resources from the Overpass API with retries, saves them and returns how many were found.
+func (s *resourceService) fetchAndSave(ctx context.Context, rg int, lat, lon float64) (int, error) {
 	retryCfg := &tools.RetryConfig{
 		MaxAttempts:   3,
 		InitialDelay:  time.Millisecond * 100,
@@ -130,18 +180,63 @@ func (s *resourceService) SaveResources(ctx context.Context, rg int, lat, lon fl
 		Jitter:        true,
 	}
 
-	return tools.RetryWithBackoff(ctx, retryCfg, func() error {
-		resources, err := findResourcesWithinRadius(rg, lat, lon)
+	var found int
+	err := tools.RetryWithBackoff(ctx, retryCfg, func() error {
+		resources, err := findResourcesWithinRadius(s.taxonomy, rg, lat, lon)
 		if err != nil {
 			return err
 		}
 		log.Printf("Found %d resources from Overpass API", len(resources))
-		log.Printf("Resources: %+v", resources)
+		found = len(resources)
 		return s.repo.AddResources(ctx, resources)
 	})
+	return found, err
+}
+
+// GetResource retrieves a resource by its ID.
+func (s *resourceService) GetResource(ctx context.Context, id string) (*types.Resource, error) {
+	return s.repo.GetByID(ctx, id)
 }
 
-// GetNearbyResources retrieves resources within a certain radius (in meters) of given coordinates.
-func (s *resourceService) GetNearbyResources(ctx context.Context, lat, lon float64, radiusMeters int) ([]*types.Resource, error) {
-	return s.repo.GetNearbyResources(ctx, lat, lon, radiusMeters)
+// GetNearbyResources retrieves resources within a certain radius (in meters) of given coordinates,
+// nearest first, optionally restricted to the given taxonomy categories.
+func (s *resourceService) GetNearbyResources(ctx context.Context, q *repo.NearbyQuery) ([]*types.Resource, error) {
+	for _, c := range q.AmenityTypes {
+		if !s.taxonomy.Has(c) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, c)
+		}
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultNearbyLimit
+	}
+	q.Limit = min(q.Limit, MaxNearbyLimit)
+	q.Offset = max(q.Offset, 0)
+	q.PerCategory = max(q.PerCategory, 0)
+
+	return s.repo.GetNearbyResources(ctx, q)
+}
+
+// GetResourcesInBounds retrieves open resources within a bounding box, optionally restricted to the given
+// taxonomy categories, e.g., to render a map tile.
+func (s *resourceService) GetResourcesInBounds(ctx context.Context, b types.Bounds, categories []string, limit int) ([]*types.Resource, error) {
+	for _, c := range categories {
+		if !s.taxonomy.Has(c) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, c)
+		}
+	}
+
+	if limit <= 0 || limit > MaxBoundsLimit {
+		limit = MaxBoundsLimit
+	}
+
+	return s.repo.GetInBounds(ctx, b, categories, limit)
+}
+
+// invalidateTiles discards cached resource tiles after resources changed.
+// A failure only delays the change showing on the map, so it is logged rather than returned.
+func (s *resourceService) invalidateTiles(ctx context.Context) {
+	if err := s.tiles.Invalidate(ctx, tilecache.LayerResources); err != nil {
+		logs.L().Warnw("Failed to invalidate resource tiles", "error", err)
+	}
 }
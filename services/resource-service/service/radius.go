@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// RadiusPolicy decides how far around a disaster resources are searched for.
+// The search starts from a per-hazard radius and widens until enough critical resources are found.
+type RadiusPolicy struct {
+	HazardRadius   map[string]int   // initial radius (in meters) per hazard, matched against disaster tags
+	DefaultRadius  int              // initial radius for disasters without a known hazard
+	MaxRadius      int              // radius the search stops widening at
+	Growth         float64          // factor the radius grows by on each widening step
+	MinPerCategory map[string]int64 // critical categories and how many of each must be found
+}
+
+// DefaultRadiusPolicy is the radius policy used for reported disasters.
+var DefaultRadiusPolicy = RadiusPolicy{
+	HazardRadius: map[string]int{
+		"fire":              5000,
+		"explosion":         5000,
+		"building_collapse": 5000,
+		"wildfire":          10000,
+		"landslide":         10000,
+		"flood":             20000,
+		"earthquake":        25000,
+		"storm":             30000,
+		"tsunami":           30000,
+		"cyclone":           50000,
+		"hurricane":         50000,
+		"typhoon":           50000,
+	},
+	DefaultRadius: 10000,
+	MaxRadius:     MaxRegionRadius,
+	Growth:        2,
+	MinPerCategory: map[string]int64{
+		"hospital":       2,
+		"shelter":        2,
+		"drinking_water": 1,
+	},
+}
+
+// InitialRadius returns the radius to start searching at for a disaster with the given tags.
+// With several known hazards, the widest one wins.
+func (p *RadiusPolicy) InitialRadius(tags []string) int {
+	rg := 0
+	for _, tag := range tags {
+		hazard := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), " ", "_")
+		hazard = strings.ReplaceAll(hazard, "-", "_")
+		rg = max(rg, p.HazardRadius[hazard])
+	}
+	if rg == 0 {
+		rg = p.DefaultRadius
+	}
+	return min(rg, p.MaxRadius)
+}
+
+// TrackDisaster syncs and keeps refreshing the resources around a reported disaster. A positive radius is
+// used as is; otherwise the radius policy picks it, widening the search until every critical category has
+// enough resources or the maximum radius is reached. The returned region carries the chosen radius.
+func (s *resourceService) TrackDisaster(ctx context.Context, disasterID string, tags []string, radius int, lat, lon float64) (*types.RefreshRegion, error) {
+	if radius > 0 {
+		return s.TrackDisasterRegion(ctx, disasterID, radius, lat, lon)
+	}
+
+	rg := s.radius.InitialRadius(tags)
+	for {
+		region, err := s.TrackDisasterRegion(ctx, disasterID, rg, lat, lon)
+		if err != nil || rg >= s.radius.MaxRadius {
+			return region, err
+		}
+
+		counts, err := s.repo.CountNearbyByCategory(ctx, lat, lon, rg)
+		if err != nil {
+			return region, err
+		}
+		if s.enoughCriticalResources(counts) {
+			return region, nil
+		}
+
+		next := min(int(float64(rg)*s.radius.Growth), s.radius.MaxRadius)
+		if next <= rg {
+			return region, nil
+		}
+		logs.L().Infow("Widening disaster search radius", "disaster_id", disasterID, "radius", rg, "next", next, "counts", counts)
+		rg = next
+	}
+}
+
+// enoughCriticalResources reports whether the counts meet the policy minimum of every critical category
+// known to the taxonomy.
+func (s *resourceService) enoughCriticalResources(counts map[string]int64) bool {
+	for category, minimum := range s.radius.MinPerCategory {
+		if s.taxonomy.Has(category) && counts[category] < minimum {
+			return false
+		}
+	}
+	return true
+}
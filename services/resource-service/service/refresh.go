@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var ErrInvalidRegion = errors.New("invalid refresh region")
+
+// MaxRegionRadius caps the radius (in meters) of a refreshed region to keep Overpass queries affordable.
+const MaxRegionRadius = 50000
+
+// RegionSummaryLimit caps how many of the nearest resources a region summary lists.
+const RegionSummaryLimit = 100
+
+// RegionSummary describes the open resources of a region.
+type RegionSummary struct {
+	Resources []*types.Resource // nearest first, at most RegionSummaryLimit
+	Counts    map[string]int64  // all resources per category
+	Total     int64
+}
+
+// RefreshConfig controls the background refresh of OSM resources.
+type RefreshConfig struct {
+	Interval       time.Duration // how often each region is re-synced
+	CheckEvery     time.Duration // how often the refresher looks for due regions
+	DisasterWindow time.Duration // how long the area around a disaster keeps being refreshed
+}
+
+// TrackDisasterRegion syncs the resources around a newly reported disaster and keeps the area
+// refreshed for the configured disaster window.
+func (s *resourceService) TrackDisasterRegion(ctx context.Context, disasterID string, rg int, lat, lon float64) (*types.RefreshRegion, error) {
+	region := &types.RefreshRegion{
+		ID:         types.RegionDisaster + ":" + disasterID,
+		Kind:       types.RegionDisaster,
+		Name:       disasterID,
+		Location:   types.Coordinates{Latitude: lat, Longitude: lon},
+		Radius:     min(rg, MaxRegionRadius),
+		ExpiresAt:  time.Now().Add(s.refresh.DisasterWindow),
+		LastStatus: types.RefreshPending,
+	}
+	if err := s.regions.Upsert(ctx, region); err != nil {
+		return nil, err
+	}
+
+	return region, s.refreshRegion(ctx, region)
+}
+
+// WatchRegion adds a region whose resources are refreshed until it is removed.
+func (s *resourceService) WatchRegion(ctx context.Context, name string, rg int, lat, lon float64) (*types.RefreshRegion, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidRegion)
+	}
+	if rg <= 0 || rg > MaxRegionRadius {
+		return nil, fmt.Errorf("%w: radius must be between 1 and %d meters", ErrInvalidRegion, MaxRegionRadius)
+	}
+
+	// A zero last refresh time makes the region due on the refresher's next pass
+	region := &types.RefreshRegion{
+		ID:         types.RegionWatch + ":" + bson.NewObjectID().Hex(),
+		Kind:       types.RegionWatch,
+		Name:       name,
+		Location:   types.Coordinates{Latitude: lat, Longitude: lon},
+		Radius:     rg,
+		LastStatus: types.RefreshPending,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.regions.Upsert(ctx, region); err != nil {
+		return nil, err
+	}
+	return region, nil
+}
+
+// UnwatchRegion stops refreshing a region. Its resources are kept as they are.
+func (s *resourceService) UnwatchRegion(ctx context.Context, regionID string) error {
+	return s.regions.Delete(ctx, regionID)
+}
+
+// ListRefreshRegions retrieves all refreshed regions with the outcome of their latest refresh.
+func (s *resourceService) ListRefreshRegions(ctx context.Context) ([]*types.RefreshRegion, error) {
+	return s.regions.List(ctx)
+}
+
+// RefreshRegion re-syncs a region immediately and returns its updated status.
+func (s *resourceService) RefreshRegion(ctx context.Context, regionID string) (*types.RefreshRegion, error) {
+	region, err := s.regions.GetByID(ctx, regionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return region, s.refreshRegion(ctx, region)
+}
+
+// SummarizeRegion lists the nearest open resources of a region along with per-category counts.
+func (s *resourceService) SummarizeRegion(ctx context.Context, region *types.RefreshRegion) (*RegionSummary, error) {
+	lat, lon := region.Location.Latitude, region.Location.Longitude
+
+	resources, err := s.repo.GetNearbyResources(ctx, &repo.NearbyQuery{
+		Lat:          lat,
+		Lon:          lon,
+		RadiusMeters: region.Radius,
+		Limit:        RegionSummaryLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountNearbyByCategory(ctx, lat, lon, region.Radius)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &RegionSummary{Resources: resources, Counts: counts}
+	for _, n := range counts {
+		summary.Total += n
+	}
+	return summary, nil
+}
+
+// RunRefresher periodically re-syncs every region that has not been refreshed within the
+// configured interval, until the context is cancelled.
+func (s *resourceService) RunRefresher(ctx context.Context) error {
+	logger := logs.L()
+
+	ticker := time.NewTicker(s.refresh.CheckEvery)
+	defer ticker.Stop()
+
+	for {
+		regions, err := s.regions.ListDue(ctx, time.Now().Add(-s.refresh.Interval))
+		if err != nil {
+			logger.Errorw("Failed to list regions due for refresh", "error", err)
+		}
+
+		// Refresh one region at a time to stay within Overpass rate limits
+		for _, region := range regions {
+			if ctx.Err() != nil {
+				break
+			}
+			if err := s.refreshRegion(ctx, region); err != nil {
+				logger.Errorw("Failed to refresh region", "region", region.ID, "error", err)
+				continue
+			}
+			logger.Infow("Region refreshed", "region", region.ID, "found", region.Found, "closed", region.Closed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshRegion re-syncs the resources of a region, closes the ones that vanished upstream
+// and records the outcome on the region.
+func (s *resourceService) refreshRegion(ctx context.Context, region *types.RefreshRegion) error {
+	lat, lon := region.Location.Latitude, region.Location.Longitude
+	start := time.Now()
+
+	region.LastRefreshAt = start
+	region.LastError = ""
+	region.Found = 0
+	region.Closed = 0
+
+	// Resources are only closed after a complete fetch; a failed or partial one leaves them as they are
+	found, err := s.fetchAndSave(ctx, region.Radius, lat, lon)
+	if err == nil {
+		region.Found = found
+		// An empty response is more likely an upstream hiccup than every resource closing at once
+		if found > 0 {
+			region.Closed, err = s.repo.CloseMissing(ctx, lat, lon, region.Radius, start)
+			s.invalidateTiles(ctx)
+		}
+	}
+
+	region.LastStatus = types.RefreshOK
+	if err != nil {
+		region.LastStatus = types.RefreshFailed
+		region.LastError = err.Error()
+	}
+
+	if recordErr := s.regions.RecordRefresh(ctx, region); recordErr != nil {
+		return errors.Join(err, recordErr)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/cprakhar/relief-ops/services/resource-service/repo"
+	"github.com/cprakhar/relief-ops/services/resource-service/routing"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+var (
+	ErrRoutingUnavailable = errors.New("road network routing is not configured")
+	ErrUnknownWay         = errors.New("way is not part of the road network")
+)
+
+// MaxRankCandidates caps how many straight-line candidates are routed for a single ranking.
+const MaxRankCandidates = 200
+
+// RankedResource is a resource with its travel distance and time over the road network.
+type RankedResource struct {
+	Resource  *types.Resource
+	Reachable bool
+	Distance  float64 // meters along the road network
+	Duration  float64 // seconds
+	Geometry  []routing.Point
+}
+
+// RankResourcesByTravelTime finds candidate resources around the origin and ranks them by
+// estimated travel time, avoiding roads blocked by admins. Unreachable resources are listed last.
+func (s *resourceService) RankResourcesByTravelTime(ctx context.Context, q *repo.NearbyQuery, withGeometry bool) ([]*RankedResource, error) {
+	if s.graph == nil {
+		return nil, ErrRoutingUnavailable
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultNearbyLimit
+	}
+	limit = min(limit, MaxNearbyLimit)
+
+	// Fetch straight-line candidates; the road network ordering may differ considerably
+	candidates, err := s.GetNearbyResources(ctx, &repo.NearbyQuery{
+		Lat:          q.Lat,
+		Lon:          q.Lon,
+		RadiusMeters: q.RadiusMeters,
+		AmenityTypes: q.AmenityTypes,
+		PerCategory:  q.PerCategory,
+		Limit:        MaxRankCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blockedRoads, err := s.blockedRoads.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(map[int64]bool, len(blockedRoads))
+	for _, r := range blockedRoads {
+		blocked[r.WayID] = true
+	}
+
+	targets := make([]routing.Point, len(candidates))
+	for i, c := range candidates {
+		targets[i] = routing.Point{Lat: c.Location.Coordinates[1], Lon: c.Location.Coordinates[0]}
+	}
+	routes := s.graph.Routes(routing.Point{Lat: q.Lat, Lon: q.Lon}, targets, blocked, withGeometry)
+
+	ranked := make([]*RankedResource, len(candidates))
+	for i, c := range candidates {
+		ranked[i] = &RankedResource{Resource: c}
+		if r := routes[i]; r != nil {
+			ranked[i].Reachable = true
+			ranked[i].Distance = r.Distance
+			ranked[i].Duration = r.Duration
+			ranked[i].Geometry = r.Geometry
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Reachable != ranked[j].Reachable {
+			return ranked[i].Reachable
+		}
+		return ranked[i].Duration < ranked[j].Duration
+	})
+
+	offset := min(max(q.Offset, 0), len(ranked))
+	ranked = ranked[offset:]
+	return ranked[:min(limit, len(ranked))], nil
+}
+
+// BlockRoad marks an OSM way as impassable for routing.
+func (s *resourceService) BlockRoad(ctx context.Context, road *types.BlockedRoad) error {
+	// Without a road graph any way may be blocked, so blocks can be recorded before routing is set up
+	if s.graph != nil && !s.graph.HasWay(road.WayID) {
+		return ErrUnknownWay
+	}
+	return s.blockedRoads.Block(ctx, road)
+}
+
+// UnblockRoad removes the block on an OSM way.
+func (s *resourceService) UnblockRoad(ctx context.Context, wayID int64) error {
+	return s.blockedRoads.Unblock(ctx, wayID)
+}
+
+// ListBlockedRoads retrieves all roads currently marked as blocked.
+func (s *resourceService) ListBlockedRoads(ctx context.Context) ([]*types.BlockedRoad, error) {
+	return s.blockedRoads.List(ctx)
+}
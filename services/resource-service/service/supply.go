@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/cprakhar/relief-ops/shared/geo"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+var (
+	ErrUnknownSupplyItem = errors.New("unknown supply item")
+	ErrInvalidUrgency    = errors.New("invalid urgency")
+	ErrInvalidQuantity   = errors.New("quantity must be positive")
+	ErrNeedMismatch      = errors.New("need does not belong to disaster")
+)
+
+// DefaultSupplyRadius is how far (in meters) depots are searched when matching needs.
+const DefaultSupplyRadius = 250000
+
+// SupplyProposal suggests shipping a quantity of an item from a depot to a disaster's need.
+type SupplyProposal struct {
+	NeedID     string
+	DisasterID string
+	Depot      *types.Resource
+	Item       string
+	Quantity   int64
+	Distance   float64 // meters from depot to disaster
+}
+
+// validateSupply checks the supply item and quantity.
+func validateSupply(item string, quantity int64) error {
+	if !slices.Contains(types.SupplyItems, item) {
+		return fmt.Errorf("%w: %s", ErrUnknownSupplyItem, item)
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	return nil
+}
+
+// DeclareNeed records a supply need for a disaster.
+func (s *resourceService) DeclareNeed(ctx context.Context, need *types.Need) (string, error) {
+	if err := validateSupply(need.Item, need.Quantity); err != nil {
+		return "", err
+	}
+	if !slices.Contains(types.UrgencyLevels, need.Urgency) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidUrgency, need.Urgency)
+	}
+
+	need.Committed = 0
+	return s.supply.CreateNeed(ctx, need)
+}
+
+// ListNeeds retrieves all needs declared for a disaster.
+func (s *resourceService) ListNeeds(ctx context.Context, disasterID string) ([]*types.Need, error) {
+	return s.supply.ListNeeds(ctx, disasterID)
+}
+
+// CreateResource adds a manually managed resource, such as a supply depot.
+func (s *resourceService) CreateResource(ctx context.Context, resource *types.Resource) (string, error) {
+	if !s.taxonomy.Has(resource.AmenityType) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCategory, resource.AmenityType)
+	}
+	for item, quantity := range resource.Inventory {
+		if !slices.Contains(types.SupplyItems, item) {
+			return "", fmt.Errorf("%w: %s", ErrUnknownSupplyItem, item)
+		}
+		if quantity < 0 {
+			return "", ErrInvalidQuantity
+		}
+	}
+
+	id, err := s.repo.Create(ctx, resource)
+	if err != nil {
+		return "", err
+	}
+	s.invalidateTiles(ctx)
+	return id, nil
+}
+
+// SetInventory sets the stock of a supply item held by a resource.
+func (s *resourceService) SetInventory(ctx context.Context, resourceID, item string, quantity int64) (*types.Resource, error) {
+	if !slices.Contains(types.SupplyItems, item) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSupplyItem, item)
+	}
+	if quantity < 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	return s.repo.SetInventory(ctx, resourceID, item, quantity)
+}
+
+// MatchNeeds proposes which depots should ship what to a disaster. Outstanding needs are served
+// most urgent first, each from the nearest depots with stock, without promising the same stock twice.
+func (s *resourceService) MatchNeeds(ctx context.Context, disasterID string, radiusMeters int) ([]*SupplyProposal, error) {
+	if radiusMeters <= 0 {
+		radiusMeters = DefaultSupplyRadius
+	}
+
+	needs, err := s.supply.ListNeeds(ctx, disasterID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(needs, func(i, j int) bool {
+		return slices.Index(types.UrgencyLevels, needs[i].Urgency) > slices.Index(types.UrgencyLevels, needs[j].Urgency)
+	})
+
+	// Stock already promised to earlier needs in this run, by depot and item
+	promised := make(map[string]map[string]int64)
+
+	var proposals []*SupplyProposal
+	for _, need := range needs {
+		outstanding := need.Quantity - need.Committed
+		if outstanding <= 0 {
+			continue
+		}
+
+		lat, lon := need.Location.Coordinates[1], need.Location.Coordinates[0]
+		depots, err := s.repo.GetStockedNearby(ctx, lat, lon, radiusMeters, need.Item)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, depot := range depots {
+			depotID := depot.ID.Hex()
+			if promised[depotID] == nil {
+				promised[depotID] = make(map[string]int64)
+			}
+
+			available := depot.Inventory[need.Item] - promised[depotID][need.Item]
+			if available <= 0 {
+				continue
+			}
+
+			quantity := min(available, outstanding)
+			promised[depotID][need.Item] += quantity
+			outstanding -= quantity
+
+			proposals = append(proposals, &SupplyProposal{
+				NeedID:     need.ID.Hex(),
+				DisasterID: need.DisasterID,
+				Depot:      depot,
+				Item:       need.Item,
+				Quantity:   quantity,
+				Distance:   depot.Distance,
+			})
+
+			if outstanding == 0 {
+				break
+			}
+		}
+	}
+
+	return proposals, nil
+}
+
+// CommitSupply reserves depot stock against a disaster's need and records the commitment.
+func (s *resourceService) CommitSupply(ctx context.Context, disasterID, needID, depotID string, quantity int64, committedBy string) (*types.SupplyCommitment, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	need, err := s.supply.GetNeed(ctx, needID)
+	if err != nil {
+		return nil, err
+	}
+	if need.DisasterID != disasterID {
+		return nil, ErrNeedMismatch
+	}
+
+	depot, err := s.repo.GetByID(ctx, depotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reserve the stock first, then claim the need; release the stock if the need is already covered
+	if err := s.repo.AdjustInventory(ctx, depotID, need.Item, -quantity); err != nil {
+		return nil, err
+	}
+	if err := s.supply.AddCommitted(ctx, needID, quantity); err != nil {
+		if rbErr := s.repo.AdjustInventory(ctx, depotID, need.Item, quantity); rbErr != nil {
+			return nil, fmt.Errorf("%w (failed to release stock: %v)", err, rbErr)
+		}
+		return nil, err
+	}
+
+	commitment := &types.SupplyCommitment{
+		NeedID:     needID,
+		DisasterID: need.DisasterID,
+		DepotID:    depotID,
+		Item:       need.Item,
+		Quantity:   quantity,
+		Distance: geo.Haversine(
+			depot.Location.Coordinates[1], depot.Location.Coordinates[0],
+			need.Location.Coordinates[1], need.Location.Coordinates[0],
+		),
+		CommittedBy: committedBy,
+	}
+	if _, err := s.supply.CreateCommitment(ctx, commitment); err != nil {
+		return nil, err
+	}
+
+	return commitment, nil
+}
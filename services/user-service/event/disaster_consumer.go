@@ -3,24 +3,21 @@ package event
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
-	"github.com/cprakhar/relief-ops/services/user-service/mail"
 	"github.com/cprakhar/relief-ops/services/user-service/service"
 	"github.com/cprakhar/relief-ops/shared/events"
 	"github.com/cprakhar/relief-ops/shared/messaging"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
 )
 
 type disasterConsumer struct {
 	kafkaClient *messaging.KafkaClient
 	svc         service.UserService
-	mailer      mail.Client
-	webURL      string
 }
 
 // NewDisasterConsumer creates a new instance of disasterConsumer.
-func NewDisasterConsumer(kc *messaging.KafkaClient, svc service.UserService, mailer mail.Client, w string) *disasterConsumer {
-	return &disasterConsumer{kafkaClient: kc, svc: svc, mailer: mailer, webURL: w}
+func NewDisasterConsumer(kc *messaging.KafkaClient, svc service.UserService) *disasterConsumer {
+	return &disasterConsumer{kafkaClient: kc, svc: svc}
 }
 
 // Consumer starts consuming messages from the specified topics.
@@ -31,36 +28,51 @@ func (dc *disasterConsumer) Consumer(ctx context.Context, topics []string) error
 			if err := dc.handleAdminNotify(ctx, value); err != nil {
 				return err
 			}
+		case events.DisasterEventApproved:
+			if err := dc.handleDisasterApproved(ctx, value); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
-// handleAdminNotify processes the admin notification event.
+// handleAdminNotify notifies admins of a new disaster report to review.
 func (dc *disasterConsumer) handleAdminNotify(ctx context.Context, value []byte) error {
-	users, err := dc.svc.GetAdmins(ctx)
-	if err != nil {
+	var data events.DisasterEventCreatedPayload
+	if err := json.Unmarshal(value, &data); err != nil {
 		return err
 	}
 
-	if len(users) == 0 {
-		return nil
+	report := &service.DisasterReport{
+		DisasterID:  data.DisasterID,
+		Title:       data.Title,
+		Severity:    data.Severity,
+		Location:    data.Location,
+		Tags:        data.Tags,
+		VolunteerID: data.VolunteerID,
 	}
+	return dc.svc.NotifyAdmins(ctx, report)
+}
 
-	var data events.DisasterEventCreatedPayload
+// handleDisasterApproved alerts the users subscribed to the area of an approved disaster.
+func (dc *disasterConsumer) handleDisasterApproved(ctx context.Context, value []byte) error {
+	var data events.DisasterEventApprovedPayload
 	if err := json.Unmarshal(value, &data); err != nil {
 		return err
 	}
 
-	adminData := struct {
-		DisasterID  string
-		VolunteerID string
-		ReviewURL   string
-	}{
-		DisasterID:  data.DisasterID,
-		VolunteerID: data.VolunteerID,
-		ReviewURL:   fmt.Sprintf("%s/admin/review/%s", dc.webURL, data.DisasterID),
+	alert := &service.DisasterAlert{
+		DisasterID: data.DisasterID,
+		Title:      data.Title,
+		Location:   data.Location,
+		Tags:       data.Tags,
+	}
+	alerted, err := dc.svc.AlertSubscribers(ctx, alert)
+	if err != nil {
+		return err
 	}
 
-	return dc.mailer.NotifyMultiple(users, adminData, false)
+	logs.L().Infow("Alerted subscribers of approved disaster", "disasterID", data.DisasterID, "users", alerted)
+	return nil
 }
@@ -12,14 +12,13 @@ import (
 )
 
 type gRPCServer struct {
-	addr      string
-	svc       service.UserService
-	jwtSecret string
+	addr string
+	svc  service.UserService
 }
 
 // newgRPCServer creates a new gRPC server instance.
-func newgRPCServer(addr string, svc service.UserService, s string) *gRPCServer {
-	return &gRPCServer{addr: addr, svc: svc, jwtSecret: s}
+func newgRPCServer(addr string, svc service.UserService) *gRPCServer {
+	return &gRPCServer{addr: addr, svc: svc}
 }
 
 // run starts the gRPC server and listens for incoming requests.
@@ -33,7 +32,7 @@ func (s *gRPCServer) run(ctx context.Context) error {
 
 	// Create a new gRPC server
 	srv := grpc.NewServer(traces.WithTracingInterceptors()...)
-	handler.NewUsergRPCHandler(srv, s.svc, s.jwtSecret)
+	handler.NewUsergRPCHandler(srv, s.svc)
 
 	// Listen for incoming requests in a separate goroutine
 	errChan := make(chan error, 1)
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestEmailVerification emails a user a new link to verify their email address.
+func (h *gRPCHandler) RequestEmailVerification(ctx context.Context, req *pb.RequestEmailVerificationRequest) (*pb.RequestEmailVerificationResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := h.svc.RequestEmailVerification(ctx, req.GetUserId()); err != nil {
+		return nil, accountStatus(err)
+	}
+
+	return &pb.RequestEmailVerificationResponse{}, nil
+}
+
+// ConfirmEmailVerification verifies an email address using the token from a verification link.
+func (h *gRPCHandler) ConfirmEmailVerification(ctx context.Context, req *pb.ConfirmEmailVerificationRequest) (*pb.ConfirmEmailVerificationResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	if err := h.svc.ConfirmEmailVerification(ctx, req.GetToken()); err != nil {
+		return nil, accountStatus(err)
+	}
+
+	return &pb.ConfirmEmailVerificationResponse{}, nil
+}
+
+// RequestPasswordReset emails a password reset link, if an account with the email address exists.
+func (h *gRPCHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if err := h.svc.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		return nil, accountStatus(err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+// ConfirmPasswordReset sets a new password using the token from a password reset link.
+func (h *gRPCHandler) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error) {
+	if req.GetToken() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and password are required")
+	}
+
+	if err := h.svc.ResetPassword(ctx, req.GetToken(), req.GetPassword()); err != nil {
+		return nil, accountStatus(err)
+	}
+
+	return &pb.ConfirmPasswordResetResponse{}, nil
+}
+
+// UnlockAccount lifts an account lockout using the token from an unlock link.
+func (h *gRPCHandler) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.UnlockAccountResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	if err := h.svc.UnlockAccount(ctx, req.GetToken()); err != nil {
+		return nil, accountStatus(err)
+	}
+
+	return &pb.UnlockAccountResponse{}, nil
+}
+
+// accountStatus maps account flow errors to gRPC status errors.
+func accountStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidActionToken):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "account action failed: %v", err)
+	}
+}
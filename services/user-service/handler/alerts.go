@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateAlertSubscription saves an area a user wants to be alerted about.
+func (h *gRPCHandler) CreateAlertSubscription(ctx context.Context, req *pb.CreateAlertSubscriptionRequest) (*pb.AlertSubscription, error) {
+	if req.GetSubscription() == nil {
+		return nil, status.Error(codes.InvalidArgument, "subscription is required")
+	}
+
+	sub, err := h.svc.CreateAlertSubscription(ctx, req.GetUserId(), fromPbAlertSubscription(req.GetSubscription()))
+	if err != nil {
+		return nil, alertStatus(err)
+	}
+
+	return toPbAlertSubscription(sub), nil
+}
+
+// ListAlertSubscriptions lists the alert subscriptions of a user.
+func (h *gRPCHandler) ListAlertSubscriptions(ctx context.Context, req *pb.ListAlertSubscriptionsRequest) (*pb.ListAlertSubscriptionsResponse, error) {
+	subs, err := h.svc.ListAlertSubscriptions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, alertStatus(err)
+	}
+
+	pbSubs := make([]*pb.AlertSubscription, 0, len(subs))
+	for _, sub := range subs {
+		pbSubs = append(pbSubs, toPbAlertSubscription(sub))
+	}
+
+	return &pb.ListAlertSubscriptionsResponse{Subscriptions: pbSubs}, nil
+}
+
+// DeleteAlertSubscription deletes an alert subscription of a user.
+func (h *gRPCHandler) DeleteAlertSubscription(ctx context.Context, req *pb.DeleteAlertSubscriptionRequest) (*pb.DeleteAlertSubscriptionResponse, error) {
+	if err := h.svc.DeleteAlertSubscription(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, alertStatus(err)
+	}
+
+	return &pb.DeleteAlertSubscriptionResponse{}, nil
+}
+
+func toPbAlertSubscription(sub *types.AlertSubscription) *pb.AlertSubscription {
+	pbSub := &pb.AlertSubscription{
+		Id:        sub.ID.Hex(),
+		UserId:    sub.UserID.Hex(),
+		Name:      sub.Name,
+		Radius:    int32(sub.Radius),
+		Hazards:   sub.Hazards,
+		Channels:  sub.Channels,
+		CreatedAt: timestamppb.New(sub.CreatedAt),
+	}
+	if sub.Center != nil {
+		pbSub.Center = &pb.Point{Latitude: sub.Center.Latitude, Longitude: sub.Center.Longitude}
+	}
+	for _, c := range sub.Polygon {
+		pbSub.Polygon = append(pbSub.Polygon, &pb.Point{Latitude: c.Latitude, Longitude: c.Longitude})
+	}
+	return pbSub
+}
+
+func fromPbAlertSubscription(pbSub *pb.AlertSubscription) *types.AlertSubscription {
+	sub := &types.AlertSubscription{
+		Name:     pbSub.GetName(),
+		Radius:   int(pbSub.GetRadius()),
+		Hazards:  pbSub.GetHazards(),
+		Channels: pbSub.GetChannels(),
+	}
+	if c := pbSub.GetCenter(); c != nil {
+		sub.Center = &types.Coordinates{Latitude: c.GetLatitude(), Longitude: c.GetLongitude()}
+	}
+	for _, c := range pbSub.GetPolygon() {
+		sub.Polygon = append(sub.Polygon, types.Coordinates{Latitude: c.GetLatitude(), Longitude: c.GetLongitude()})
+	}
+	return sub
+}
+
+// alertStatus maps alert subscription errors to gRPC status errors.
+func alertStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidSubscription):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrTooManySubscriptions):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "alert subscription action failed: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateAPIKey creates an API key for an organization. The key itself is only returned here.
+func (h *gRPCHandler) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.APIKey, error) {
+	perms := make([]authz.Permission, 0, len(req.GetPermissions()))
+	for _, p := range req.GetPermissions() {
+		perms = append(perms, authz.Permission(p))
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+
+	key, apiKey, err := h.svc.CreateAPIKey(ctx, req.GetActorId(), req.GetOrgId(), req.GetName(), perms, int(req.GetRateLimit()), expiresAt)
+	if err != nil {
+		return nil, apiKeyStatus(err)
+	}
+
+	pbKey := toPbAPIKey(apiKey)
+	pbKey.Key = key
+	return pbKey, nil
+}
+
+// ListAPIKeys lists the API keys of an organization.
+func (h *gRPCHandler) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	keys, err := h.svc.ListAPIKeys(ctx, req.GetActorId(), req.GetOrgId())
+	if err != nil {
+		return nil, apiKeyStatus(err)
+	}
+
+	pbKeys := make([]*pb.APIKey, 0, len(keys))
+	for _, k := range keys {
+		pbKeys = append(pbKeys, toPbAPIKey(k))
+	}
+
+	return &pb.ListAPIKeysResponse{Keys: pbKeys}, nil
+}
+
+// RevokeAPIKey revokes an API key of an organization.
+func (h *gRPCHandler) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.APIKey, error) {
+	apiKey, err := h.svc.RevokeAPIKey(ctx, req.GetActorId(), req.GetOrgId(), req.GetId())
+	if err != nil {
+		return nil, apiKeyStatus(err)
+	}
+
+	return toPbAPIKey(apiKey), nil
+}
+
+// VerifyAPIKey returns the API key presented by a request, if it is active, with whether its creator verified
+// their email address.
+func (h *gRPCHandler) VerifyAPIKey(ctx context.Context, req *pb.VerifyAPIKeyRequest) (*pb.APIKey, error) {
+	apiKey, creator, err := h.svc.VerifyAPIKey(ctx, req.GetKey())
+	if err != nil {
+		return nil, apiKeyStatus(err)
+	}
+
+	pbKey := toPbAPIKey(apiKey)
+	pbKey.CreatorEmailVerified = creator.EmailVerified
+	return pbKey, nil
+}
+
+func toPbAPIKey(k *types.APIKey) *pb.APIKey {
+	pbKey := &pb.APIKey{
+		Id:          k.ID.Hex(),
+		OrgId:       k.OrgID.Hex(),
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		Permissions: k.Permissions,
+		RateLimit:   int32(k.RateLimit),
+		CreatedBy:   k.CreatedBy,
+		CreatedAt:   timestamppb.New(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		pbKey.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	if k.LastUsedAt != nil {
+		pbKey.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		pbKey.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return pbKey
+}
+
+// apiKeyStatus maps API key errors to gRPC status errors. Management errors are those of organizations.
+func apiKeyStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidAPIKey):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrUnknownAPIKey):
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	default:
+		return orgStatus(err)
+	}
+}
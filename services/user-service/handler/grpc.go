@@ -2,21 +2,23 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/cprakhar/relief-ops/services/user-service/repo"
 	"github.com/cprakhar/relief-ops/services/user-service/service"
 	pb "github.com/cprakhar/relief-ops/shared/proto/user"
 	"github.com/cprakhar/relief-ops/shared/types"
-	"github.com/cprakhar/relief-ops/shared/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type gRPCHandler struct {
 	pb.UnimplementedUserServiceServer
-	svc       service.UserService
-	jwtSecret string
+	svc service.UserService
 }
 
 // GrpcHandler defines the gRPC handler interface for user service.
@@ -26,13 +28,56 @@ type GrpcHandler interface {
 	LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error)
 	GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error)
 	ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error)
+	RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error)
+	RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.LoginUserResponse, error)
+	GetJwks(ctx context.Context, req *pb.GetJwksRequest) (*pb.GetJwksResponse, error)
+	CheckTokenRevoked(ctx context.Context, req *pb.CheckTokenRevokedRequest) (*pb.CheckTokenRevokedResponse, error)
+	RequestEmailVerification(ctx context.Context, req *pb.RequestEmailVerificationRequest) (*pb.RequestEmailVerificationResponse, error)
+	ConfirmEmailVerification(ctx context.Context, req *pb.ConfirmEmailVerificationRequest) (*pb.ConfirmEmailVerificationResponse, error)
+	RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error)
+	UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.UnlockAccountResponse, error)
+	SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.RoleChange, error)
+	CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteResponse, error)
+	ListRoleChanges(ctx context.Context, req *pb.ListRoleChangesRequest) (*pb.ListRoleChangesResponse, error)
+	BeginMfaEnrollment(ctx context.Context, req *pb.BeginMfaEnrollmentRequest) (*pb.BeginMfaEnrollmentResponse, error)
+	ConfirmMfaEnrollment(ctx context.Context, req *pb.ConfirmMfaEnrollmentRequest) (*pb.RecoveryCodes, error)
+	DisableMfa(ctx context.Context, req *pb.DisableMfaRequest) (*pb.DisableMfaResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RecoveryCodes, error)
+	VerifyMfaLogin(ctx context.Context, req *pb.VerifyMfaLoginRequest) (*pb.LoginUserResponse, error)
+	GetVolunteerProfile(ctx context.Context, req *pb.GetVolunteerProfileRequest) (*pb.VolunteerProfile, error)
+	UpdateVolunteerProfile(ctx context.Context, req *pb.UpdateVolunteerProfileRequest) (*pb.VolunteerProfile, error)
+	FindVolunteers(ctx context.Context, req *pb.FindVolunteersRequest) (*pb.FindVolunteersResponse, error)
+	CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error)
+	GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.Organization, error)
+	ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error)
+	GetOrgMembership(ctx context.Context, req *pb.GetOrgMembershipRequest) (*pb.OrgMember, error)
+	ListOrgMembers(ctx context.Context, req *pb.ListOrgMembersRequest) (*pb.ListOrgMembersResponse, error)
+	InviteOrgMember(ctx context.Context, req *pb.InviteOrgMemberRequest) (*pb.OrgInvite, error)
+	AcceptOrgInvite(ctx context.Context, req *pb.AcceptOrgInviteRequest) (*pb.OrgMember, error)
+	SetOrgMemberRole(ctx context.Context, req *pb.SetOrgMemberRoleRequest) (*pb.OrgMember, error)
+	RemoveOrgMember(ctx context.Context, req *pb.RemoveOrgMemberRequest) (*pb.RemoveOrgMemberResponse, error)
+	CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error)
+	ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error)
+	GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error)
+	AddTeamMember(ctx context.Context, req *pb.TeamMemberRequest) (*pb.OrgMember, error)
+	RemoveTeamMember(ctx context.Context, req *pb.TeamMemberRequest) (*pb.OrgMember, error)
+	CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.APIKey, error)
+	ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.APIKey, error)
+	VerifyAPIKey(ctx context.Context, req *pb.VerifyAPIKeyRequest) (*pb.APIKey, error)
+	CreateAlertSubscription(ctx context.Context, req *pb.CreateAlertSubscriptionRequest) (*pb.AlertSubscription, error)
+	ListAlertSubscriptions(ctx context.Context, req *pb.ListAlertSubscriptionsRequest) (*pb.ListAlertSubscriptionsResponse, error)
+	DeleteAlertSubscription(ctx context.Context, req *pb.DeleteAlertSubscriptionRequest) (*pb.DeleteAlertSubscriptionResponse, error)
+	GetNotificationSettings(ctx context.Context, req *pb.GetNotificationSettingsRequest) (*pb.NotificationSettings, error)
+	UpdateNotificationSettings(ctx context.Context, req *pb.UpdateNotificationSettingsRequest) (*pb.NotificationSettings, error)
+	ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error)
 }
 
 // NewUsergRPCHandler registers the gRPC handler for user service.
-func NewUsergRPCHandler(srv *grpc.Server, svc service.UserService, s string) {
+func NewUsergRPCHandler(srv *grpc.Server, svc service.UserService) {
 	handler := &gRPCHandler{
-		svc:       svc,
-		jwtSecret: s,
+		svc: svc,
 	}
 	pb.RegisterUserServiceServer(srv, handler)
 }
@@ -42,16 +87,15 @@ func (h *gRPCHandler) OAuthSignIn(ctx context.Context, req *pb.OAuthSignInReques
 	email := req.GetEmail()
 	name := req.GetName()
 	avatarURL := req.GetAvatarUrl()
-	role := req.GetRole()
 
 	user := &types.User{
-		Name:      name,
-		Email:     email,
-		Role:      role,
-		AvatarURL: avatarURL,
+		Name:          name,
+		Email:         email,
+		AvatarURL:     avatarURL,
+		EmailVerified: true, // verified by the OAuth provider
 	}
 
-	userID, err := h.svc.CreateUser(ctx, user)
+	userID, err := h.svc.CreateUser(ctx, user, "")
 	if err != nil {
 		if err == repo.ErrResourceConflict {
 			// User already exists, fetch the existing user
@@ -63,21 +107,24 @@ func (h *gRPCHandler) OAuthSignIn(ctx context.Context, req *pb.OAuthSignInReques
 		}
 	}
 
-	token, err := h.svc.OAuthSignIn(ctx, user)
+	tokens, challenge, err := h.svc.OAuthSignIn(ctx, user)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to sign in user: %v", err)
 	}
+	if challenge != nil {
+		return toPbMFAChallenge(challenge), nil
+	}
 
-	return &pb.LoginUserResponse{
-		Token: token,
-		User: &pb.User{
-			Id:        userID,
-			Name:      user.Name,
-			Email:     user.Email,
-			Role:      user.Role,
-			AvatarUrl: user.AvatarURL,
-		},
-	}, nil
+	return toPbLogin(tokens, &pb.User{
+		Id:            userID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		AvatarUrl:     user.AvatarURL,
+		EmailVerified: user.EmailVerified,
+		Regions:       toPbRegions(user.Regions),
+		MfaEnabled:    user.MFA.Enabled,
+	}), nil
 }
 
 // RegisterUser handles user registration.
@@ -85,24 +132,25 @@ func (h *gRPCHandler) RegisterUser(ctx context.Context, req *pb.RegisterUserRequ
 	email := req.GetEmail()
 	password := req.GetPassword()
 	name := req.GetName()
-	role := req.GetRole()
 
 	user := &types.User{
 		Name:     name,
 		Email:    email,
 		Password: password,
-		Role:     role,
 	}
 
-	// Create the user
-	userID, err := h.svc.CreateUser(ctx, user)
+	// Create the user, with the role of the invite if one was given
+	userID, err := h.svc.CreateUser(ctx, user, req.GetInviteCode())
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidInvite) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
 	return &pb.RegisterUserResponse{
 		Id:   userID,
-		Role: role,
+		Role: string(user.Role),
 	}, nil
 }
 
@@ -111,20 +159,15 @@ func (h *gRPCHandler) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	email := req.GetEmail()
 	password := req.GetPassword()
 
-	user, token, err := h.svc.Login(ctx, email, password)
+	user, tokens, challenge, err := h.svc.Login(ctx, email, password, req.GetClientIp())
 	if err != nil {
-		return nil, err
+		return nil, loginStatus(err)
+	}
+	if challenge != nil {
+		return toPbMFAChallenge(challenge), nil
 	}
 
-	return &pb.LoginUserResponse{
-		Token: token,
-		User: &pb.User{
-			Id:    user.ID.Hex(),
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
-	}, nil
+	return toPbLogin(tokens, toPbUser(user)), nil
 }
 
 // GetUser retrieves a user by their ID.
@@ -133,32 +176,141 @@ func (h *gRPCHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 
 	user, err := h.svc.GetUserByID(ctx, userID)
 	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", userID)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 
-	return &pb.User{
-		Id:        user.ID.Hex(),
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		AvatarUrl: user.AvatarURL,
-	}, nil
+	return toPbUser(user), nil
 }
 
 // ValidateToken checks the validity of a JWT token and returns the associated user details.
 func (h *gRPCHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
 	tokenStr := req.GetToken()
 
-	userDetails, err := util.ParseToken(tokenStr, h.jwtSecret)
+	userDetails, err := h.svc.ValidateToken(ctx, tokenStr)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		return nil, tokenStatus(err)
 	}
 
 	return &pb.ValidateTokenResponse{
 		User: &pb.User{
-			Id:    userDetails.UserID,
-			Email: userDetails.Email,
-			Role:  userDetails.Role,
+			Id:            userDetails.UserID,
+			Email:         userDetails.Email,
+			Role:          userDetails.Role,
+			EmailVerified: userDetails.EmailVerified,
+			Regions:       toPbRegions(userDetails.Regions),
 		},
 	}, nil
 }
+
+// RevokeToken revokes a token, e.g., on logout, optionally along with every other token of the user.
+func (h *gRPCHandler) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	if err := h.svc.RevokeToken(ctx, req.GetToken(), req.GetRefreshToken(), req.GetAllSessions()); err != nil {
+		return nil, tokenStatus(err)
+	}
+
+	return &pb.RevokeTokenResponse{}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and refresh token.
+func (h *gRPCHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.LoginUserResponse, error) {
+	user, tokens, err := h.svc.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, tokenStatus(err)
+	}
+
+	return toPbLogin(tokens, toPbUser(user)), nil
+}
+
+// GetJwks returns the public keys tokens are verified with, including keys being rotated out.
+func (h *gRPCHandler) GetJwks(ctx context.Context, req *pb.GetJwksRequest) (*pb.GetJwksResponse, error) {
+	set, err := h.svc.JSONWebKeys()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get signing keys: %v", err)
+	}
+
+	keys := make([]*pb.JsonWebKey, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		keys = append(keys, &pb.JsonWebKey{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			Crv: k.Crv,
+			X:   k.X,
+			N:   k.N,
+			E:   k.E,
+		})
+	}
+
+	return &pb.GetJwksResponse{Keys: keys}, nil
+}
+
+// CheckTokenRevoked reports whether a token whose signature was verified by the caller has been revoked.
+func (h *gRPCHandler) CheckTokenRevoked(ctx context.Context, req *pb.CheckTokenRevokedRequest) (*pb.CheckTokenRevokedResponse, error) {
+	if req.GetJti() == "" || req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "jti and user_id are required")
+	}
+
+	revoked, err := h.svc.IsTokenRevoked(ctx, req.GetJti(), req.GetUserId(), req.GetGeneration())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check token: %v", err)
+	}
+
+	return &pb.CheckTokenRevokedResponse{Revoked: revoked}, nil
+}
+
+// toPbLogin converts an issued token pair to a login response.
+func toPbUser(user *types.User) *pb.User {
+	return &pb.User{
+		Id:            user.ID.Hex(),
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		AvatarUrl:     user.AvatarURL,
+		EmailVerified: user.EmailVerified,
+		Regions:       toPbRegions(user.Regions),
+		MfaEnabled:    user.MFA.Enabled,
+	}
+}
+
+func toPbLogin(tokens *service.TokenPair, user *pb.User) *pb.LoginUserResponse {
+	return &pb.LoginUserResponse{
+		Token:            tokens.AccessToken,
+		User:             user,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        int64(time.Until(tokens.AccessExpiresAt).Seconds()),
+		RefreshExpiresIn: int64(time.Until(tokens.RefreshExpiresAt).Seconds()),
+	}
+}
+
+// tokenStatus maps token validation errors to gRPC status errors.
+func tokenStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidToken), errors.Is(err, service.ErrTokenRevoked),
+		errors.Is(err, service.ErrTokenReused):
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to check token: %v", err)
+	}
+}
+
+// loginStatus maps login errors to gRPC status errors. Throttled attempts carry a RetryInfo detail
+// telling the caller when to try again.
+func loginStatus(err error) error {
+	var throttled *service.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		st := status.New(codes.ResourceExhausted, err.Error())
+		if detailed, derr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(throttled.RetryAfter)}); derr == nil {
+			st = detailed
+		}
+		return st.Err()
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to log in: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BeginMfaEnrollment generates a TOTP secret for a user to add to an authenticator app.
+func (h *gRPCHandler) BeginMfaEnrollment(ctx context.Context, req *pb.BeginMfaEnrollmentRequest) (*pb.BeginMfaEnrollmentResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	enrollment, err := h.svc.BeginMFAEnrollment(ctx, req.GetUserId())
+	if err != nil {
+		return nil, mfaStatus(err)
+	}
+
+	return &pb.BeginMfaEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+	}, nil
+}
+
+// ConfirmMfaEnrollment enables MFA with a code from the authenticator app and returns the recovery codes.
+func (h *gRPCHandler) ConfirmMfaEnrollment(ctx context.Context, req *pb.ConfirmMfaEnrollmentRequest) (*pb.RecoveryCodes, error) {
+	if req.GetUserId() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	recoveryCodes, err := h.svc.ConfirmMFAEnrollment(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, mfaStatus(err)
+	}
+
+	return &pb.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
+// DisableMfa turns off MFA for a user.
+func (h *gRPCHandler) DisableMfa(ctx context.Context, req *pb.DisableMfaRequest) (*pb.DisableMfaResponse, error) {
+	if req.GetUserId() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	if err := h.svc.DisableMFA(ctx, req.GetUserId(), req.GetCode()); err != nil {
+		return nil, mfaStatus(err)
+	}
+
+	return &pb.DisableMfaResponse{}, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user.
+func (h *gRPCHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RecoveryCodes, error) {
+	if req.GetUserId() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	recoveryCodes, err := h.svc.RegenerateRecoveryCodes(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, mfaStatus(err)
+	}
+
+	return &pb.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
+// VerifyMfaLogin completes a sign in with the MFA challenge from the first step and a code.
+func (h *gRPCHandler) VerifyMfaLogin(ctx context.Context, req *pb.VerifyMfaLoginRequest) (*pb.LoginUserResponse, error) {
+	if req.GetMfaToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa_token and code are required")
+	}
+
+	user, tokens, err := h.svc.VerifyMFALogin(ctx, req.GetMfaToken(), req.GetCode())
+	if err != nil {
+		return nil, mfaStatus(err)
+	}
+
+	return toPbLogin(tokens, toPbUser(user)), nil
+}
+
+func toPbMFAChallenge(challenge *service.MFAChallenge) *pb.LoginUserResponse {
+	return &pb.LoginUserResponse{
+		MfaRequired:  true,
+		MfaToken:     challenge.Token,
+		MfaExpiresIn: int64(time.Until(challenge.ExpiresAt).Seconds()),
+	}
+}
+
+// mfaStatus maps MFA errors to gRPC status errors. Code checks are throttled like logins.
+func mfaStatus(err error) error {
+	var throttled *service.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		return loginStatus(err)
+	case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrInvalidMFAChallenge):
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	case errors.Is(err, service.ErrMFANotEnabled), errors.Is(err, service.ErrMFAAlreadyEnabled),
+		errors.Is(err, service.ErrMFANotStarted):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "MFA action failed: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetNotificationSettings retrieves how a user wants to be notified.
+func (h *gRPCHandler) GetNotificationSettings(ctx context.Context, req *pb.GetNotificationSettingsRequest) (*pb.NotificationSettings, error) {
+	settings, err := h.svc.GetNotificationSettings(ctx, req.GetUserId())
+	if err != nil {
+		return nil, notificationStatus(err)
+	}
+
+	return toPbNotificationSettings(settings), nil
+}
+
+// UpdateNotificationSettings replaces how a user wants to be notified.
+func (h *gRPCHandler) UpdateNotificationSettings(ctx context.Context, req *pb.UpdateNotificationSettingsRequest) (*pb.NotificationSettings, error) {
+	pbSettings := req.GetSettings()
+	if pbSettings == nil {
+		return nil, status.Error(codes.InvalidArgument, "settings are required")
+	}
+
+	settings := &types.NotificationSettings{
+		Channels:       pbSettings.GetChannels(),
+		Phone:          pbSettings.GetPhone(),
+		PushTokens:     pbSettings.GetPushTokens(),
+		WebhookURL:     pbSettings.GetWebhookUrl(),
+		Locale:         pbSettings.GetLocale(),
+		ReportDelivery: pbSettings.GetReportDelivery(),
+	}
+	settings, err := h.svc.UpdateNotificationSettings(ctx, req.GetUserId(), settings)
+	if err != nil {
+		return nil, notificationStatus(err)
+	}
+
+	return toPbNotificationSettings(settings), nil
+}
+
+// ListDeliveries lists the most recent notifications sent to a user, with their delivery status.
+func (h *gRPCHandler) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	deliveries, err := h.svc.ListDeliveries(ctx, req.GetUserId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, notificationStatus(err)
+	}
+
+	pbDeliveries := make([]*pb.Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		pbDelivery := &pb.Delivery{
+			Id:        d.ID.Hex(),
+			UserId:    d.UserID.Hex(),
+			Kind:      d.Kind,
+			Status:    d.Status,
+			Channel:   d.Channel,
+			CreatedAt: timestamppb.New(d.CreatedAt),
+			UpdatedAt: timestamppb.New(d.UpdatedAt),
+		}
+		for _, a := range d.Attempts {
+			pbDelivery.Attempts = append(pbDelivery.Attempts, &pb.DeliveryAttempt{
+				Channel: a.Channel,
+				Error:   a.Error,
+				At:      timestamppb.New(a.At),
+			})
+		}
+		pbDeliveries = append(pbDeliveries, pbDelivery)
+	}
+
+	return &pb.ListDeliveriesResponse{Deliveries: pbDeliveries}, nil
+}
+
+func toPbNotificationSettings(s *types.NotificationSettings) *pb.NotificationSettings {
+	return &pb.NotificationSettings{
+		Channels:       s.Channels,
+		Phone:          s.Phone,
+		PushTokens:     s.PushTokens,
+		WebhookUrl:     s.WebhookURL,
+		Locale:         s.Locale,
+		ReportDelivery: s.ReportDelivery,
+	}
+}
+
+// notificationStatus maps notification errors to gRPC status errors.
+func notificationStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidNotificationSettings):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "user not found")
+	default:
+		return status.Errorf(codes.Internal, "notification action failed: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateOrganization creates an organization with the actor as its first org admin.
+func (h *gRPCHandler) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error) {
+	org, err := h.svc.CreateOrganization(ctx, req.GetActorId(), req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrganization(org), nil
+}
+
+// GetOrganization retrieves an organization by ID.
+func (h *gRPCHandler) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.Organization, error) {
+	org, err := h.svc.GetOrganization(ctx, req.GetId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrganization(org), nil
+}
+
+// ListOrganizations lists the organizations a user belongs to.
+func (h *gRPCHandler) ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	memberships, err := h.svc.ListOrganizations(ctx, req.GetUserId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	pbMemberships := make([]*pb.OrgMembership, 0, len(memberships))
+	for _, m := range memberships {
+		pbMemberships = append(pbMemberships, &pb.OrgMembership{
+			Organization: toPbOrganization(m.Org),
+			Membership:   toPbOrgMember(m.Membership),
+		})
+	}
+
+	return &pb.ListOrganizationsResponse{Memberships: pbMemberships}, nil
+}
+
+// GetOrgMembership retrieves a user's membership in an organization.
+func (h *gRPCHandler) GetOrgMembership(ctx context.Context, req *pb.GetOrgMembershipRequest) (*pb.OrgMember, error) {
+	membership, err := h.svc.GetOrgMembership(ctx, req.GetOrgId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrNotOrgMember) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrgMember(membership), nil
+}
+
+// ListOrgMembers lists the members of an organization, or of one of its teams.
+func (h *gRPCHandler) ListOrgMembers(ctx context.Context, req *pb.ListOrgMembersRequest) (*pb.ListOrgMembersResponse, error) {
+	members, err := h.svc.ListOrgMembers(ctx, req.GetActorId(), req.GetOrgId(), req.GetTeamId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	pbMembers := make([]*pb.OrgMember, 0, len(members))
+	for _, m := range members {
+		member := toPbOrgMember(&m.Membership)
+		member.Name = m.Name
+		member.Email = m.Email
+		pbMembers = append(pbMembers, member)
+	}
+
+	return &pb.ListOrgMembersResponse{Members: pbMembers}, nil
+}
+
+// InviteOrgMember invites an email address to join an organization with a role.
+func (h *gRPCHandler) InviteOrgMember(ctx context.Context, req *pb.InviteOrgMemberRequest) (*pb.OrgInvite, error) {
+	role, err := types.ParseOrgRole(req.GetRole())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	code, invite, err := h.svc.InviteOrgMember(ctx, req.GetActorId(), req.GetOrgId(), req.GetEmail(), role)
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return &pb.OrgInvite{
+		Id:        invite.ID.Hex(),
+		OrgId:     invite.OrgID.Hex(),
+		Email:     invite.Email,
+		Role:      string(invite.Role),
+		Code:      code,
+		ExpiresAt: timestamppb.New(invite.ExpiresAt),
+	}, nil
+}
+
+// AcceptOrgInvite joins the user to the organization of an invite sent to their email address.
+func (h *gRPCHandler) AcceptOrgInvite(ctx context.Context, req *pb.AcceptOrgInviteRequest) (*pb.OrgMember, error) {
+	membership, err := h.svc.AcceptOrgInvite(ctx, req.GetUserId(), req.GetCode())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrgMember(membership), nil
+}
+
+// SetOrgMemberRole changes the role of a member within an organization.
+func (h *gRPCHandler) SetOrgMemberRole(ctx context.Context, req *pb.SetOrgMemberRoleRequest) (*pb.OrgMember, error) {
+	role, err := types.ParseOrgRole(req.GetRole())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	membership, err := h.svc.SetOrgMemberRole(ctx, req.GetActorId(), req.GetOrgId(), req.GetUserId(), role)
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrgMember(membership), nil
+}
+
+// RemoveOrgMember removes a member from an organization.
+func (h *gRPCHandler) RemoveOrgMember(ctx context.Context, req *pb.RemoveOrgMemberRequest) (*pb.RemoveOrgMemberResponse, error) {
+	if err := h.svc.RemoveOrgMember(ctx, req.GetActorId(), req.GetOrgId(), req.GetUserId()); err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return &pb.RemoveOrgMemberResponse{}, nil
+}
+
+// CreateTeam creates a team within an organization.
+func (h *gRPCHandler) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	team, err := h.svc.CreateTeam(ctx, req.GetActorId(), req.GetOrgId(), req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbTeam(team), nil
+}
+
+// ListTeams lists the teams of an organization.
+func (h *gRPCHandler) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	teams, err := h.svc.ListTeams(ctx, req.GetActorId(), req.GetOrgId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	pbTeams := make([]*pb.Team, 0, len(teams))
+	for _, t := range teams {
+		pbTeams = append(pbTeams, toPbTeam(t))
+	}
+
+	return &pb.ListTeamsResponse{Teams: pbTeams}, nil
+}
+
+// GetTeam retrieves a team of an organization.
+func (h *gRPCHandler) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	team, err := h.svc.GetTeam(ctx, req.GetOrgId(), req.GetTeamId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbTeam(team), nil
+}
+
+// AddTeamMember adds a member of an organization to one of its teams.
+func (h *gRPCHandler) AddTeamMember(ctx context.Context, req *pb.TeamMemberRequest) (*pb.OrgMember, error) {
+	membership, err := h.svc.AddTeamMember(ctx, req.GetActorId(), req.GetOrgId(), req.GetTeamId(), req.GetUserId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrgMember(membership), nil
+}
+
+// RemoveTeamMember removes a member of an organization from one of its teams.
+func (h *gRPCHandler) RemoveTeamMember(ctx context.Context, req *pb.TeamMemberRequest) (*pb.OrgMember, error) {
+	membership, err := h.svc.RemoveTeamMember(ctx, req.GetActorId(), req.GetOrgId(), req.GetTeamId(), req.GetUserId())
+	if err != nil {
+		return nil, orgStatus(err)
+	}
+
+	return toPbOrgMember(membership), nil
+}
+
+func toPbOrganization(o *types.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:          o.ID.Hex(),
+		Name:        o.Name,
+		Description: o.Description,
+		CreatedBy:   o.CreatedBy,
+		CreatedAt:   timestamppb.New(o.CreatedAt),
+	}
+}
+
+func toPbOrgMember(m *types.Membership) *pb.OrgMember {
+	return &pb.OrgMember{
+		OrgId:    m.OrgID.Hex(),
+		UserId:   m.UserID.Hex(),
+		Role:     string(m.Role),
+		TeamIds:  m.TeamIDs,
+		JoinedAt: timestamppb.New(m.JoinedAt),
+	}
+}
+
+func toPbTeam(t *types.Team) *pb.Team {
+	return &pb.Team{
+		Id:          t.ID.Hex(),
+		OrgId:       t.OrgID.Hex(),
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedBy:   t.CreatedBy,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+	}
+}
+
+// orgStatus maps organization errors to gRPC status errors.
+func orgStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidOrganization), errors.Is(err, service.ErrInvalidOrgRole),
+		errors.Is(err, service.ErrInvalidOrgInvite):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrOrgForbidden), errors.Is(err, service.ErrNotOrgMember):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, service.ErrAlreadyOrgMember), errors.Is(err, repo.ErrResourceConflict):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, service.ErrLastOrgAdmin):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "organization action failed: %v", err)
+	}
+}
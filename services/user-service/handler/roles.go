@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetUserRole changes the role of a user on behalf of an admin.
+func (h *gRPCHandler) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.RoleChange, error) {
+	role, err := types.ParseRole(req.GetRole())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	change, err := h.svc.SetUserRole(ctx, req.GetActorId(), req.GetUserId(), role, fromPbRegions(req.GetRegions()), req.GetReason())
+	if err != nil {
+		return nil, roleStatus(err)
+	}
+
+	return toPbRoleChange(change), nil
+}
+
+// CreateInvite generates an invite code that grants a role at signup.
+func (h *gRPCHandler) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteResponse, error) {
+	role, err := types.ParseRole(req.GetRole())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	code, invite, err := h.svc.CreateInvite(ctx, req.GetActorId(), role)
+	if err != nil {
+		return nil, roleStatus(err)
+	}
+
+	return &pb.CreateInviteResponse{
+		Id:        invite.ID.Hex(),
+		Code:      code,
+		Role:      string(invite.Role),
+		ExpiresAt: timestamppb.New(invite.ExpiresAt),
+	}, nil
+}
+
+// ListRoleChanges retrieves the role change audit log of a user.
+func (h *gRPCHandler) ListRoleChanges(ctx context.Context, req *pb.ListRoleChangesRequest) (*pb.ListRoleChangesResponse, error) {
+	changes, err := h.svc.ListRoleChanges(ctx, req.GetActorId(), req.GetUserId())
+	if err != nil {
+		return nil, roleStatus(err)
+	}
+
+	pbChanges := make([]*pb.RoleChange, 0, len(changes))
+	for _, c := range changes {
+		pbChanges = append(pbChanges, toPbRoleChange(c))
+	}
+
+	return &pb.ListRoleChangesResponse{Changes: pbChanges}, nil
+}
+
+func toPbRoleChange(c *types.RoleChange) *pb.RoleChange {
+	return &pb.RoleChange{
+		Id:        c.ID.Hex(),
+		UserId:    c.UserID,
+		OldRole:   string(c.OldRole),
+		NewRole:   string(c.NewRole),
+		ChangedBy: c.ChangedBy,
+		Source:    c.Source,
+		InviteId:  c.InviteID,
+		Reason:    c.Reason,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		Regions:   c.Regions,
+	}
+}
+
+func toPbRegions(regions []types.Region) []*pb.Region {
+	var pbRegions []*pb.Region
+	for _, r := range regions {
+		pbRegion := &pb.Region{Name: r.Name}
+		for _, c := range r.Polygon {
+			pbRegion.Polygon = append(pbRegion.Polygon, &pb.Point{Latitude: c.Latitude, Longitude: c.Longitude})
+		}
+		pbRegions = append(pbRegions, pbRegion)
+	}
+	return pbRegions
+}
+
+func fromPbRegions(pbRegions []*pb.Region) []types.Region {
+	var regions []types.Region
+	for _, r := range pbRegions {
+		region := types.Region{Name: r.GetName()}
+		for _, p := range r.GetPolygon() {
+			region.Polygon = append(region.Polygon, types.Coordinates{Latitude: p.GetLatitude(), Longitude: p.GetLongitude()})
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// roleStatus maps role management errors to gRPC status errors.
+func roleStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidRole), errors.Is(err, authz.ErrInvalidRegion):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrForbidden):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, service.ErrRoleUnchanged), errors.Is(err, service.ErrLastAdmin):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "role management failed: %v", err)
+	}
+}
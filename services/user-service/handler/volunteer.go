@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/services/user-service/service"
+	pb "github.com/cprakhar/relief-ops/shared/proto/user"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetVolunteerProfile retrieves the volunteer profile of a user.
+func (h *gRPCHandler) GetVolunteerProfile(ctx context.Context, req *pb.GetVolunteerProfileRequest) (*pb.VolunteerProfile, error) {
+	profile, err := h.svc.GetVolunteerProfile(ctx, req.GetActorId(), req.GetUserId())
+	if err != nil {
+		return nil, volunteerStatus(err)
+	}
+
+	return toPbVolunteerProfile(profile), nil
+}
+
+// UpdateVolunteerProfile replaces the volunteer profile of a user.
+func (h *gRPCHandler) UpdateVolunteerProfile(ctx context.Context, req *pb.UpdateVolunteerProfileRequest) (*pb.VolunteerProfile, error) {
+	if req.GetProfile() == nil {
+		return nil, status.Error(codes.InvalidArgument, "profile is required")
+	}
+
+	profile, err := h.svc.UpdateVolunteerProfile(ctx, req.GetUserId(), fromPbVolunteerProfile(req.GetProfile()))
+	if err != nil {
+		return nil, volunteerStatus(err)
+	}
+
+	return toPbVolunteerProfile(profile), nil
+}
+
+// FindVolunteers searches volunteers by skill, distance and availability, nearest first.
+func (h *gRPCHandler) FindVolunteers(ctx context.Context, req *pb.FindVolunteersRequest) (*pb.FindVolunteersResponse, error) {
+	if req.GetLocation() == nil {
+		return nil, status.Error(codes.InvalidArgument, "location is required")
+	}
+
+	q := &repo.VolunteerQuery{
+		Lat:          req.GetLocation().GetLatitude(),
+		Lon:          req.GetLocation().GetLongitude(),
+		RadiusMeters: int(req.GetWithin()),
+		Skills:       req.GetSkills(),
+		Languages:    req.GetLanguages(),
+		Vehicles:     req.GetVehicles(),
+		Limit:        int(req.GetLimit()),
+	}
+
+	var availableAt time.Time
+	if req.GetAvailableAt() != nil {
+		availableAt = req.GetAvailableAt().AsTime()
+	}
+
+	matches, err := h.svc.FindVolunteers(ctx, req.GetActorId(), q, availableAt)
+	if err != nil {
+		return nil, volunteerStatus(err)
+	}
+
+	pbVolunteers := make([]*pb.VolunteerProfile, 0, len(matches))
+	for _, m := range matches {
+		v := toPbVolunteerProfile(&m.VolunteerProfile)
+		v.Name = m.Name
+		v.Distance = m.Distance
+		pbVolunteers = append(pbVolunteers, v)
+	}
+
+	return &pb.FindVolunteersResponse{Volunteers: pbVolunteers}, nil
+}
+
+func toPbVolunteerProfile(p *types.VolunteerProfile) *pb.VolunteerProfile {
+	pbProfile := &pb.VolunteerProfile{
+		UserId:    p.UserID.Hex(),
+		Skills:    p.Skills,
+		Languages: p.Languages,
+		Vehicles:  p.Vehicles,
+		TimeZone:  p.TimeZone,
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+	for _, w := range p.Availability {
+		pbProfile.Availability = append(pbProfile.Availability, &pb.AvailabilityWindow{
+			Day:   int32(w.Day),
+			Start: int32(w.Start),
+			End:   int32(w.End),
+		})
+	}
+	if p.Home != nil && len(p.Home.Coordinates) == 2 {
+		// GeoJSON format is [longitude, latitude]
+		pbProfile.Home = &pb.Point{Latitude: p.Home.Coordinates[1], Longitude: p.Home.Coordinates[0]}
+	}
+	return pbProfile
+}
+
+func fromPbVolunteerProfile(p *pb.VolunteerProfile) *types.VolunteerProfile {
+	profile := &types.VolunteerProfile{
+		Skills:    p.GetSkills(),
+		Languages: p.GetLanguages(),
+		Vehicles:  p.GetVehicles(),
+		TimeZone:  p.GetTimeZone(),
+	}
+	for _, w := range p.GetAvailability() {
+		profile.Availability = append(profile.Availability, types.AvailabilityWindow{
+			Day:   time.Weekday(w.GetDay()),
+			Start: int(w.GetStart()),
+			End:   int(w.GetEnd()),
+		})
+	}
+	if home := p.GetHome(); home != nil {
+		profile.Home = &types.Location{
+			Type:        "Point",
+			Coordinates: []float64{home.GetLongitude(), home.GetLatitude()},
+		}
+	}
+	return profile
+}
+
+// volunteerStatus maps volunteer profile errors to gRPC status errors.
+func volunteerStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidProfile), errors.Is(err, service.ErrInvalidVolunteerQuery):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrProfilesForbidden):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, service.ErrNotVolunteer):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrNoResourcesFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "volunteer profile action failed: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// FileMailer writes rendered emails to a directory as .eml files instead of sending them, or logs them when
+// no directory is set. It is meant for local development and air-gapped deployments.
+type FileMailer struct {
+	dir       string
+	fromEmail string
+	templates *Registry
+}
+
+// NewFile creates a new FileMailer instance, creating the directory if needed.
+func NewFile(dir, fromEmail string, templates *Registry) (*FileMailer, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+	return &FileMailer{dir: dir, fromEmail: fromEmail, templates: templates}, nil
+}
+
+// Send renders an email using the specified template and data and writes it out.
+func (f *FileMailer) Send(templateFile string, to Recipient, data any, isSandbox bool) (int, error) {
+	msg, err := newMessage(f.templates, templateFile, f.fromEmail, to, data)
+	if err != nil {
+		return -1, err
+	}
+	if isSandbox {
+		return 0, nil
+	}
+
+	if f.dir == "" {
+		logs.L().Infow("Email", "to", msg.To.String(), "subject", msg.Subject, "template", templateFile, "locale", to.Locale, "body", msg.Text)
+		return 0, nil
+	}
+
+	raw, err := msg.Bytes()
+	if err != nil {
+		return -1, err
+	}
+	file := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000Z"), rand.Text()[:8])
+	if err := os.WriteFile(filepath.Join(f.dir, file), raw, 0o640); err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// NotifyMultiple writes the admin notification email for multiple users.
+func (f *FileMailer) NotifyMultiple(users []*types.User, data any, isSandbox bool) error {
+	return notifyMultiple(f, users, data, isSandbox)
+}
@@ -2,7 +2,9 @@ package mail
 
 import (
 	"embed"
+	"fmt"
 
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/types"
 )
 
@@ -10,12 +12,84 @@ const (
 	FromName            = "Relief Ops"
 	MaxRetries          = 3
 	AdminNotifyTemplate = "admin_notify.tmpl"
+
+	VerifyEmailTemplate     = "verify_email.tmpl"
+	ResetPasswordTemplate   = "reset_password.tmpl"
+	PasswordChangedTemplate = "password_changed.tmpl"
+	UnlockAccountTemplate   = "unlock_account.tmpl"
+	MFAEnabledTemplate      = "mfa_enabled.tmpl"
+	MFADisabledTemplate     = "mfa_disabled.tmpl"
+	RoleChangedTemplate     = "role_changed.tmpl"
+	OrgInviteTemplate       = "org_invite.tmpl"
+	DisasterAlertTemplate   = "disaster_alert.tmpl"
+	AdminDigestTemplate     = "admin_digest.tmpl"
 )
 
 //go:embed "templates"
 var FS embed.FS
 
 type Client interface {
-	Send(templateFile, username, email string, data any, isSandbox bool) (int, error)
+	Send(templateFile string, to Recipient, data any, isSandbox bool) (int, error)
 	NotifyMultiple(users []*types.User, data any, isSandbox bool) error
 }
+
+// Recipient is who an email is sent to; Locale picks the language of the template, e.g., hi.
+type Recipient struct {
+	Name   string
+	Email  string
+	Locale string
+}
+
+// RecipientOf returns a user as a recipient, in the locale of their notification settings.
+func RecipientOf(user *types.User) Recipient {
+	return Recipient{Name: user.Name, Email: user.Email, Locale: user.Notifications.Locale}
+}
+
+// notifyMultiple sends the admin notification email to multiple users through a client, a few at a time.
+// It returns an aggregate error if any sends failed.
+func notifyMultiple(c Client, users []*types.User, data any, isSandbox bool) error {
+	logger := logs.L()
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	type result struct {
+		email      string
+		err        error
+		statusCode int
+	}
+
+	results := make(chan result, len(users))
+	semaphore := make(chan struct{}, 5) // Limit to 5 concurrent sends
+
+	// Send emails concurrently
+	for _, user := range users {
+		semaphore <- struct{}{} // Acquire semaphore
+		go func(u *types.User) {
+			defer func() { <-semaphore }() // Release semaphore
+			statusCode, err := c.Send(AdminNotifyTemplate, RecipientOf(u), data, isSandbox)
+			results <- result{email: u.Email, err: err, statusCode: statusCode}
+		}(user)
+	}
+
+	// Collect results
+	var failedEmails []string
+	successCount := 0
+	for range users {
+		res := <-results
+		if res.err != nil {
+			failedEmails = append(failedEmails, res.email)
+			logger.Errorw("Failed to send email", "email", res.email, "error", res.err, "statusCode", res.statusCode)
+		} else {
+			successCount++
+		}
+	}
+
+	if len(failedEmails) > 0 {
+		return fmt.Errorf("failed to send %d/%d emails to: %v", len(failedEmails), len(users), failedEmails)
+	}
+
+	logger.Infow("Successfully sent emails", "count", successCount)
+	return nil
+}
@@ -0,0 +1,160 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// message is a rendered email with plain-text and HTML bodies.
+type message struct {
+	From    netmail.Address
+	To      netmail.Address
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// newMessage renders a template into a message in the recipient's locale.
+func newMessage(templates *Registry, templateFile, fromEmail string, to Recipient, data any) (*message, error) {
+	rendered, err := templates.Render(templateFile, to.Locale, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message{
+		From:    netmail.Address{Name: FromName, Address: fromEmail},
+		To:      netmail.Address{Name: to.Name, Address: to.Email},
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	}, nil
+}
+
+// Bytes encodes the message in RFC 5322 format as multipart/alternative, plain text first.
+func (m *message) Bytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+
+	fmt.Fprintf(buf, "From: %s\r\n", m.From.String())
+	fmt.Fprintf(buf, "To: %s\r\n", m.To.String())
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(buf, "Message-ID: <%s@%s>\r\n", rand.Text(), domainOf(m.From.Address))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func domainOf(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// htmlToText converts an HTML email body to plain text, breaking lines at block elements and keeping
+// link targets next to their text.
+func htmlToText(body string) string {
+	var sb strings.Builder
+	var href string
+	z := html.NewTokenizer(strings.NewReader(body))
+	skip := 0      // depth inside head, style or script
+	space := false // whether whitespace separates the next text from the previous one
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			text := strings.TrimSpace(blankLines.ReplaceAllString(sb.String(), "\n\n"))
+			return text + "\n"
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			raw := string(z.Text())
+			text := strings.Join(strings.Fields(raw), " ")
+			if text == "" {
+				space = space || raw != ""
+				continue
+			}
+			if s := sb.String(); (space || startsWithSpace(raw)) && s != "" && !strings.HasSuffix(s, "\n") && !strings.HasSuffix(s, " ") {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(text)
+			space = endsWithSpace(raw)
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "head", "style", "script":
+				if tt == html.StartTagToken {
+					skip++
+				} else if tt == html.EndTagToken && skip > 0 {
+					skip--
+				}
+			case "a":
+				if tt == html.StartTagToken {
+					href = ""
+					for _, a := range tok.Attr {
+						if a.Key == "href" {
+							href = a.Val
+						}
+					}
+				} else if tt == html.EndTagToken && href != "" {
+					// Links whose text is their target are written once
+					if !strings.HasSuffix(sb.String(), href) {
+						fmt.Fprintf(&sb, " (%s)", href)
+					}
+					href = ""
+				}
+			case "br":
+				sb.WriteString("\n")
+			case "p", "div", "h1", "h2", "h3", "h4", "ul", "ol", "table", "tr":
+				sb.WriteString("\n\n")
+			case "li":
+				if tt == html.StartTagToken {
+					sb.WriteString("\n- ")
+				}
+			}
+		}
+	}
+}
+
+func startsWithSpace(s string) bool { return s != "" && strings.TrimLeft(s, " \t\r\n") != s }
+
+func endsWithSpace(s string) bool { return s != "" && strings.TrimRight(s, " \t\r\n") != s }
@@ -0,0 +1,232 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+)
+
+// DefaultLocale is the locale every template exists in, used when the recipient's locale has no variant.
+const DefaultLocale = "en"
+
+var ErrMissingField = errors.New("template data is missing a field")
+
+// Templates lists every email template with the data fields it needs. Templates may only use these fields,
+// which is checked when they are loaded. Fields of list items are written as List[].Field.
+var Templates = map[string][]string{
+	AdminNotifyTemplate:     {"DisasterID", "Title", "Severity", "Location", "VolunteerID", "ReviewURL"},
+	AdminDigestTemplate:     {"Count", "More", "Reports[].Title", "Reports[].Severity", "Reports[].Location", "Reports[].Hazards", "Reports[].ReviewURL", "DashboardURL"},
+	VerifyEmailTemplate:     {"Name", "VerifyURL", "ExpiresIn"},
+	ResetPasswordTemplate:   {"Name", "ResetURL", "ExpiresIn"},
+	PasswordChangedTemplate: {"Name", "ResetURL"},
+	UnlockAccountTemplate:   {"Name", "LockedFor", "UnlockURL", "ResetURL"},
+	MFAEnabledTemplate:      {"Name", "ResetURL"},
+	MFADisabledTemplate:     {"Name", "ResetURL"},
+	RoleChangedTemplate:     {"Name", "Role", "Regions"},
+	OrgInviteTemplate:       {"Name", "OrgName", "Role", "JoinURL", "ExpiresIn"},
+	DisasterAlertTemplate:   {"Name", "Title", "Hazards", "DisasterURL", "ManageURL"},
+}
+
+// Rendered is an email rendered from a template.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// variant is a template in one locale. The subject and plain text are rendered as text, the body as HTML.
+type variant struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Registry holds the email templates, parsed once, in every locale they were translated to.
+type Registry struct {
+	variants map[string]map[string]*variant // template file -> locale -> variant
+}
+
+// NewRegistry parses the templates laid out as templates/<locale>/<template file> in fsys. Each file defines
+// a "subject", an "html" body and optionally a "text" body, derived from the HTML one when missing. Every
+// template in Templates must exist in DefaultLocale and may only use its listed fields.
+func NewRegistry(fsys fs.FS) (*Registry, error) {
+	r := &Registry{variants: make(map[string]map[string]*variant)}
+
+	files, err := fs.Glob(fsys, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		locale, name := path.Base(path.Dir(file)), path.Base(file)
+		fields, ok := Templates[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown template %s", file)
+		}
+
+		v, err := parseVariant(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if err := v.check(fields); err != nil {
+			return nil, fmt.Errorf("template %s: %w", file, err)
+		}
+
+		if r.variants[name] == nil {
+			r.variants[name] = make(map[string]*variant)
+		}
+		r.variants[name][normalizeLocale(locale)] = v
+	}
+
+	for name := range Templates {
+		if _, ok := r.variants[name][DefaultLocale]; !ok {
+			return nil, fmt.Errorf("template %s is missing in %q", name, DefaultLocale)
+		}
+	}
+	return r, nil
+}
+
+// Render renders a template in the recipient's locale, e.g., pt-BR, falling back to its language and then
+// to DefaultLocale. It returns ErrMissingField if data lacks a field the template needs.
+func (r *Registry) Render(templateFile, locale string, data any) (*Rendered, error) {
+	fields, ok := Templates[templateFile]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %s", templateFile)
+	}
+	if err := checkFields(data, fields); err != nil {
+		return nil, fmt.Errorf("template %s: %w", templateFile, err)
+	}
+
+	v := r.lookup(templateFile, locale)
+
+	subject := new(strings.Builder)
+	if err := v.text.ExecuteTemplate(subject, "subject", data); err != nil {
+		return nil, err
+	}
+
+	body := new(strings.Builder)
+	if err := v.html.ExecuteTemplate(body, "html", data); err != nil {
+		return nil, err
+	}
+
+	var text string
+	if v.text.Lookup("text") != nil {
+		sb := new(strings.Builder)
+		if err := v.text.ExecuteTemplate(sb, "text", data); err != nil {
+			return nil, err
+		}
+		text = strings.TrimSpace(sb.String()) + "\n"
+	} else {
+		text = htmlToText(body.String())
+	}
+
+	return &Rendered{
+		Subject: strings.Join(strings.Fields(subject.String()), " "), // subjects may carry user input, e.g., disaster titles
+		Text:    text,
+		HTML:    body.String(),
+	}, nil
+}
+
+// Locales lists the locales a template was translated to.
+func (r *Registry) Locales(templateFile string) []string {
+	var locales []string
+	for locale := range r.variants[templateFile] {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+func (r *Registry) lookup(templateFile, locale string) *variant {
+	variants := r.variants[templateFile]
+	locale = normalizeLocale(locale)
+	for locale != "" {
+		if v, ok := variants[locale]; ok {
+			return v
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return variants[DefaultLocale]
+}
+
+func parseVariant(fsys fs.FS, file string) (*variant, error) {
+	text, err := texttemplate.New(path.Base(file)).Option("missingkey=error").ParseFS(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.New(path.Base(file)).Option("missingkey=error").ParseFS(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range []string{"subject", "html"} {
+		if text.Lookup(part) == nil {
+			return nil, fmt.Errorf("template %s does not define %q", file, part)
+		}
+	}
+	return &variant{text: text, html: html}, nil
+}
+
+// check renders every part of a variant with sample data holding only the listed fields, so templates that
+// use any other field fail when they are loaded rather than when an email is sent.
+func (v *variant) check(fields []string) error {
+	sample := make(map[string]any, len(fields))
+	for _, f := range fields {
+		list, field, ok := strings.Cut(f, "[].")
+		if !ok {
+			sample[f] = f
+			continue
+		}
+		items, _ := sample[list].([]map[string]any)
+		if items == nil {
+			items = []map[string]any{{}}
+			sample[list] = items
+		}
+		items[0][field] = field
+	}
+
+	if err := v.text.ExecuteTemplate(io.Discard, "subject", sample); err != nil {
+		return err
+	}
+	if v.text.Lookup("text") != nil {
+		if err := v.text.ExecuteTemplate(io.Discard, "text", sample); err != nil {
+			return err
+		}
+	}
+	return v.html.ExecuteTemplate(io.Discard, "html", sample)
+}
+
+// checkFields reports whether data, a struct or a map with string keys, has every field. Only the lists of
+// list item fields are checked, as lists may be empty.
+func checkFields(data any, fields []string) error {
+	val := reflect.Indirect(reflect.ValueOf(data))
+	for _, f := range fields {
+		f, _, _ = strings.Cut(f, "[].")
+		switch val.Kind() {
+		case reflect.Struct:
+			if sf, ok := val.Type().FieldByName(f); ok && sf.IsExported() {
+				continue
+			}
+		case reflect.Map:
+			if val.Type().Key().Kind() == reflect.String && val.MapIndex(reflect.ValueOf(f).Convert(val.Type().Key())).IsValid() {
+				continue
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrMissingField, f)
+	}
+	return nil
+}
+
+// normalizeLocale lowercases a locale and uses hyphens, e.g., pt_BR becomes pt-br.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -1,13 +1,10 @@
 package mail
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"time"
 
-	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/tools"
 	"github.com/cprakhar/relief-ops/shared/types"
 	"github.com/sendgrid/sendgrid-go"
@@ -18,40 +15,29 @@ type SendGridMailer struct {
 	fromEmail string
 	apiKey    string
 	client    *sendgrid.Client
+	templates *Registry
 }
 
 // NewSendGrid creates a new SendGridMailer instance.
-func NewSendGrid(fromEmail, apiKey string) *SendGridMailer {
+func NewSendGrid(fromEmail, apiKey string, templates *Registry) *SendGridMailer {
 	client := sendgrid.NewSendClient(apiKey)
 	return &SendGridMailer{
 		fromEmail: fromEmail,
 		apiKey:    apiKey,
 		client:    client,
+		templates: templates,
 	}
 }
 
 // Send sends an email using the specified template and data.
-func (s *SendGridMailer) Send(templateFile, name, email string, data any, isSandbox bool) (int, error) {
-	from := mail.NewEmail(FromName, s.fromEmail)
-	to := mail.NewEmail(name, email)
-
-	// template parsing and dynamic data handling
-	tmpl, err := template.ParseFS(FS, "templates/"+templateFile)
+func (s *SendGridMailer) Send(templateFile string, to Recipient, data any, isSandbox bool) (int, error) {
+	msg, err := newMessage(s.templates, templateFile, s.fromEmail, to, data)
 	if err != nil {
 		return -1, err
 	}
 
-	subject := new(bytes.Buffer)
-	if err := tmpl.ExecuteTemplate(subject, "subject", data); err != nil {
-		return -1, err
-	}
-
-	body := new(bytes.Buffer)
-	if err := tmpl.ExecuteTemplate(body, "body", data); err != nil {
-		return -1, err
-	}
-
-	message := mail.NewSingleEmail(from, subject.String(), to, "", body.String())
+	from := mail.NewEmail(msg.From.Name, msg.From.Address)
+	message := mail.NewSingleEmail(from, msg.Subject, mail.NewEmail(msg.To.Name, msg.To.Address), msg.Text, msg.HTML)
 
 	message.SetMailSettings(&mail.MailSettings{
 		SandboxMode: &mail.Setting{
@@ -85,51 +71,7 @@ func (s *SendGridMailer) Send(templateFile, name, email string, data any, isSand
 	return statusCode, nil
 }
 
-// NotifyMultiple sends the same email to multiple users with proper error handling.
-// Returns a slice of errors (one per user) and an aggregate error if any sends failed.
+// NotifyMultiple sends the admin notification email to multiple users.
 func (s *SendGridMailer) NotifyMultiple(users []*types.User, data any, isSandbox bool) error {
-	logger := logs.L()
-
-	if len(users) == 0 {
-		return nil
-	}
-
-	type result struct {
-		email      string
-		err        error
-		statusCode int
-	}
-
-	results := make(chan result, len(users))
-	semaphore := make(chan struct{}, 5) // Limit to 5 concurrent sends
-
-	// Send emails concurrently
-	for _, user := range users {
-		semaphore <- struct{}{} // Acquire semaphore
-		go func(u *types.User) {
-			defer func() { <-semaphore }() // Release semaphore
-			statusCode, err := s.Send(AdminNotifyTemplate, u.Name, u.Email, data, isSandbox)
-			results <- result{email: u.Email, err: err, statusCode: statusCode}
-		}(user)
-	}
-
-	// Collect results
-	var failedEmails []string
-	successCount := 0
-	for range users {
-		res := <-results
-		if res.err != nil {
-			failedEmails = append(failedEmails, res.email)
-			logger.Errorw("Failed to send email", "email", res.email, "error", res.err, "statusCode", res.statusCode)
-		} else {
-			successCount++
-		}
-	}
-
-	if len(failedEmails) > 0 {
-		return fmt.Errorf("failed to send %d/%d emails to: %v", len(failedEmails), len(users), failedEmails)
-	}
-
-	logger.Infow("Successfully sent emails", "count", successCount)
-	return nil
+	return notifyMultiple(s, users, data, isSandbox)
 }
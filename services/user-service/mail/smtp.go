@@ -0,0 +1,140 @@
+package mail
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// SMTP transport security modes.
+const (
+	SMTPStartTLS = "starttls" // upgrade a plain connection, refusing servers that don't offer STARTTLS
+	SMTPTLS      = "tls"      // implicit TLS, usually on port 465
+	SMTPNone     = "none"     // plain text, for local mail catchers only
+)
+
+// smtpTimeout bounds a whole SMTP session.
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig configures an SMTP server to send mail through.
+type SMTPConfig struct {
+	Addr      string // host:port
+	Username  string // no authentication when empty
+	Password  string
+	Security  string // starttls, tls or none
+	FromEmail string
+}
+
+type SMTPMailer struct {
+	cfg       *SMTPConfig
+	host      string
+	templates *Registry
+	rootCAs   *x509.CertPool // trusted server certificates, the system's when nil
+}
+
+// NewSMTP creates a new SMTPMailer instance.
+func NewSMTP(cfg *SMTPConfig, templates *Registry) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", cfg.Addr, err)
+	}
+	switch cfg.Security {
+	case SMTPStartTLS, SMTPTLS, SMTPNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP security %q", cfg.Security)
+	}
+	return &SMTPMailer{cfg: cfg, host: host, templates: templates}, nil
+}
+
+// Send sends an email using the specified template and data through the SMTP server, as plain text
+// and HTML. In sandbox mode the email is rendered but not sent.
+func (s *SMTPMailer) Send(templateFile string, to Recipient, data any, isSandbox bool) (int, error) {
+	msg, err := newMessage(s.templates, templateFile, s.cfg.FromEmail, to, data)
+	if err != nil {
+		return -1, err
+	}
+	raw, err := msg.Bytes()
+	if err != nil {
+		return -1, err
+	}
+	if isSandbox {
+		return 0, nil
+	}
+
+	if err := s.send(to.Email, raw); err != nil {
+		return -1, fmt.Errorf("failed to send email: %w", err)
+	}
+	return 250, nil
+}
+
+// NotifyMultiple sends the admin notification email to multiple users.
+func (s *SMTPMailer) NotifyMultiple(users []*types.User, data any, isSandbox bool) error {
+	return notifyMultiple(s, users, data, isSandbox)
+}
+
+// send delivers a raw message to a recipient in one SMTP session.
+func (s *SMTPMailer) send(to string, raw []byte) error {
+	tlsCfg := &tls.Config{ServerName: s.host, RootCAs: s.rootCAs, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.Security == SMTPTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.cfg.Addr, tlsCfg)
+	} else {
+		conn, err = dialer.Dial("tcp", s.cfg.Addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.cfg.Security == SMTPStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server %s does not offer STARTTLS", s.cfg.Addr)
+		}
+		if err := c.StartTLS(tlsCfg); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		// PlainAuth refuses to send credentials over unencrypted connections other than to localhost
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.cfg.FromEmail); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
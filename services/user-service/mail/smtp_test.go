@@ -0,0 +1,383 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var verifyData = struct {
+	Name      string
+	VerifyURL string
+	ExpiresIn string
+}{
+	Name:      "Asha",
+	VerifyURL: "https://relief.example/verify?token=abc",
+	ExpiresIn: "24 hours",
+}
+
+// smtpServer is an in-process SMTP server that records one session.
+type smtpServer struct {
+	ln            net.Listener
+	tlsCfg        *tls.Config
+	offerStartTLS bool
+
+	mu       sync.Mutex
+	startTLS bool   // whether the session was upgraded with STARTTLS
+	auth     string // decoded PLAIN credentials
+	authTLS  bool   // whether the credentials came over TLS
+	from     string
+	rcpt     []string
+	data     []byte
+	done     chan struct{}
+}
+
+func newSMTPServer(t *testing.T, offerStartTLS bool) (*smtpServer, *x509.CertPool) {
+	t.Helper()
+
+	cert, pool := selfSignedCert(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	srv := &smtpServer{
+		ln:            ln,
+		tlsCfg:        &tls.Config{Certificates: []tls.Certificate{cert}},
+		offerStartTLS: offerStartTLS,
+		done:          make(chan struct{}),
+	}
+	go srv.serve()
+	return srv, pool
+}
+
+func (s *smtpServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	secure := false
+	tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			exts := []string{"localhost"}
+			if s.offerStartTLS && !secure {
+				exts = append(exts, "STARTTLS")
+			}
+			exts = append(exts, "AUTH PLAIN", "8BITMIME")
+			for i, ext := range exts {
+				sep := "-"
+				if i == len(exts)-1 {
+					sep = " "
+				}
+				tp.PrintfLine("250%s%s", sep, ext)
+			}
+		case "STARTTLS":
+			tp.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsCfg)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, secure = tlsConn, true
+			tp = textproto.NewConn(conn)
+			s.mu.Lock()
+			s.startTLS = true
+			s.mu.Unlock()
+		case "AUTH":
+			mech, resp, _ := strings.Cut(arg, " ")
+			creds, err := base64.StdEncoding.DecodeString(resp)
+			if mech != "PLAIN" || err != nil {
+				tp.PrintfLine("504 Unsupported authentication")
+				continue
+			}
+			s.mu.Lock()
+			s.auth, s.authTLS = string(creds), secure
+			s.mu.Unlock()
+			tp.PrintfLine("235 Authentication successful")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = pathOf(arg)
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.mu.Lock()
+			s.rcpt = append(s.rcpt, pathOf(arg))
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = data
+			s.mu.Unlock()
+			tp.PrintfLine("250 Queued")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// pathOf returns the address in a MAIL or RCPT argument, e.g., FROM:<a@example.com> BODY=8BITMIME.
+func pathOf(arg string) string {
+	_, rest, _ := strings.Cut(arg, "<")
+	addr, _, _ := strings.Cut(rest, ">")
+	return addr
+}
+
+// wait waits for the session to end.
+func (s *smtpServer) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SMTP session did not end")
+	}
+}
+
+// selfSignedCert creates a certificate for 127.0.0.1 and a pool trusting it.
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	templates, err := NewRegistry(FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return templates
+}
+
+func newTestSMTP(t *testing.T, srv *smtpServer, pool *x509.CertPool, cfg SMTPConfig) *SMTPMailer {
+	t.Helper()
+	cfg.Addr = srv.ln.Addr().String()
+	cfg.FromEmail = "noreply@relief.example"
+	mailer, err := NewSMTP(&cfg, newTestRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailer.rootCAs = pool
+	return mailer
+}
+
+func TestSMTPSendStartTLS(t *testing.T) {
+	srv, pool := newSMTPServer(t, true)
+	mailer := newTestSMTP(t, srv, pool, SMTPConfig{Username: "relief", Password: "s3cret", Security: SMTPStartTLS})
+
+	to := Recipient{Name: "Asha", Email: "asha@example.com", Locale: DefaultLocale}
+	code, err := mailer.Send(VerifyEmailTemplate, to, verifyData, false)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if code != 250 {
+		t.Errorf("Send returned %d, want 250", code)
+	}
+	srv.wait(t)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if !srv.startTLS {
+		t.Error("session was not upgraded with STARTTLS")
+	}
+	if want := "\x00relief\x00s3cret"; srv.auth != want {
+		t.Errorf("PLAIN credentials = %q, want %q", srv.auth, want)
+	}
+	if !srv.authTLS {
+		t.Error("credentials were sent before TLS")
+	}
+	if srv.from != "noreply@relief.example" {
+		t.Errorf("MAIL FROM = %q", srv.from)
+	}
+	if len(srv.rcpt) != 1 || srv.rcpt[0] != to.Email {
+		t.Errorf("RCPT TO = %q, want [%s]", srv.rcpt, to.Email)
+	}
+	checkAlternative(t, srv.data)
+}
+
+func TestSMTPSendRefusesWithoutStartTLS(t *testing.T) {
+	srv, pool := newSMTPServer(t, false)
+	mailer := newTestSMTP(t, srv, pool, SMTPConfig{Username: "relief", Password: "s3cret", Security: SMTPStartTLS})
+
+	to := Recipient{Name: "Asha", Email: "asha@example.com"}
+	if _, err := mailer.Send(VerifyEmailTemplate, to, verifyData, false); err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("Send error = %v, want a STARTTLS refusal", err)
+	}
+	srv.wait(t)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.auth != "" || srv.from != "" || srv.data != nil {
+		t.Error("credentials or mail were sent over a plain connection")
+	}
+}
+
+func TestSMTPSendSandbox(t *testing.T) {
+	srv, pool := newSMTPServer(t, true)
+	mailer := newTestSMTP(t, srv, pool, SMTPConfig{Security: SMTPStartTLS})
+
+	to := Recipient{Name: "Asha", Email: "asha@example.com"}
+	if code, err := mailer.Send(VerifyEmailTemplate, to, verifyData, true); err != nil || code != 0 {
+		t.Fatalf("Send = %d, %v; want 0, nil", code, err)
+	}
+	srv.ln.Close()
+	srv.wait(t)
+	if srv.data != nil {
+		t.Error("sandboxed email was sent")
+	}
+}
+
+func TestFileMailerWritesEML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	mailer, err := NewFile(dir, "noreply@relief.example", newTestRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := Recipient{Name: "Asha", Email: "asha@example.com"}
+	if _, err := mailer.Send(VerifyEmailTemplate, to, verifyData, false); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("wrote %d .eml files, want 1", len(files))
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAlternative(t, raw)
+}
+
+func TestFileMailerSandbox(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewFile(dir, "noreply@relief.example", newTestRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := Recipient{Name: "Asha", Email: "asha@example.com"}
+	if _, err := mailer.Send(VerifyEmailTemplate, to, verifyData, true); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("sandbox mode wrote %d files", len(entries))
+	}
+}
+
+// checkAlternative checks that a raw email is multipart/alternative with a plain-text and an HTML part
+// carrying the rendered template.
+func checkAlternative(t *testing.T, raw []byte) {
+	t.Helper()
+
+	msg, err := netmail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("invalid Content-Type: %v", err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %s, want multipart/alternative", mediaType)
+	}
+
+	bodies := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid part: %v", err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[partType] = string(body)
+	}
+
+	for _, partType := range []string{"text/plain", "text/html"} {
+		body, ok := bodies[partType]
+		if !ok {
+			t.Errorf("missing %s part", partType)
+			continue
+		}
+		if !strings.Contains(body, verifyData.VerifyURL) {
+			t.Errorf("%s part lacks the verify URL", partType)
+		}
+	}
+}
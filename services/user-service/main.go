@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/cprakhar/relief-ops/services/user-service/event"
 	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/notify"
 	"github.com/cprakhar/relief-ops/services/user-service/repo"
 	"github.com/cprakhar/relief-ops/services/user-service/service"
 	"github.com/cprakhar/relief-ops/shared/db"
@@ -19,6 +22,7 @@ import (
 	"github.com/cprakhar/relief-ops/shared/messaging"
 	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/observe/traces"
+	"github.com/cprakhar/relief-ops/shared/util"
 )
 
 var (
@@ -29,9 +33,53 @@ var (
 	sendGridAPIKey = env.GetString("SENDGRID_API_KEY", "")
 	brokers        = env.GetString("KAFKA_BROKERS", "apache-kafka:9092")
 
+	// Mail configuration; the backend defaults to SendGrid when it has a key, or else to files in development
+	mailBackend  = env.GetString("MAIL_BACKEND", "") // sendgrid, smtp or file
+	mailDir      = env.GetString("MAIL_DIR", "")     // where the file backend writes emails, logged when empty
+	smtpAddr     = env.GetString("SMTP_ADDR", "")    // host:port
+	smtpUsername = env.GetString("SMTP_USERNAME", "")
+	smtpPassword = env.GetString("SMTP_PASSWORD", "")
+	smtpSecurity = env.GetString("SMTP_SECURITY", mail.SMTPStartTLS)
+
+	// Notification channel configuration; SMS and webhooks are only offered once configured
+	smsAPIURL       = env.GetString("SMS_API_URL", "https://api.twilio.com")
+	smsAccountSID   = env.GetString("SMS_ACCOUNT_SID", "")
+	smsAuthToken    = env.GetString("SMS_AUTH_TOKEN", "")
+	smsFrom         = env.GetString("SMS_FROM", "")
+	pushAPIURL      = env.GetString("PUSH_API_URL", "https://exp.host/--/api/v2/push/send")
+	pushAccessToken = env.GetString("PUSH_ACCESS_TOKEN", "")
+	webhookSecret   = env.GetString("WEBHOOK_SECRET", "")
+
 	// JWT configuration
-	jwtSecret = env.GetString("JWT_SECRET", "")
-	jwtExpiry = env.GetTimeDuration("JWT_EXPIRY", time.Hour*24*7) // 7 days
+	jwtKeysDir    = env.GetString("JWT_KEYS_DIR", "")
+	jwtActiveKey  = env.GetString("JWT_ACTIVE_KEY_ID", "") // empty uses the last key ID in lexical order
+	jwtExpiry     = env.GetTimeDuration("JWT_EXPIRY", time.Minute*15)
+	refreshExpiry = env.GetTimeDuration("REFRESH_TOKEN_EXPIRY", time.Hour*24*30) // 30 days
+
+	// Account link configuration
+	verifyEmailExpiry   = env.GetTimeDuration("VERIFY_EMAIL_EXPIRY", time.Hour*24)
+	resetPasswordExpiry = env.GetTimeDuration("RESET_PASSWORD_EXPIRY", time.Hour)
+	inviteExpiry        = env.GetTimeDuration("INVITE_EXPIRY", time.Hour*24*7) // 7 days
+	mfaChallengeExpiry  = env.GetTimeDuration("MFA_CHALLENGE_EXPIRY", time.Minute*5)
+
+	// Default rate limit of API keys, in requests per minute
+	apiKeyRateLimit = env.GetInt("API_KEY_RATE_LIMIT", 60)
+
+	// Login throttling configuration
+	loginFailureWindow   = env.GetTimeDuration("LOGIN_FAILURE_WINDOW", time.Minute*15)
+	loginDelayAfter      = env.GetInt("LOGIN_DELAY_AFTER", 3)
+	loginBaseDelay       = env.GetTimeDuration("LOGIN_BASE_DELAY", time.Second)
+	loginMaxDelay        = env.GetTimeDuration("LOGIN_MAX_DELAY", time.Second*30)
+	loginLockAfter       = env.GetInt("LOGIN_LOCK_AFTER", 10)
+	loginLockoutDuration = env.GetTimeDuration("LOGIN_LOCKOUT_DURATION", time.Minute*30)
+	loginMaxIPFailures   = env.GetInt("LOGIN_MAX_IP_FAILURES", 100)
+
+	// Disaster alert rate limit configuration
+	alertWindow       = env.GetTimeDuration("ALERT_WINDOW", time.Hour)
+	alertMaxPerWindow = env.GetInt("ALERT_MAX_PER_WINDOW", 5)
+
+	// How often admins who chose digest delivery are sent the disaster reports buffered for them
+	digestInterval = env.GetTimeDuration("DIGEST_INTERVAL", time.Minute*15)
 
 	// Redis configuration
 	redisAddr     = env.GetString("REDIS_ADDR", "redis-db:6379")
@@ -134,18 +182,114 @@ func main() {
 	defer kafkaClient.Close()
 	logger.Info("Kafka client initialized")
 
-	mailer := mail.NewSendGrid(fromEmail, sendGridAPIKey)
+	templates, err := mail.NewRegistry(mail.FS)
+	if err != nil {
+		logger.Fatalw("Failed to load email templates", "error", err)
+	}
+	mailer, err := newMailer(templates)
+	if err != nil {
+		logger.Fatalw("Failed to create mailer", "error", err)
+	}
 
 	// Initialize repository and service
 	userRepo, err := repo.NewUserRepo(ctx, mongoClient)
 	if err != nil {
 		logger.Fatalw("Failed to create user repository", "error", err)
 	}
-	userService := service.NewUserService(userRepo, jwtSecret, jwtExpiry)
+	keyRing, err := loadKeyRing()
+	if err != nil {
+		logger.Fatalw("Failed to load JWT signing keys", "error", err)
+	}
+
+	tokenRepo := repo.NewTokenRepo(db.GetRedisClient())
+	attemptRepo := repo.NewLoginAttemptRepo(db.GetRedisClient())
+	roleRepo, err := repo.NewRoleRepo(ctx, mongoClient.Database().Collection("invites"), mongoClient.Database().Collection("role_changes"))
+	if err != nil {
+		logger.Fatalw("Failed to create role repository", "error", err)
+	}
+	profileRepo, err := repo.NewProfileRepo(ctx, mongoClient.Database().Collection("volunteer_profiles"), mongoClient)
+	if err != nil {
+		logger.Fatalw("Failed to create volunteer profile repository", "error", err)
+	}
+	mongoDatabase := mongoClient.Database()
+	orgRepo, err := repo.NewOrgRepo(ctx,
+		mongoDatabase.Collection("organizations"),
+		mongoDatabase.Collection("org_members"),
+		mongoDatabase.Collection("teams"),
+		mongoDatabase.Collection("org_invites"),
+		mongoClient,
+	)
+	if err != nil {
+		logger.Fatalw("Failed to create organization repository", "error", err)
+	}
+	apiKeyRepo, err := repo.NewAPIKeyRepo(ctx, mongoDatabase.Collection("api_keys"))
+	if err != nil {
+		logger.Fatalw("Failed to create API key repository", "error", err)
+	}
+	subscriptionRepo, err := repo.NewSubscriptionRepo(ctx, mongoDatabase.Collection("alert_subscriptions"))
+	if err != nil {
+		logger.Fatalw("Failed to create alert subscription repository", "error", err)
+	}
+	alertLimitRepo := repo.NewAlertLimitRepo(db.GetRedisClient())
+	deliveryRepo, err := repo.NewDeliveryRepo(ctx, mongoDatabase.Collection("deliveries"))
+	if err != nil {
+		logger.Fatalw("Failed to create delivery repository", "error", err)
+	}
+	digestRepo := repo.NewDigestRepo(db.GetRedisClient())
+	notifier := notify.NewNotifier(deliveryRepo, notificationChannels(mailer)...)
+	jwtCfg := &service.JwtConfig{
+		Keys:          keyRing,
+		Expiry:        jwtExpiry,
+		RefreshExpiry: refreshExpiry,
+	}
+	accountCfg := &service.AccountConfig{
+		WebURL:              webURL,
+		VerifyEmailExpiry:   verifyEmailExpiry,
+		ResetPasswordExpiry: resetPasswordExpiry,
+		InviteExpiry:        inviteExpiry,
+		MFAChallengeExpiry:  mfaChallengeExpiry,
+		APIKeyRateLimit:     int(apiKeyRateLimit),
+	}
+	loginCfg := &service.LoginConfig{
+		Window:          loginFailureWindow,
+		DelayAfter:      int(loginDelayAfter),
+		BaseDelay:       loginBaseDelay,
+		MaxDelay:        loginMaxDelay,
+		LockAfter:       int(loginLockAfter),
+		LockoutDuration: loginLockoutDuration,
+		MaxIPFailures:   int(loginMaxIPFailures),
+	}
+	alertCfg := &service.AlertConfig{
+		Window:       alertWindow,
+		MaxPerWindow: int(alertMaxPerWindow),
+	}
+	digestCfg := &service.DigestConfig{
+		Interval: digestInterval,
+	}
+	userService := service.NewUserService(service.Deps{
+		Users:         userRepo,
+		Tokens:        tokenRepo,
+		Roles:         roleRepo,
+		Attempts:      attemptRepo,
+		Profiles:      profileRepo,
+		Orgs:          orgRepo,
+		APIKeys:       apiKeyRepo,
+		Subscriptions: subscriptionRepo,
+		AlertLimits:   alertLimitRepo,
+		Deliveries:    deliveryRepo,
+		Digests:       digestRepo,
+		Mailer:        mailer,
+		Notifier:      notifier,
+		JwtCfg:        jwtCfg,
+		AccountCfg:    accountCfg,
+		LoginCfg:      loginCfg,
+		AlertCfg:      alertCfg,
+		DigestCfg:     digestCfg,
+	})
 
 	// Initialize and start the disaster consumer
-	topics := []string{events.UserNotifyAdminReview}
-	disasterConsumer := event.NewDisasterConsumer(kafkaClient, userService, mailer, webURL)
+	topics := []string{events.UserNotifyAdminReview, events.DisasterEventApproved}
+	disasterConsumer := event.NewDisasterConsumer(kafkaClient, userService)
 
 	var wg sync.WaitGroup
 
@@ -156,8 +300,17 @@ func main() {
 		}
 	}()
 
+	// Send the report digests of admins who chose digest delivery
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := userService.RunDigests(ctx); err != nil {
+			logger.Errorw("Error in digest sender", "error", err)
+		}
+	}()
+
 	// Initialize and run the gRPC server
-	gRPCServer := newgRPCServer(addr, userService, jwtSecret)
+	gRPCServer := newgRPCServer(addr, userService)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -170,3 +323,79 @@ func main() {
 	wg.Wait()
 	logger.Info("User service stopped")
 }
+
+// newMailer creates the mail backend chosen by MAIL_BACKEND.
+func newMailer(templates *mail.Registry) (mail.Client, error) {
+	backend := mailBackend
+	if backend == "" {
+		backend = "sendgrid"
+		if sendGridAPIKey == "" && environment == "development" {
+			backend = "file"
+		}
+	}
+
+	switch backend {
+	case "sendgrid":
+		if sendGridAPIKey == "" {
+			return nil, fmt.Errorf("SENDGRID_API_KEY is required by the sendgrid mail backend")
+		}
+		return mail.NewSendGrid(fromEmail, sendGridAPIKey, templates), nil
+	case "smtp":
+		smtpCfg := &mail.SMTPConfig{
+			Addr:      smtpAddr,
+			Username:  smtpUsername,
+			Password:  smtpPassword,
+			Security:  smtpSecurity,
+			FromEmail: fromEmail,
+		}
+		logs.L().Infow("Sending mail through SMTP", "addr", smtpAddr, "security", smtpSecurity)
+		return mail.NewSMTP(smtpCfg, templates)
+	case "file":
+		logs.L().Infow("Writing mail to files instead of sending it", "dir", mailDir)
+		return mail.NewFile(mailDir, fromEmail, templates)
+	default:
+		return nil, fmt.Errorf("unknown mail backend %q", backend)
+	}
+}
+
+// notificationChannels returns the configured notification channels. Email is always available.
+func notificationChannels(mailer mail.Client) []notify.Channel {
+	client := &http.Client{Timeout: notify.RequestTimeout}
+	channels := []notify.Channel{
+		notify.NewEmailChannel(mailer),
+		notify.NewPushChannel(&notify.PushConfig{APIURL: pushAPIURL, AccessToken: pushAccessToken}, client),
+	}
+
+	if smsAccountSID != "" {
+		smsCfg := &notify.SMSConfig{
+			APIURL:     smsAPIURL,
+			AccountSID: smsAccountSID,
+			AuthToken:  smsAuthToken,
+			From:       smsFrom,
+		}
+		channels = append(channels, notify.NewSMSChannel(smsCfg, client))
+	}
+
+	if webhookSecret != "" {
+		// Webhook URLs come from users, so only development may call internal addresses
+		webhookClient := notify.NewPublicHTTPClient()
+		if environment == "development" {
+			webhookClient = client
+		}
+		channels = append(channels, notify.NewWebhookChannel(webhookSecret, webhookClient))
+	}
+	return channels
+}
+
+// loadKeyRing loads the JWT signing keys. Without a key directory, development falls back to a throwaway key.
+func loadKeyRing() (*util.KeyRing, error) {
+	if jwtKeysDir != "" {
+		return util.LoadKeyRing(jwtKeysDir, jwtActiveKey)
+	}
+	if environment != "development" {
+		return nil, fmt.Errorf("JWT_KEYS_DIR is required outside development")
+	}
+
+	logs.L().Warn("JWT_KEYS_DIR is not set, signing tokens with a generated key; tokens will not survive a restart")
+	return util.GenerateKeyRing()
+}
@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+type emailChannel struct {
+	mailer mail.Client
+}
+
+// NewEmailChannel creates a channel sending notifications as emails through a mailer, e.g., SendGrid or SMTP.
+func NewEmailChannel(mailer mail.Client) Channel {
+	return &emailChannel{mailer: mailer}
+}
+
+func (c *emailChannel) Name() string { return types.ChannelEmail }
+
+// Reaches reports whether the user verified their email address; unverified addresses may belong to someone else.
+func (c *emailChannel) Reaches(user *types.User) bool {
+	return user.Email != "" && user.EmailVerified
+}
+
+// Send emails the notification to the user.
+func (c *emailChannel) Send(ctx context.Context, user *types.User, msg *Message) error {
+	_, err := c.mailer.Send(msg.Template, mail.RecipientOf(user), msg.Data, false)
+	return err
+}
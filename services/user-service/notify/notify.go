@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// RequestTimeout bounds each request to a notification provider.
+const RequestTimeout = 10 * time.Second
+
+var (
+	ErrUnreachable = errors.New("user can't be reached on any channel")
+	ErrUndelivered = errors.New("notification could not be delivered")
+)
+
+// Message is a notification to a user. Email renders Template with Data; the other channels send the
+// short Title, Text and URL.
+type Message struct {
+	Kind     string // what the notification is about, e.g., disaster_alert
+	Template string
+	Data     any
+	Title    string
+	Text     string
+	URL      string
+
+	// Channels to try in order; empty uses the user's notification settings.
+	Channels []string
+}
+
+// Channel delivers notifications to users through one provider.
+type Channel interface {
+	Name() string
+	// Reaches reports whether the user has an address on the channel.
+	Reaches(user *types.User) bool
+	Send(ctx context.Context, user *types.User, msg *Message) error
+}
+
+// Notifier routes notifications to the channels users prefer, falling back to the next channel when one fails,
+// and records the delivery status of each notification.
+type Notifier struct {
+	channels   map[string]Channel
+	deliveries repo.DeliveryRepo
+}
+
+// NewNotifier creates a new Notifier sending through the given channels.
+func NewNotifier(deliveries repo.DeliveryRepo, channels ...Channel) *Notifier {
+	n := &Notifier{channels: make(map[string]Channel, len(channels)), deliveries: deliveries}
+	for _, c := range channels {
+		n.channels[c.Name()] = c
+	}
+	return n
+}
+
+// Available reports whether a channel is configured.
+func (n *Notifier) Available(channel string) bool {
+	_, ok := n.channels[channel]
+	return ok
+}
+
+// Notify delivers a notification to a user on the first channel that succeeds. Channels are tried in the order
+// of the message, or else the user's settings, with email as the last resort. It returns ErrUnreachable if the
+// user has no address on any of them and ErrUndelivered if every channel failed.
+func (n *Notifier) Notify(ctx context.Context, user *types.User, msg *Message) (*types.Delivery, error) {
+	delivery := &types.Delivery{UserID: user.ID, Kind: msg.Kind, Status: types.DeliveryPending}
+	if err := n.deliveries.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	logger := logs.L()
+	var lastErr error
+	for _, channel := range n.route(user, msg) {
+		attempt := types.DeliveryAttempt{Channel: channel.Name(), At: time.Now()}
+		err := channel.Send(ctx, user, msg)
+		status := types.DeliverySent
+		if err != nil {
+			attempt.Error = err.Error()
+			status = types.DeliveryFailed
+			lastErr = err
+			logger.Warnw("Failed to send notification", "channel", channel.Name(), "userID", user.ID.Hex(), "kind", msg.Kind, "error", err)
+		}
+
+		delivery.Attempts = append(delivery.Attempts, attempt)
+		delivery.Status = status
+		if err := n.deliveries.RecordAttempt(ctx, delivery.ID, attempt, status); err != nil {
+			logger.Warnw("Failed to record delivery attempt", "deliveryID", delivery.ID.Hex(), "error", err)
+		}
+
+		if attempt.Error == "" {
+			delivery.Channel = channel.Name()
+			return delivery, nil
+		}
+	}
+
+	if lastErr == nil {
+		delivery.Status = types.DeliveryFailed
+		if err := n.deliveries.RecordAttempt(ctx, delivery.ID, types.DeliveryAttempt{Error: ErrUnreachable.Error(), At: time.Now()}, types.DeliveryFailed); err != nil {
+			logger.Warnw("Failed to record delivery attempt", "deliveryID", delivery.ID.Hex(), "error", err)
+		}
+		return delivery, ErrUnreachable
+	}
+	return delivery, fmt.Errorf("%w: %v", ErrUndelivered, lastErr)
+}
+
+// route returns the configured channels a notification is tried on that can reach the user, in order.
+func (n *Notifier) route(user *types.User, msg *Message) []Channel {
+	names := msg.Channels
+	if len(names) == 0 {
+		names = user.Notifications.Channels
+	}
+	if !slices.Contains(names, types.ChannelEmail) {
+		names = append(slices.Clip(names), types.ChannelEmail)
+	}
+
+	var route []Channel
+	for _, name := range names {
+		if c, ok := n.channels[name]; ok && c.Reaches(user) {
+			route = append(route, c)
+		}
+	}
+	return route
+}
+
+// post sends a request to a provider, failing on any status other than 2xx.
+func post(client *http.Client, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("status code: %d, body: %.512s", res.StatusCode, body)
+	}
+	return body, nil
+}
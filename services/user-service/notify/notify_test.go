@@ -0,0 +1,209 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logs.Set(zap.NewNop().Sugar())
+	os.Exit(m.Run())
+}
+
+// recordedAttempt is a delivery attempt as recorded in the repository.
+type recordedAttempt struct {
+	id      bson.ObjectID
+	attempt types.DeliveryAttempt
+	status  string
+}
+
+type fakeDeliveries struct {
+	mu       sync.Mutex
+	created  []*types.Delivery
+	attempts []recordedAttempt
+}
+
+func (f *fakeDeliveries) CreateDelivery(ctx context.Context, d *types.Delivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d.ID = bson.NewObjectID()
+	f.created = append(f.created, d)
+	return nil
+}
+
+func (f *fakeDeliveries) RecordAttempt(ctx context.Context, id bson.ObjectID, attempt types.DeliveryAttempt, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts = append(f.attempts, recordedAttempt{id: id, attempt: attempt, status: status})
+	return nil
+}
+
+func (f *fakeDeliveries) ListDeliveries(ctx context.Context, userID string, limit int64) ([]*types.Delivery, error) {
+	return nil, nil
+}
+
+type fakeMailer struct {
+	err  error
+	sent []mail.Recipient
+}
+
+func (f *fakeMailer) Send(templateFile string, to mail.Recipient, data any, isSandbox bool) (int, error) {
+	if f.err != nil {
+		return -1, f.err
+	}
+	f.sent = append(f.sent, to)
+	return 202, nil
+}
+
+func (f *fakeMailer) NotifyMultiple(users []*types.User, data any, isSandbox bool) error {
+	return nil
+}
+
+// failingSMS returns an SMS channel whose gateway rejects every message, counting the requests it got.
+func failingSMS(t *testing.T) (Channel, *int) {
+	t.Helper()
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "gateway down", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	return NewSMSChannel(&SMSConfig{APIURL: srv.URL, AccountSID: "AC123", AuthToken: "token", From: "+15005550006"}, srv.Client()), &requests
+}
+
+func testUser(channels ...string) *types.User {
+	return &types.User{
+		ID:            bson.NewObjectID(),
+		Name:          "Asha",
+		Email:         "asha@example.com",
+		EmailVerified: true,
+		Notifications: types.NotificationSettings{Channels: channels, Phone: "+919812345678"},
+	}
+}
+
+var testMessage = &Message{
+	Kind:     "disaster_alert",
+	Template: mail.DisasterAlertTemplate,
+	Title:    "Flood near Home",
+	Text:     "A flood was reported 2 km from you.",
+	URL:      "https://relief.example/disasters/1",
+}
+
+func TestNotifyFallsBackToEmail(t *testing.T) {
+	sms, requests := failingSMS(t)
+	mailer := &fakeMailer{}
+	deliveries := &fakeDeliveries{}
+	n := NewNotifier(deliveries, sms, NewEmailChannel(mailer))
+
+	user := testUser(types.ChannelSMS)
+	delivery, err := n.Notify(context.Background(), user, testMessage)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if *requests != 1 {
+		t.Errorf("SMS gateway got %d requests, want 1", *requests)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].Email != user.Email {
+		t.Errorf("emails sent = %v, want one to %s", mailer.sent, user.Email)
+	}
+	if delivery.Status != types.DeliverySent || delivery.Channel != types.ChannelEmail {
+		t.Errorf("delivery = %s on %q, want sent on email", delivery.Status, delivery.Channel)
+	}
+
+	if len(deliveries.created) != 1 || deliveries.created[0].UserID != user.ID || deliveries.created[0].Kind != testMessage.Kind {
+		t.Fatalf("created deliveries = %+v", deliveries.created)
+	}
+	want := []struct {
+		channel, status string
+		failed          bool
+	}{
+		{types.ChannelSMS, types.DeliveryFailed, true},
+		{types.ChannelEmail, types.DeliverySent, false},
+	}
+	if len(deliveries.attempts) != len(want) {
+		t.Fatalf("recorded %d attempts, want %d", len(deliveries.attempts), len(want))
+	}
+	for i, w := range want {
+		got := deliveries.attempts[i]
+		if got.id != delivery.ID || got.attempt.Channel != w.channel || got.status != w.status || (got.attempt.Error != "") != w.failed {
+			t.Errorf("attempt %d = %+v, want %s %s (failed: %v)", i, got, w.channel, w.status, w.failed)
+		}
+	}
+}
+
+func TestNotifyMessageChannelsOverrideSettings(t *testing.T) {
+	sms, requests := failingSMS(t)
+	mailer := &fakeMailer{}
+	n := NewNotifier(&fakeDeliveries{}, sms, NewEmailChannel(mailer))
+
+	// The user prefers SMS, but the message asks for email only
+	msg := *testMessage
+	msg.Channels = []string{types.ChannelEmail}
+	delivery, err := n.Notify(context.Background(), testUser(types.ChannelSMS), &msg)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if *requests != 0 {
+		t.Errorf("SMS gateway got %d requests, want none", *requests)
+	}
+	if delivery.Channel != types.ChannelEmail || len(delivery.Attempts) != 1 {
+		t.Errorf("delivery = %+v, want one attempt on email", delivery)
+	}
+}
+
+func TestNotifyUnreachable(t *testing.T) {
+	mailer := &fakeMailer{}
+	deliveries := &fakeDeliveries{}
+	// Push isn't configured and the email address is unverified
+	n := NewNotifier(deliveries, NewEmailChannel(mailer))
+
+	user := testUser(types.ChannelPush)
+	user.EmailVerified = false
+	delivery, err := n.Notify(context.Background(), user, testMessage)
+	if !errors.Is(err, ErrUnreachable) {
+		t.Fatalf("Notify error = %v, want ErrUnreachable", err)
+	}
+	if len(mailer.sent) != 0 {
+		t.Error("emailed an unverified address")
+	}
+	if delivery.Status != types.DeliveryFailed {
+		t.Errorf("delivery status = %s, want failed", delivery.Status)
+	}
+	if len(deliveries.attempts) != 1 || deliveries.attempts[0].status != types.DeliveryFailed || deliveries.attempts[0].attempt.Error != ErrUnreachable.Error() {
+		t.Errorf("recorded attempts = %+v, want one failed as unreachable", deliveries.attempts)
+	}
+}
+
+func TestNotifyUndelivered(t *testing.T) {
+	sms, _ := failingSMS(t)
+	deliveries := &fakeDeliveries{}
+	n := NewNotifier(deliveries, sms, NewEmailChannel(&fakeMailer{err: errors.New("mailbox full")}))
+
+	delivery, err := n.Notify(context.Background(), testUser(types.ChannelSMS), testMessage)
+	if !errors.Is(err, ErrUndelivered) {
+		t.Fatalf("Notify error = %v, want ErrUndelivered", err)
+	}
+	if delivery.Status != types.DeliveryFailed || delivery.Channel != "" {
+		t.Errorf("delivery = %s on %q, want failed on no channel", delivery.Status, delivery.Channel)
+	}
+	if len(deliveries.attempts) != 2 {
+		t.Fatalf("recorded %d attempts, want 2", len(deliveries.attempts))
+	}
+	for i, a := range deliveries.attempts {
+		if a.status != types.DeliveryFailed || a.attempt.Error == "" {
+			t.Errorf("attempt %d = %+v, want failed with an error", i, a)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// PushConfig configures the Expo push service, which delivers to devices through FCM and APNs.
+type PushConfig struct {
+	APIURL      string // e.g., https://exp.host/--/api/v2/push/send
+	AccessToken string // optional, required once push security is enabled for the project
+}
+
+type pushChannel struct {
+	cfg    *PushConfig
+	client *http.Client
+}
+
+type pushMessage struct {
+	To    string            `json:"to"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+	Sound string            `json:"sound"`
+}
+
+type pushResponse struct {
+	Data []struct {
+		Status  string `json:"status"` // ok or error
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// NewPushChannel creates a channel sending notifications as push notifications to the user's devices.
+func NewPushChannel(cfg *PushConfig, client *http.Client) Channel {
+	return &pushChannel{cfg: cfg, client: client}
+}
+
+func (c *pushChannel) Name() string { return types.ChannelPush }
+
+// Reaches reports whether the user registered a device.
+func (c *pushChannel) Reaches(user *types.User) bool {
+	return len(user.Notifications.PushTokens) > 0
+}
+
+// Send pushes the notification to every device of the user. It succeeds if any device accepted it.
+func (c *pushChannel) Send(ctx context.Context, user *types.User, msg *Message) error {
+	messages := make([]pushMessage, 0, len(user.Notifications.PushTokens))
+	for _, token := range user.Notifications.PushTokens {
+		m := pushMessage{To: token, Title: msg.Title, Body: msg.Text, Sound: "default"}
+		if msg.URL != "" {
+			m.Data = map[string]string{"url": msg.URL}
+		}
+		messages = append(messages, m)
+	}
+
+	payload, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.APIURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.AccessToken)
+	}
+
+	body, err := post(c.client, req)
+	if err != nil {
+		return fmt.Errorf("failed to send push notification: %w", err)
+	}
+
+	var res pushResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return fmt.Errorf("failed to decode push response: %w", err)
+	}
+	var errs []string
+	for _, ticket := range res.Data {
+		if ticket.Status == "ok" {
+			return nil
+		}
+		errs = append(errs, ticket.Message)
+	}
+	return fmt.Errorf("push notification rejected for every device: %s", strings.Join(errs, "; "))
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// pushServer returns a push service answering with the given tickets, and the messages it received.
+func pushServer(t *testing.T, tickets string) (*httptest.Server, *[]pushMessage, *string) {
+	t.Helper()
+	var messages []pushMessage
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&messages); err != nil {
+			t.Errorf("invalid push request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": ` + tickets + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &messages, &auth
+}
+
+func pushUser() *types.User {
+	user := testUser()
+	user.Notifications.PushTokens = []string{"ExponentPushToken[old]", "ExponentPushToken[new]"}
+	return user
+}
+
+func TestPushChannelSend(t *testing.T) {
+	srv, messages, auth := pushServer(t, `[{"status": "error", "message": "DeviceNotRegistered"}, {"status": "ok", "id": "abc"}]`)
+	c := NewPushChannel(&PushConfig{APIURL: srv.URL, AccessToken: "expo-token"}, srv.Client())
+
+	user := pushUser()
+	if !c.Reaches(user) {
+		t.Fatal("push channel doesn't reach a user with devices")
+	}
+	// One device accepting the notification is enough
+	if err := c.Send(context.Background(), user, testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if *auth != "Bearer expo-token" {
+		t.Errorf("Authorization = %q", *auth)
+	}
+	if len(*messages) != 2 {
+		t.Fatalf("pushed %d messages, want one per device", len(*messages))
+	}
+	for i, m := range *messages {
+		if m.To != user.Notifications.PushTokens[i] || m.Title != testMessage.Title || m.Body != testMessage.Text || m.Data["url"] != testMessage.URL {
+			t.Errorf("message %d = %+v", i, m)
+		}
+	}
+}
+
+func TestPushChannelEveryDeviceRejected(t *testing.T) {
+	srv, _, auth := pushServer(t, `[{"status": "error", "message": "DeviceNotRegistered"}, {"status": "error", "message": "MessageRateExceeded"}]`)
+	c := NewPushChannel(&PushConfig{APIURL: srv.URL}, srv.Client())
+
+	err := c.Send(context.Background(), pushUser(), testMessage)
+	if err == nil {
+		t.Fatal("Send succeeded with every device rejected")
+	}
+	for _, reason := range []string{"DeviceNotRegistered", "MessageRateExceeded"} {
+		if !strings.Contains(err.Error(), reason) {
+			t.Errorf("Send error %q lacks %s", err, reason)
+		}
+	}
+	if *auth != "" {
+		t.Errorf("Authorization = %q without an access token", *auth)
+	}
+}
+
+func TestPushChannelNeedsDevices(t *testing.T) {
+	c := NewPushChannel(&PushConfig{APIURL: "http://push.invalid"}, http.DefaultClient)
+	if c.Reaches(testUser()) {
+		t.Error("push channel reaches a user without devices")
+	}
+}
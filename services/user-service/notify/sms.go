@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// maxSMSLength is the longest text sent by SMS, split into segments by the gateway.
+const maxSMSLength = 640
+
+// SMSConfig configures a Twilio-style SMS gateway.
+type SMSConfig struct {
+	APIURL     string // e.g., https://api.twilio.com
+	AccountSID string
+	AuthToken  string
+	From       string // sender number or ID
+}
+
+type smsChannel struct {
+	cfg    *SMSConfig
+	client *http.Client
+}
+
+// NewSMSChannel creates a channel sending notifications as text messages through an SMS gateway.
+func NewSMSChannel(cfg *SMSConfig, client *http.Client) Channel {
+	return &smsChannel{cfg: cfg, client: client}
+}
+
+func (c *smsChannel) Name() string { return types.ChannelSMS }
+
+// Reaches reports whether the user gave a phone number.
+func (c *smsChannel) Reaches(user *types.User) bool {
+	return user.Notifications.Phone != ""
+}
+
+// Send texts the title, text and URL of the notification to the user's phone.
+func (c *smsChannel) Send(ctx context.Context, user *types.User, msg *Message) error {
+	text := strings.TrimSpace(strings.Join([]string{msg.Title, msg.Text, msg.URL}, "\n"))
+	if runes := []rune(text); len(runes) > maxSMSLength {
+		text = string(runes[:maxSMSLength-1]) + "…"
+	}
+
+	form := url.Values{}
+	form.Set("To", user.Notifications.Phone)
+	form.Set("From", c.cfg.From)
+	form.Set("Body", text)
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimSuffix(c.cfg.APIURL, "/"), url.PathEscape(c.cfg.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.cfg.AccountSID, c.cfg.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if _, err := post(c.client, req); err != nil {
+		return fmt.Errorf("failed to send SMS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSMSChannelSend(t *testing.T) {
+	var got struct {
+		path, user, pass, to, from, body string
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.path = r.URL.Path
+		got.user, got.pass, _ = r.BasicAuth()
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("invalid form: %v", err)
+		}
+		got.to, got.from, got.body = r.PostForm.Get("To"), r.PostForm.Get("From"), r.PostForm.Get("Body")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid": "SM123", "status": "queued"}`))
+	}))
+	defer srv.Close()
+
+	c := NewSMSChannel(&SMSConfig{APIURL: srv.URL + "/", AccountSID: "AC123", AuthToken: "token", From: "+15005550006"}, srv.Client())
+	user := testUser()
+	if !c.Reaches(user) {
+		t.Fatal("SMS channel doesn't reach a user with a phone number")
+	}
+	if err := c.Send(context.Background(), user, testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got.path != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Errorf("path = %s", got.path)
+	}
+	if got.user != "AC123" || got.pass != "token" {
+		t.Errorf("basic auth = %s:%s", got.user, got.pass)
+	}
+	if got.to != user.Notifications.Phone || got.from != "+15005550006" {
+		t.Errorf("To = %s, From = %s", got.to, got.from)
+	}
+	for _, s := range []string{testMessage.Title, testMessage.Text, testMessage.URL} {
+		if !strings.Contains(got.body, s) {
+			t.Errorf("body %q lacks %q", got.body, s)
+		}
+	}
+}
+
+func TestSMSChannelTruncatesLongText(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = r.FormValue("Body")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := NewSMSChannel(&SMSConfig{APIURL: srv.URL, AccountSID: "AC123"}, srv.Client())
+	msg := *testMessage
+	msg.Text = strings.Repeat("बाढ़ ", 500)
+	if err := c.Send(context.Background(), testUser(), &msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if n := utf8.RuneCountInString(body); n != maxSMSLength {
+		t.Errorf("sent %d characters, want %d", n, maxSMSLength)
+	}
+	if !strings.HasSuffix(body, "…") {
+		t.Error("truncated text doesn't end with an ellipsis")
+	}
+}
+
+func TestSMSChannelGatewayError(t *testing.T) {
+	c, _ := failingSMS(t)
+	err := c.Send(context.Background(), testUser(), testMessage)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Send error = %v, want the gateway's status", err)
+	}
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook body, keyed with the webhook secret.
+const SignatureHeader = "X-Relief-Ops-Signature"
+
+var errPrivateAddress = errors.New("webhook address is not public")
+
+type webhookChannel struct {
+	secret []byte
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Kind   string    `json:"kind"`
+	Title  string    `json:"title"`
+	Text   string    `json:"text"`
+	URL    string    `json:"url,omitempty"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// NewWebhookChannel creates a channel posting notifications as JSON to the user's webhook URL, signed with secret.
+func NewWebhookChannel(secret string, client *http.Client) Channel {
+	return &webhookChannel{secret: []byte(secret), client: client}
+}
+
+// NewPublicHTTPClient creates an HTTP client that refuses to connect to loopback, private and link-local
+// addresses, so user-supplied URLs can't reach internal services.
+func NewPublicHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: RequestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   RequestTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (c *webhookChannel) Name() string { return types.ChannelWebhook }
+
+// Reaches reports whether the user gave a webhook URL.
+func (c *webhookChannel) Reaches(user *types.User) bool {
+	return user.Notifications.WebhookURL != ""
+}
+
+// Send posts the notification to the user's webhook URL.
+func (c *webhookChannel) Send(ctx context.Context, user *types.User, msg *Message) error {
+	payload, err := json.Marshal(webhookPayload{
+		Kind:   msg.Kind,
+		Title:  msg.Title,
+		Text:   msg.Text,
+		URL:    msg.URL,
+		SentAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, user.Notifications.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	if _, err := post(c.client, req); err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	return nil
+}
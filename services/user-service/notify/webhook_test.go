@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookChannelSignsBody(t *testing.T) {
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(SignatureHeader)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewWebhookChannel("whsec", srv.Client())
+	user := testUser()
+	user.Notifications.WebhookURL = srv.URL + "/hooks/relief"
+	if !c.Reaches(user) {
+		t.Fatal("webhook channel doesn't reach a user with a webhook URL")
+	}
+	if err := c.Send(context.Background(), user, testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("whsec"))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, signature, want)
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.Kind != testMessage.Kind || payload.Title != testMessage.Title || payload.Text != testMessage.Text || payload.URL != testMessage.URL || payload.SentAt.IsZero() {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookChannelError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	user := testUser()
+	user.Notifications.WebhookURL = srv.URL
+	if err := NewWebhookChannel("whsec", srv.Client()).Send(context.Background(), user, testMessage); err == nil {
+		t.Error("Send succeeded on a 403")
+	}
+}
+
+func TestPublicHTTPClientRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	c := NewWebhookChannel("whsec", NewPublicHTTPClient())
+	for _, url := range []string{srv.URL, "http://10.0.0.1:1/", "http://169.254.169.254/latest/meta-data"} {
+		user := testUser()
+		user.Notifications.WebhookURL = url
+		if err := c.Send(context.Background(), user, testMessage); !errors.Is(err, errPrivateAddress) {
+			t.Errorf("Send to %s error = %v, want errPrivateAddress", url, err)
+		}
+	}
+	if called {
+		t.Error("webhook on a loopback address was called")
+	}
+}
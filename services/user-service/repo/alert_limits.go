@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisAlertLimitRepo struct {
+	client *redis.Client
+}
+
+// AlertLimitRepo defines the interface for the counters that keep users from being flooded with alerts.
+type AlertLimitRepo interface {
+	ClaimAlert(ctx context.Context, disasterID, userID string, ttl time.Duration) (bool, error)
+	RecordAlert(ctx context.Context, userID string, window time.Duration, limit int) (bool, error)
+}
+
+// recordAlertScript trims a user's alert window and adds an alert to it only if the window has room, so alerts
+// dropped over the limit don't keep the window full. It returns 1 if the alert was recorded.
+var recordAlertScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[4])
+redis.call("PEXPIRE", KEYS[1], ARGV[5])
+return 1
+`)
+
+// NewAlertLimitRepo creates a new instance of redisAlertLimitRepo.
+func NewAlertLimitRepo(client *redis.Client) AlertLimitRepo {
+	return &redisAlertLimitRepo{client: client}
+}
+
+// ClaimAlert marks a user as alerted about a disaster, reporting false if they already were, so overlapping
+// subscriptions and redelivered events alert once.
+func (r *redisAlertLimitRepo) ClaimAlert(ctx context.Context, disasterID, userID string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.SetNX(ctx, alertedKey(disasterID, userID), 1, ttl).Result()
+}
+
+// RecordAlert records an alert for a user in a sliding window, reporting false without recording it if the
+// window already holds limit alerts.
+func (r *redisAlertLimitRepo) RecordAlert(ctx context.Context, userID string, window time.Duration, limit int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	recorded, err := recordAlertScript.Run(ctx, r.client, []string{alertsKey(userID)},
+		now.Add(-window).UnixMilli(), now.UnixMilli(), limit, rand.Text(), window.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return recorded == 1, nil
+}
+
+func alertedKey(disasterID, userID string) string {
+	return fmt.Sprintf("alerts:sent:%s:%s", disasterID, userID)
+}
+
+func alertsKey(userID string) string {
+	return fmt.Sprintf("alerts:window:%s", userID)
+}
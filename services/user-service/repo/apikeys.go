@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbAPIKeyRepo struct {
+	keys *mongo.Collection
+}
+
+// APIKeyRepo defines the interface for organization API keys.
+type APIKeyRepo interface {
+	CreateKey(ctx context.Context, key *types.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*types.APIKey, error)
+	ListKeys(ctx context.Context, orgID string) ([]*types.APIKey, error)
+	RevokeKey(ctx context.Context, orgID, keyID string) (*types.APIKey, error)
+	TouchKey(ctx context.Context, id bson.ObjectID, usedAt time.Time) error
+}
+
+// NewAPIKeyRepo creates a new instance of mongodbAPIKeyRepo.
+func NewAPIKeyRepo(ctx context.Context, keys *mongo.Collection) (APIKeyRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "prefix", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+	if _, err := keys.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, err
+	}
+
+	return &mongodbAPIKeyRepo{keys: keys}, nil
+}
+
+// CreateKey stores a new API key. It returns ErrResourceConflict if another key has the same prefix.
+func (r *mongodbAPIKeyRepo) CreateKey(ctx context.Context, key *types.APIKey) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	key.CreatedAt = time.Now()
+
+	res, err := r.keys.InsertOne(ctx, key)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrResourceConflict
+		}
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		key.ID = oid
+	}
+	return nil
+}
+
+// GetByPrefix retrieves an API key by its prefix.
+func (r *mongodbAPIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*types.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	var key types.APIKey
+	if err := r.keys.FindOne(ctx, bson.M{"prefix": prefix}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListKeys retrieves the API keys of an organization, newest first, including revoked and expired ones.
+func (r *mongodbAPIKeyRepo) ListKeys(ctx context.Context, orgID string) ([]*types.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.keys.Find(ctx, bson.M{"org_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*types.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeKey revokes an API key of an organization. Revoking a revoked key keeps its original revocation time.
+func (r *mongodbAPIKeyRepo) RevokeKey(ctx context.Context, orgID, keyID string) (*types.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	orgOID, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+	keyOID, err := bson.ObjectIDFromHex(keyID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	filter := bson.M{"_id": keyOID, "org_id": orgOID}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"revoked_at": bson.M{"$ifNull": bson.A{"$revoked_at", time.Now()}}}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var key types.APIKey
+	if err := r.keys.FindOneAndUpdate(ctx, filter, update, opts).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// TouchKey records when an API key was last used.
+func (r *mongodbAPIKeyRepo) TouchKey(ctx context.Context, id bson.ObjectID, usedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	_, err := r.keys.UpdateByID(ctx, id, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	return err
+}
@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Scopes failed login attempts are counted in.
+const (
+	FailureScopeAccount = "account" // by email address, whether or not an account exists
+	FailureScopeIP      = "ip"      // by client IP address
+)
+
+type redisLoginAttemptRepo struct {
+	client *redis.Client
+}
+
+// LoginAttemptRepo defines the interface for failed login counters and account lockouts.
+type LoginAttemptRepo interface {
+	RecordFailure(ctx context.Context, scope, id string, window time.Duration) (*FailureWindow, error)
+	Failures(ctx context.Context, scope, id string, window time.Duration) (*FailureWindow, error)
+	ResetFailures(ctx context.Context, scope, id string) error
+	Lock(ctx context.Context, email string, d time.Duration) error
+	LockedFor(ctx context.Context, email string) (time.Duration, error)
+	Unlock(ctx context.Context, email string) error
+}
+
+// FailureWindow summarizes the failed login attempts within a sliding window.
+type FailureWindow struct {
+	Count int
+	First time.Time // oldest failure still in the window
+	Last  time.Time // most recent failure
+}
+
+// NewLoginAttemptRepo creates a new instance of redisLoginAttemptRepo.
+func NewLoginAttemptRepo(client *redis.Client) LoginAttemptRepo {
+	return &redisLoginAttemptRepo{client: client}
+}
+
+// RecordFailure adds a failed attempt to a sliding window and returns the failures now in it.
+func (r *redisLoginAttemptRepo) RecordFailure(ctx context.Context, scope, id string, window time.Duration) (*FailureWindow, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	key := failuresKey(scope, id)
+	now := time.Now()
+
+	var entries *redis.ZSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-window).UnixMilli(), 10))
+		// Attempts in the same millisecond must not collapse into a single member
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: rand.Text()})
+		pipe.Expire(ctx, key, window)
+		entries = pipe.ZRangeWithScores(ctx, key, 0, -1)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toFailureWindow(entries.Val()), nil
+}
+
+// Failures returns the failed attempts within a sliding window.
+func (r *redisLoginAttemptRepo) Failures(ctx context.Context, scope, id string, window time.Duration) (*FailureWindow, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	key := failuresKey(scope, id)
+	since := time.Now().Add(-window).UnixMilli()
+
+	entries, err := r.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(since, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return toFailureWindow(entries), nil
+}
+
+// ResetFailures forgets the failed attempts, e.g., after a successful login.
+func (r *redisLoginAttemptRepo) ResetFailures(ctx context.Context, scope, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.Del(ctx, failuresKey(scope, id)).Err()
+}
+
+// Lock locks the account with the given email address for a duration.
+func (r *redisLoginAttemptRepo) Lock(ctx context.Context, email string, d time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.Set(ctx, lockedKey(email), 1, d).Err()
+}
+
+// LockedFor returns how long the account with the given email address stays locked, 0 if it isn't.
+func (r *redisLoginAttemptRepo) LockedFor(ctx context.Context, email string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	ttl, err := r.client.PTTL(ctx, lockedKey(email)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// Negative TTLs mean the key does not exist or has no expiry
+	return max(ttl, 0), nil
+}
+
+// Unlock lifts the lock on the account with the given email address.
+func (r *redisLoginAttemptRepo) Unlock(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.Del(ctx, lockedKey(email)).Err()
+}
+
+func toFailureWindow(entries []redis.Z) *FailureWindow {
+	w := &FailureWindow{Count: len(entries)}
+	if len(entries) > 0 {
+		w.First = time.UnixMilli(int64(entries[0].Score))
+		w.Last = time.UnixMilli(int64(entries[len(entries)-1].Score))
+	}
+	return w
+}
+
+func failuresKey(scope, id string) string {
+	return fmt.Sprintf("auth:login_failures:%s:%s", scope, id)
+}
+
+func lockedKey(email string) string {
+	return fmt.Sprintf("auth:login_locked:%s", email)
+}
@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// deliveryRetention is how long delivery statuses are kept.
+const deliveryRetention = 90 * 24 * time.Hour
+
+type mongodbDeliveryRepo struct {
+	deliveries *mongo.Collection
+}
+
+// DeliveryRepo defines the interface for the delivery statuses of notifications.
+type DeliveryRepo interface {
+	CreateDelivery(ctx context.Context, d *types.Delivery) error
+	RecordAttempt(ctx context.Context, id bson.ObjectID, attempt types.DeliveryAttempt, status string) error
+	ListDeliveries(ctx context.Context, userID string, limit int64) ([]*types.Delivery, error)
+}
+
+// NewDeliveryRepo creates a new instance of mongodbDeliveryRepo.
+func NewDeliveryRepo(ctx context.Context, deliveries *mongo.Collection) (DeliveryRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(deliveryRetention.Seconds())),
+		},
+	}
+	if _, err := deliveries.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, err
+	}
+
+	return &mongodbDeliveryRepo{deliveries: deliveries}, nil
+}
+
+// CreateDelivery stores the delivery status of a new notification.
+func (r *mongodbDeliveryRepo) CreateDelivery(ctx context.Context, d *types.Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = d.CreatedAt
+	if d.Attempts == nil {
+		d.Attempts = []types.DeliveryAttempt{}
+	}
+
+	res, err := r.deliveries.InsertOne(ctx, d)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		d.ID = oid
+	}
+	return nil
+}
+
+// RecordAttempt appends a delivery attempt to a notification and sets its status. Successful attempts also
+// record the channel the notification was delivered through.
+func (r *mongodbDeliveryRepo) RecordAttempt(ctx context.Context, id bson.ObjectID, attempt types.DeliveryAttempt, status string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	set := bson.M{"status": status, "updated_at": time.Now()}
+	if attempt.Error == "" {
+		set["channel"] = attempt.Channel
+	}
+
+	res, err := r.deliveries.UpdateByID(ctx, id, bson.M{
+		"$set":  set,
+		"$push": bson.M{"attempts": attempt},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the most recent notifications of a user, newest first.
+func (r *mongodbDeliveryRepo) ListDeliveries(ctx context.Context, userID string, limit int64) ([]*types.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.deliveries.Find(ctx, bson.M{"user_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*types.Delivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// digestTTL is how long buffered reports are kept if digests stop being sent, matching how long reports
+// wait for review.
+const digestTTL = 7 * 24 * time.Hour
+
+const pendingDigestsKey = "digests:pending"
+
+type redisDigestRepo struct {
+	client *redis.Client
+}
+
+// DigestRepo defines the interface for the disaster reports buffered for admins who receive them as a digest.
+type DigestRepo interface {
+	AddToDigest(ctx context.Context, adminID string, report *DigestReport) error
+	PendingDigests(ctx context.Context) ([]string, error)
+	TakeDigest(ctx context.Context, adminID string) ([]*DigestReport, error)
+	RestoreDigest(ctx context.Context, adminID string, reports []*DigestReport) error
+}
+
+// DigestReport is a disaster report waiting in an admin's digest.
+type DigestReport struct {
+	DisasterID  string            `json:"disaster_id"`
+	Title       string            `json:"title"`
+	Severity    string            `json:"severity"`
+	Location    types.Coordinates `json:"location"`
+	Tags        []string          `json:"tags,omitempty"`
+	VolunteerID string            `json:"volunteer_id"`
+	ReportedAt  time.Time         `json:"reported_at"`
+}
+
+// NewDigestRepo creates a new instance of redisDigestRepo.
+func NewDigestRepo(client *redis.Client) DigestRepo {
+	return &redisDigestRepo{client: client}
+}
+
+// AddToDigest buffers a report for an admin's next digest.
+func (r *redisDigestRepo) AddToDigest(ctx context.Context, adminID string, report *DigestReport) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	value, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	key := digestKey(adminID)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, value)
+		pipe.Expire(ctx, key, digestTTL)
+		pipe.SAdd(ctx, pendingDigestsKey, adminID)
+		return nil
+	})
+	return err
+}
+
+// PendingDigests lists the admins with reports buffered.
+func (r *redisDigestRepo) PendingDigests(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.SMembers(ctx, pendingDigestsKey).Result()
+}
+
+// TakeDigest removes and returns the reports buffered for an admin, oldest first. Reports are taken
+// atomically, so each is sent once even with several instances sending digests.
+func (r *redisDigestRepo) TakeDigest(ctx context.Context, adminID string) ([]*DigestReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	key := digestKey(adminID)
+	var values *redis.StringSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		values = pipe.LRange(ctx, key, 0, -1)
+		pipe.Del(ctx, key)
+		pipe.SRem(ctx, pendingDigestsKey, adminID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*DigestReport, 0, len(values.Val()))
+	for _, v := range values.Val() {
+		var report DigestReport
+		if err := json.Unmarshal([]byte(v), &report); err != nil {
+			return nil, fmt.Errorf("invalid digest entry: %w", err)
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+// RestoreDigest puts reports taken from an admin's digest back in front of the ones buffered since, so they
+// are sent with the next digest.
+func (r *redisDigestRepo) RestoreDigest(ctx context.Context, adminID string, reports []*DigestReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	// LPUSH prepends one value at a time, so push the newest first
+	values := make([]any, 0, len(reports))
+	for i := len(reports) - 1; i >= 0; i-- {
+		value, err := json.Marshal(reports[i])
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	key := digestKey(adminID)
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, values...)
+		pipe.Expire(ctx, key, digestTTL)
+		pipe.SAdd(ctx, pendingDigestsKey, adminID)
+		return nil
+	})
+	return err
+}
+
+func digestKey(adminID string) string {
+	return fmt.Sprintf("digests:reports:%s", adminID)
+}
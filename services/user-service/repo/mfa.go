@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SetPendingMFASecret stores the secret of an MFA enrollment until it is confirmed.
+func (r *mongodbUserRepo) SetPendingMFASecret(ctx context.Context, id, secret string) error {
+	return r.update(ctx, id, bson.M{"mfa.pending_secret": secret})
+}
+
+// EnableMFA turns on MFA with the pending secret of a user, along with hashed recovery codes and the time step
+// of the code that confirmed the enrollment. It returns ErrNoResourcesFound if the pending secret changed meanwhile.
+func (r *mongodbUserRepo) EnableMFA(ctx context.Context, id, secret string, recoveryCodes []string, step int64) error {
+	now := time.Now()
+	filter := bson.M{"mfa.pending_secret": secret}
+	update := bson.M{
+		"$set": bson.M{
+			"mfa.enabled":        true,
+			"mfa.enabled_at":     now,
+			"mfa.secret":         secret,
+			"mfa.recovery_codes": recoveryCodes,
+			"mfa.last_step":      step,
+			"updated_at":         now,
+		},
+		"$unset": bson.M{"mfa.pending_secret": ""},
+	}
+	return r.updateWhere(ctx, id, filter, update)
+}
+
+// DisableMFA turns off MFA for a user, discarding the secret and recovery codes.
+func (r *mongodbUserRepo) DisableMFA(ctx context.Context, id string) error {
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"mfa": ""},
+	}
+	return r.updateWhere(ctx, id, bson.M{}, update)
+}
+
+// SetRecoveryCodes replaces the hashed recovery codes of a user.
+func (r *mongodbUserRepo) SetRecoveryCodes(ctx context.Context, id string, recoveryCodes []string) error {
+	return r.update(ctx, id, bson.M{"mfa.recovery_codes": recoveryCodes})
+}
+
+// UseMFAStep records the time step of an accepted TOTP code. It reports false if a code of the same or a later
+// step was already accepted, i.e., the code is being replayed.
+func (r *mongodbUserRepo) UseMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	filter := bson.M{"mfa.enabled": true, "mfa.last_step": bson.M{"$lt": step}}
+	err := r.updateWhere(ctx, id, filter, bson.M{"$set": bson.M{"mfa.last_step": step}})
+	return used(err)
+}
+
+// ConsumeRecoveryCode removes a hashed recovery code of a user. It reports false if the user has no such code.
+func (r *mongodbUserRepo) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	filter := bson.M{"mfa.enabled": true, "mfa.recovery_codes": codeHash}
+	err := r.updateWhere(ctx, id, filter, bson.M{"$pull": bson.M{"mfa.recovery_codes": codeHash}})
+	return used(err)
+}
+
+// updateWhere applies an update to a user if it also matches the filter.
+func (r *mongodbUserRepo) updateWhere(ctx context.Context, id string, filter, update bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+
+	filter["_id"] = oid
+	res, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
+
+// used turns the result of a conditional update into whether it applied.
+func used(err error) (bool, error) {
+	if errors.Is(err, ErrNoResourcesFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -28,7 +28,17 @@ type UserRepo interface {
 	Create(ctx context.Context, user *types.User) (string, error)
 	GetByID(ctx context.Context, id string) (*types.User, error)
 	GetByEmail(ctx context.Context, email string) (*types.User, error)
-	GetAllByRole(ctx context.Context, role string) ([]*types.User, error)
+	GetAllByRole(ctx context.Context, role types.Role) ([]*types.User, error)
+	SetEmailVerified(ctx context.Context, id string) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	UpdateRole(ctx context.Context, id string, role types.Role, regions []types.Region) error
+	UpdateNotificationSettings(ctx context.Context, id string, settings *types.NotificationSettings) error
+	SetPendingMFASecret(ctx context.Context, id, secret string) error
+	EnableMFA(ctx context.Context, id, secret string, recoveryCodes []string, step int64) error
+	DisableMFA(ctx context.Context, id string) error
+	SetRecoveryCodes(ctx context.Context, id string, recoveryCodes []string) error
+	UseMFAStep(ctx context.Context, id string, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error)
 }
 
 // NewUserRepo creates a new instance of inMemoryUserRepo.
@@ -72,14 +82,24 @@ func (r *mongodbUserRepo) GetByID(ctx context.Context, id string) (*types.User,
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
 	var user types.User
 	filter := bson.M{
-		"_id": id,
+		"_id": oid,
 	}
 
-	err := r.db.FindOne(ctx, filter).Decode(&user)
+	err = r.db.FindOne(ctx, filter).Decode(&user)
 	if err != nil {
-		return nil, err
+		switch err {
+		case mongo.ErrNoDocuments:
+			return nil, ErrNoResourcesFound
+		default:
+			return nil, err
+		}
 	}
 	return &user, nil
 }
@@ -103,11 +123,11 @@ func (r *mongodbUserRepo) GetByEmail(ctx context.Context, email string) (*types.
 }
 
 // GetAllByRole retrieves all users with the specified role.
-func (r *mongodbUserRepo) GetAllByRole(ctx context.Context, role string) ([]*types.User, error) {
+func (r *mongodbUserRepo) GetAllByRole(ctx context.Context, role types.Role) ([]*types.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
 	defer cancel()
 
-	cursor, err := r.db.Find(ctx, map[string]string{"role": role})
+	cursor, err := r.db.Find(ctx, bson.M{"role": role})
 	if err != nil {
 		return nil, err
 	}
@@ -127,3 +147,44 @@ func (r *mongodbUserRepo) GetAllByRole(ctx context.Context, role string) ([]*typ
 
 	return users, nil
 }
+
+// SetEmailVerified marks the email address of a user as verified.
+func (r *mongodbUserRepo) SetEmailVerified(ctx context.Context, id string) error {
+	return r.update(ctx, id, bson.M{"email_verified": true})
+}
+
+// UpdatePassword replaces the password hash of a user.
+func (r *mongodbUserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	return r.update(ctx, id, bson.M{"password": passwordHash})
+}
+
+// UpdateRole replaces the role of a user and the regions it is scoped to.
+func (r *mongodbUserRepo) UpdateRole(ctx context.Context, id string, role types.Role, regions []types.Region) error {
+	return r.update(ctx, id, bson.M{"role": role, "regions": regions})
+}
+
+// UpdateNotificationSettings replaces how a user wants to be notified.
+func (r *mongodbUserRepo) UpdateNotificationSettings(ctx context.Context, id string, settings *types.NotificationSettings) error {
+	return r.update(ctx, id, bson.M{"notifications": settings})
+}
+
+// update sets fields of a user, along with its update time.
+func (r *mongodbUserRepo) update(ctx context.Context, id string, fields bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+
+	fields["updated_at"] = time.Now()
+	res, err := r.db.UpdateByID(ctx, oid, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
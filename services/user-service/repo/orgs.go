@@ -0,0 +1,434 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbOrgRepo struct {
+	orgs    *mongo.Collection
+	members *mongo.Collection
+	teams   *mongo.Collection
+	invites *mongo.Collection
+	users   *mongo.Collection
+}
+
+// OrgRepo defines the interface for organizations, their members, teams and invites.
+type OrgRepo interface {
+	CreateOrg(ctx context.Context, org *types.Organization) error
+	GetOrg(ctx context.Context, id string) (*types.Organization, error)
+	AddMember(ctx context.Context, m *types.Membership) error
+	GetMember(ctx context.Context, orgID, userID string) (*types.Membership, error)
+	ListMembers(ctx context.Context, orgID, teamID string) ([]*OrgMember, error)
+	ListMemberships(ctx context.Context, userID string) ([]*types.Membership, error)
+	CountMembers(ctx context.Context, orgID string, role types.OrgRole) (int64, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role types.OrgRole) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	CreateTeam(ctx context.Context, team *types.Team) error
+	GetTeam(ctx context.Context, orgID, teamID string) (*types.Team, error)
+	ListTeams(ctx context.Context, orgID string) ([]*types.Team, error)
+	AddToTeam(ctx context.Context, orgID, userID, teamID string) error
+	RemoveFromTeam(ctx context.Context, orgID, userID, teamID string) error
+	CreateInvite(ctx context.Context, invite *types.OrgInvite) error
+	ClaimInvite(ctx context.Context, codeHash, email, userID string) (*types.OrgInvite, error)
+	ReleaseInvite(ctx context.Context, id bson.ObjectID) error
+}
+
+// OrgMember is a membership listed by ListMembers, with the member's name and email address.
+type OrgMember struct {
+	types.Membership `bson:",inline"`
+	Name             string `bson:"name"`
+	Email            string `bson:"email"`
+}
+
+// NewOrgRepo creates a new instance of mongodbOrgRepo. Member lists join the users collection for names.
+func NewOrgRepo(ctx context.Context, orgs, members, teams, invites, users *mongo.Collection) (OrgRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	memberIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	}
+	if _, err := members.Indexes().CreateMany(ctx, memberIndexes); err != nil {
+		return nil, err
+	}
+
+	teamIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := teams.Indexes().CreateOne(ctx, teamIndex); err != nil {
+		return nil, err
+	}
+
+	inviteIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "code_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := invites.Indexes().CreateOne(ctx, inviteIndex); err != nil {
+		return nil, err
+	}
+
+	return &mongodbOrgRepo{orgs: orgs, members: members, teams: teams, invites: invites, users: users}, nil
+}
+
+// CreateOrg stores a new organization.
+func (r *mongodbOrgRepo) CreateOrg(ctx context.Context, org *types.Organization) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	org.CreatedAt = time.Now()
+	org.UpdatedAt = org.CreatedAt
+
+	res, err := r.orgs.InsertOne(ctx, org)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		org.ID = oid
+	}
+	return nil
+}
+
+// GetOrg retrieves an organization by its ID.
+func (r *mongodbOrgRepo) GetOrg(ctx context.Context, id string) (*types.Organization, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	var org types.Organization
+	if err := r.orgs.FindOne(ctx, bson.M{"_id": oid}).Decode(&org); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+// AddMember adds a user to an organization. It returns ErrResourceConflict if the user already is a member.
+func (r *mongodbOrgRepo) AddMember(ctx context.Context, m *types.Membership) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	m.JoinedAt = time.Now()
+	if m.TeamIDs == nil {
+		m.TeamIDs = []string{}
+	}
+
+	res, err := r.members.InsertOne(ctx, m)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrResourceConflict
+		}
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		m.ID = oid
+	}
+	return nil
+}
+
+// GetMember retrieves the membership of a user in an organization.
+func (r *mongodbOrgRepo) GetMember(ctx context.Context, orgID, userID string) (*types.Membership, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	filter, err := memberFilter(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var m types.Membership
+	if err := r.members.FindOne(ctx, filter).Decode(&m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListMembers retrieves the members of an organization, optionally only those of a team, in joining order.
+func (r *mongodbOrgRepo) ListMembers(ctx context.Context, orgID, teamID string) ([]*OrgMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	match := bson.M{"org_id": oid}
+	if teamID != "" {
+		match["team_ids"] = teamID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "joined_at", Value: 1}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.users.Name(),
+			"localField":   "user_id",
+			"foreignField": "_id",
+			"as":           "user",
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"name":  bson.M{"$first": "$user.name"},
+			"email": bson.M{"$first": "$user.email"},
+		}}},
+		{{Key: "$project", Value: bson.M{"user": 0}}},
+	}
+
+	cursor, err := r.members.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var members []*OrgMember
+	if err := cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListMemberships retrieves the organization memberships of a user.
+func (r *mongodbOrgRepo) ListMemberships(ctx context.Context, userID string) ([]*types.Membership, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "joined_at", Value: 1}})
+	cursor, err := r.members.Find(ctx, bson.M{"user_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var memberships []*types.Membership
+	if err := cursor.All(ctx, &memberships); err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// CountMembers counts the members of an organization with a role.
+func (r *mongodbOrgRepo) CountMembers(ctx context.Context, orgID string, role types.OrgRole) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return 0, ErrNoResourcesFound
+	}
+
+	return r.members.CountDocuments(ctx, bson.M{"org_id": oid, "role": role})
+}
+
+// UpdateMemberRole changes the organization role of a member.
+func (r *mongodbOrgRepo) UpdateMemberRole(ctx context.Context, orgID, userID string, role types.OrgRole) error {
+	return r.updateMember(ctx, orgID, userID, bson.M{"$set": bson.M{"role": role}})
+}
+
+// RemoveMember removes a user from an organization.
+func (r *mongodbOrgRepo) RemoveMember(ctx context.Context, orgID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	filter, err := memberFilter(orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.members.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
+
+// CreateTeam stores a new team. It returns ErrResourceConflict if the organization has a team with the same name.
+func (r *mongodbOrgRepo) CreateTeam(ctx context.Context, team *types.Team) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	team.CreatedAt = time.Now()
+
+	res, err := r.teams.InsertOne(ctx, team)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrResourceConflict
+		}
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		team.ID = oid
+	}
+	return nil
+}
+
+// GetTeam retrieves a team of an organization.
+func (r *mongodbOrgRepo) GetTeam(ctx context.Context, orgID, teamID string) (*types.Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	orgOID, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+	teamOID, err := bson.ObjectIDFromHex(teamID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	var team types.Team
+	if err := r.teams.FindOne(ctx, bson.M{"_id": teamOID, "org_id": orgOID}).Decode(&team); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+// ListTeams retrieves the teams of an organization by name.
+func (r *mongodbOrgRepo) ListTeams(ctx context.Context, orgID string) ([]*types.Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.teams.Find(ctx, bson.M{"org_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var teams []*types.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// AddToTeam adds a member to a team of their organization.
+func (r *mongodbOrgRepo) AddToTeam(ctx context.Context, orgID, userID, teamID string) error {
+	return r.updateMember(ctx, orgID, userID, bson.M{"$addToSet": bson.M{"team_ids": teamID}})
+}
+
+// RemoveFromTeam removes a member from a team of their organization.
+func (r *mongodbOrgRepo) RemoveFromTeam(ctx context.Context, orgID, userID, teamID string) error {
+	return r.updateMember(ctx, orgID, userID, bson.M{"$pull": bson.M{"team_ids": teamID}})
+}
+
+// CreateInvite stores a new organization invite.
+func (r *mongodbOrgRepo) CreateInvite(ctx context.Context, invite *types.OrgInvite) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	invite.CreatedAt = time.Now()
+
+	res, err := r.invites.InsertOne(ctx, invite)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrResourceConflict
+		}
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		invite.ID = oid
+	}
+	return nil
+}
+
+// ClaimInvite atomically marks an unexpired, unaccepted invite for the given email address as accepted by a user,
+// so it can't be accepted twice. It returns ErrNoResourcesFound if no such invite exists.
+func (r *mongodbOrgRepo) ClaimInvite(ctx context.Context, codeHash, email, userID string) (*types.OrgInvite, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"code_hash":   codeHash,
+		"email":       email,
+		"expires_at":  bson.M{"$gt": now},
+		"accepted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"accepted_at": now, "accepted_by": userID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invite types.OrgInvite
+	if err := r.invites.FindOneAndUpdate(ctx, filter, update, opts).Decode(&invite); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ReleaseInvite makes a claimed invite acceptable again, e.g., when joining the organization failed.
+func (r *mongodbOrgRepo) ReleaseInvite(ctx context.Context, id bson.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	_, err := r.invites.UpdateByID(ctx, id, bson.M{"$unset": bson.M{"accepted_at": "", "accepted_by": ""}})
+	return err
+}
+
+func (r *mongodbOrgRepo) updateMember(ctx context.Context, orgID, userID string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	filter, err := memberFilter(orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.members.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
+
+func memberFilter(orgID, userID string) (bson.M, error) {
+	orgOID, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+	userOID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+	return bson.M{"org_id": orgOID, "user_id": userOID}, nil
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbProfileRepo struct {
+	profiles *mongo.Collection
+	users    *mongo.Collection
+}
+
+// ProfileRepo defines the interface for volunteer profile operations.
+type ProfileRepo interface {
+	GetProfile(ctx context.Context, userID string) (*types.VolunteerProfile, error)
+	UpsertProfile(ctx context.Context, profile *types.VolunteerProfile) error
+	FindVolunteers(ctx context.Context, q *VolunteerQuery) ([]*VolunteerMatch, error)
+}
+
+// VolunteerQuery describes a distance-ordered search for volunteers around a point.
+type VolunteerQuery struct {
+	Lat          float64
+	Lon          float64
+	RadiusMeters int
+	Skills       []string // volunteers must have every skill
+	Languages    []string // volunteers must speak one of the languages, if any
+	Vehicles     []string // volunteers must have one of the vehicles, if any
+	Limit        int
+}
+
+// VolunteerMatch is a volunteer found by FindVolunteers.
+type VolunteerMatch struct {
+	types.VolunteerProfile `bson:",inline"`
+	Name                   string  `bson:"name"`
+	Distance               float64 `bson:"distance"` // meters from the query point
+}
+
+// NewProfileRepo creates a new instance of mongodbProfileRepo. Searches join the users collection,
+// so only users who are still volunteers are found.
+func NewProfileRepo(ctx context.Context, profiles, users *mongo.Collection) (ProfileRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexModel := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "home", Value: "2dsphere"}},
+			Options: options.Index().SetName("home_2dsphere"),
+		},
+		{
+			Keys:    bson.D{{Key: "skills", Value: 1}},
+			Options: options.Index().SetName("skills"),
+		},
+	}
+	if _, err := profiles.Indexes().CreateMany(ctx, indexModel); err != nil {
+		return nil, err
+	}
+
+	return &mongodbProfileRepo{profiles: profiles, users: users}, nil
+}
+
+// GetProfile retrieves the volunteer profile of a user.
+func (r *mongodbProfileRepo) GetProfile(ctx context.Context, userID string) (*types.VolunteerProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	var profile types.VolunteerProfile
+	if err := r.profiles.FindOne(ctx, bson.M{"_id": oid}).Decode(&profile); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// UpsertProfile creates or replaces the volunteer profile of a user.
+func (r *mongodbProfileRepo) UpsertProfile(ctx context.Context, profile *types.VolunteerProfile) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	profile.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.profiles.ReplaceOne(ctx, bson.M{"_id": profile.UserID}, profile, opts)
+	return err
+}
+
+// FindVolunteers retrieves volunteers whose home is within a radius (in meters) of given coordinates and who
+// match the skill, language and vehicle filters, nearest first.
+func (r *mongodbProfileRepo) FindVolunteers(ctx context.Context, q *VolunteerQuery) ([]*VolunteerMatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	query := bson.M{}
+	if len(q.Skills) > 0 {
+		query["skills"] = bson.M{"$all": q.Skills}
+	}
+	if len(q.Languages) > 0 {
+		query["languages"] = bson.M{"$in": q.Languages}
+	}
+	if len(q.Vehicles) > 0 {
+		query["vehicles"] = bson.M{"$in": q.Vehicles}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: bson.M{
+			"near": bson.M{
+				"type":        "Point",
+				"coordinates": []float64{q.Lon, q.Lat}, // GeoJSON format is [longitude, latitude]
+			},
+			"distanceField": "distance",
+			"maxDistance":   q.RadiusMeters,
+			"spherical":     true,
+			"key":           "home",
+			"query":         query,
+		}}},
+		// Profiles outlive the volunteer role, e.g., after a promotion, so check the current role
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.users.Name(),
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "user",
+		}}},
+		{{Key: "$match", Value: bson.M{"user.role": types.RoleVolunteer}}},
+		{{Key: "$set", Value: bson.M{"name": bson.M{"$first": "$user.name"}}}},
+		{{Key: "$project", Value: bson.M{"user": 0}}},
+	}
+	if q.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: q.Limit}})
+	}
+
+	cursor, err := r.profiles.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []*VolunteerMatch
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/db"
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongodbRoleRepo struct {
+	invites *mongo.Collection
+	changes *mongo.Collection
+}
+
+// RoleRepo defines the interface for role invites and the role change audit log.
+type RoleRepo interface {
+	CreateInvite(ctx context.Context, invite *types.Invite) (string, error)
+	ClaimInvite(ctx context.Context, codeHash, email string) (*types.Invite, error)
+	ReleaseInvite(ctx context.Context, id string) error
+	CompleteInvite(ctx context.Context, id, userID string) error
+	RecordRoleChange(ctx context.Context, change *types.RoleChange) error
+	ListRoleChanges(ctx context.Context, userID string) ([]*types.RoleChange, error)
+}
+
+// NewRoleRepo creates a new instance of mongodbRoleRepo.
+func NewRoleRepo(ctx context.Context, invites, changes *mongo.Collection) (RoleRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	inviteIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "code_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := invites.Indexes().CreateOne(ctx, inviteIndex); err != nil {
+		return nil, err
+	}
+
+	changeIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}
+	if _, err := changes.Indexes().CreateOne(ctx, changeIndex); err != nil {
+		return nil, err
+	}
+
+	return &mongodbRoleRepo{invites: invites, changes: changes}, nil
+}
+
+// CreateInvite stores a new invite.
+func (r *mongodbRoleRepo) CreateInvite(ctx context.Context, invite *types.Invite) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	invite.CreatedAt = time.Now()
+
+	res, err := r.invites.InsertOne(ctx, invite)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", ErrResourceConflict
+		}
+		return "", err
+	}
+
+	return db.PrimitiveToHex(res.InsertedID)
+}
+
+// ClaimInvite atomically marks an unexpired, unredeemed invite as redeemed by the given email address,
+// so it can't be redeemed twice. It returns ErrNoResourcesFound if no such invite exists.
+func (r *mongodbRoleRepo) ClaimInvite(ctx context.Context, codeHash, email string) (*types.Invite, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"code_hash":   codeHash,
+		"expires_at":  bson.M{"$gt": now},
+		"redeemed_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"redeemed_at": now, "redeemed_by": email}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invite types.Invite
+	if err := r.invites.FindOneAndUpdate(ctx, filter, update, opts).Decode(&invite); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ReleaseInvite makes a claimed invite redeemable again, e.g., when the signup it was claimed for failed.
+func (r *mongodbRoleRepo) ReleaseInvite(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+
+	_, err = r.invites.UpdateByID(ctx, oid, bson.M{"$unset": bson.M{"redeemed_at": "", "redeemed_by": ""}})
+	return err
+}
+
+// CompleteInvite records the user created by redeeming a claimed invite.
+func (r *mongodbRoleRepo) CompleteInvite(ctx context.Context, id, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+
+	_, err = r.invites.UpdateByID(ctx, oid, bson.M{"$set": bson.M{"redeemed_by": userID}})
+	return err
+}
+
+// RecordRoleChange appends a record to the role change audit log.
+func (r *mongodbRoleRepo) RecordRoleChange(ctx context.Context, change *types.RoleChange) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	change.CreatedAt = time.Now()
+
+	res, err := r.changes.InsertOne(ctx, change)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		change.ID = oid
+	}
+	return nil
+}
+
+// ListRoleChanges retrieves the role changes of a user, most recent first.
+func (r *mongodbRoleRepo) ListRoleChanges(ctx context.Context, userID string) ([]*types.RoleChange, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.changes.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []*types.RoleChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
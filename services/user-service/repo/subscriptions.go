@@ -0,0 +1,213 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	types "github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// MaxAlertRadius is the largest radius of a circular alert area, in meters.
+const MaxAlertRadius = 200000
+
+// geoKeyErrorCode is the MongoDB error code for documents whose geometry can't be indexed,
+// e.g., self-intersecting polygons.
+const geoKeyErrorCode = 16755
+
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+type mongodbSubscriptionRepo struct {
+	subscriptions *mongo.Collection
+}
+
+// SubscriptionRepo defines the interface for alert subscriptions.
+type SubscriptionRepo interface {
+	CreateSubscription(ctx context.Context, sub *types.AlertSubscription) error
+	ListSubscriptions(ctx context.Context, userID string) ([]*types.AlertSubscription, error)
+	CountSubscriptions(ctx context.Context, userID string) (int64, error)
+	DeleteSubscription(ctx context.Context, userID, id string) error
+	MatchSubscriptions(ctx context.Context, loc types.Coordinates, tags []string) ([]*types.AlertSubscription, error)
+}
+
+// subscriptionDoc is a stored alert subscription, with its area as GeoJSON for the geo index.
+type subscriptionDoc struct {
+	*types.AlertSubscription `bson:",inline"`
+	Area                     bson.M `bson:"area"`
+}
+
+// NewSubscriptionRepo creates a new instance of mongodbSubscriptionRepo.
+func NewSubscriptionRepo(ctx context.Context, subscriptions *mongo.Collection) (SubscriptionRepo, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	indexModel := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "area", Value: "2dsphere"}},
+			Options: options.Index().SetName("area_2dsphere"),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	}
+	if _, err := subscriptions.Indexes().CreateMany(ctx, indexModel); err != nil {
+		return nil, err
+	}
+
+	return &mongodbSubscriptionRepo{subscriptions: subscriptions}, nil
+}
+
+// CreateSubscription stores a new alert subscription. It returns ErrInvalidGeometry if its area can't be indexed.
+func (r *mongodbSubscriptionRepo) CreateSubscription(ctx context.Context, sub *types.AlertSubscription) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	sub.CreatedAt = time.Now()
+	if sub.Hazards == nil {
+		sub.Hazards = []string{}
+	}
+
+	res, err := r.subscriptions.InsertOne(ctx, &subscriptionDoc{AlertSubscription: sub, Area: toGeoJSONArea(sub)})
+	if err != nil {
+		var serverErr mongo.ServerError
+		if errors.As(err, &serverErr) && serverErr.HasErrorCode(geoKeyErrorCode) {
+			return ErrInvalidGeometry
+		}
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		sub.ID = oid
+	}
+	return nil
+}
+
+// ListSubscriptions retrieves the alert subscriptions of a user, oldest first.
+func (r *mongodbSubscriptionRepo) ListSubscriptions(ctx context.Context, userID string) ([]*types.AlertSubscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrNoResourcesFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.subscriptions.Find(ctx, bson.M{"user_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subs []*types.AlertSubscription
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// CountSubscriptions counts the alert subscriptions of a user.
+func (r *mongodbSubscriptionRepo) CountSubscriptions(ctx context.Context, userID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, ErrNoResourcesFound
+	}
+
+	return r.subscriptions.CountDocuments(ctx, bson.M{"user_id": oid})
+}
+
+// DeleteSubscription deletes an alert subscription of a user.
+func (r *mongodbSubscriptionRepo) DeleteSubscription(ctx context.Context, userID, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	userOID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNoResourcesFound
+	}
+
+	res, err := r.subscriptions.DeleteOne(ctx, bson.M{"_id": oid, "user_id": userOID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNoResourcesFound
+	}
+	return nil
+}
+
+// MatchSubscriptions retrieves the subscriptions whose area contains a location and whose hazard filter matches
+// one of the tags, if they have one. Polygons are matched by intersection, circles by distance from their center.
+func (r *mongodbSubscriptionRepo) MatchSubscriptions(ctx context.Context, loc types.Coordinates, tags []string) ([]*types.AlertSubscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	point := bson.M{
+		"type":        "Point",
+		"coordinates": []float64{loc.Longitude, loc.Latitude}, // GeoJSON format is [longitude, latitude]
+	}
+	hazards := bson.M{"$or": bson.A{
+		bson.M{"hazards": bson.M{"$size": 0}},
+		bson.M{"hazards": bson.M{"$in": tags}},
+	}}
+
+	polygonFilter := bson.M{
+		"area.type": "Polygon",
+		"area":      bson.M{"$geoIntersects": bson.M{"$geometry": point}},
+		"$and":      bson.A{hazards},
+	}
+	cursor, err := r.subscriptions.Find(ctx, polygonFilter)
+	if err != nil {
+		return nil, err
+	}
+	var subs []*types.AlertSubscription
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+
+	// $geoNear can't take a per-document distance, so circles are found within the largest radius first
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: bson.M{
+			"near":          point,
+			"distanceField": "distance",
+			"maxDistance":   MaxAlertRadius,
+			"spherical":     true,
+			"key":           "area",
+			"query":         bson.M{"area.type": "Point", "$and": bson.A{hazards}},
+		}}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$lte": bson.A{"$distance", "$radius"}}}}},
+	}
+	cursor, err = r.subscriptions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var circles []*types.AlertSubscription
+	if err := cursor.All(ctx, &circles); err != nil {
+		return nil, err
+	}
+
+	return append(subs, circles...), nil
+}
+
+// toGeoJSONArea returns the area of a subscription as a GeoJSON Point or Polygon with a closed ring.
+func toGeoJSONArea(sub *types.AlertSubscription) bson.M {
+	if sub.Center != nil {
+		return bson.M{"type": "Point", "coordinates": []float64{sub.Center.Longitude, sub.Center.Latitude}}
+	}
+
+	ring := make([][]float64, 0, len(sub.Polygon)+1)
+	for _, c := range sub.Polygon {
+		ring = append(ring, []float64{c.Longitude, c.Latitude})
+	}
+	if len(sub.Polygon) > 0 && sub.Polygon[0] != sub.Polygon[len(sub.Polygon)-1] {
+		ring = append(ring, ring[0])
+	}
+	return bson.M{"type": "Polygon", "coordinates": [][][]float64{ring}}
+}
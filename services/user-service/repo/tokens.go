@@ -0,0 +1,202 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisTokenRepo struct {
+	client *redis.Client
+}
+
+// TokenRepo defines the interface for token revocation state.
+type TokenRepo interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	Generation(ctx context.Context, userID string) (int64, error)
+	BumpGeneration(ctx context.Context, userID string) (int64, error)
+	SaveRefreshToken(ctx context.Context, hash string, rt *RefreshToken) error
+	GetRefreshToken(ctx context.Context, hash string) (*RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, hash string, expiresAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, until time.Time) error
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	SaveActionToken(ctx context.Context, action, jti, userID string, expiresAt time.Time) error
+	ConsumeActionToken(ctx context.Context, action, jti string) (string, error)
+}
+
+// RefreshToken is the stored state of an opaque refresh token, keyed by the token's hash.
+// Tokens rotated from one another share a family, so a reused token can revoke all of them.
+type RefreshToken struct {
+	UserID     string    `json:"user_id"`
+	FamilyID   string    `json:"family_id"`
+	Generation int64     `json:"generation"`    // user's token generation at issue time
+	MFA        bool      `json:"mfa,omitempty"` // the family's sign in was verified with a second factor
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// NewTokenRepo creates a new instance of redisTokenRepo.
+func NewTokenRepo(client *redis.Client) TokenRepo {
+	return &redisTokenRepo{client: client}
+}
+
+// Revoke adds a token ID to the revocation list until the token would have expired anyway.
+func (r *redisTokenRepo) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, revokedKey(jti), 1, ttl).Err()
+}
+
+// IsRevoked reports whether a token ID is on the revocation list.
+func (r *redisTokenRepo) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	n, err := r.client.Exists(ctx, revokedKey(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Generation returns the current token generation of a user, 0 if it was never bumped.
+func (r *redisTokenRepo) Generation(ctx context.Context, userID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	gen, err := r.client.Get(ctx, generationKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return gen, err
+}
+
+// BumpGeneration increments the token generation of a user and returns the new generation.
+func (r *redisTokenRepo) BumpGeneration(ctx context.Context, userID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.Incr(ctx, generationKey(userID)).Result()
+}
+
+// SaveRefreshToken stores a refresh token until it expires.
+func (r *redisTokenRepo) SaveRefreshToken(ctx context.Context, hash string, rt *RefreshToken) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	value, err := json.Marshal(rt)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, refreshKey(hash), value, time.Until(rt.ExpiresAt)).Err()
+}
+
+// GetRefreshToken retrieves a stored refresh token by its hash.
+func (r *redisTokenRepo) GetRefreshToken(ctx context.Context, hash string) (*RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	value, err := r.client.Get(ctx, refreshKey(hash)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrNoResourcesFound
+		}
+		return nil, err
+	}
+
+	var rt RefreshToken
+	if err := json.Unmarshal(value, &rt); err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// MarkRefreshTokenUsed atomically marks a refresh token as used. It returns false if it was already used.
+func (r *redisTokenRepo) MarkRefreshTokenUsed(ctx context.Context, hash string, expiresAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.SetNX(ctx, refreshUsedKey(hash), 1, time.Until(expiresAt)).Result()
+}
+
+// RevokeFamily revokes every refresh token of a family. The revocation is kept until the given time,
+// by which every token of the family has expired.
+func (r *redisTokenRepo) RevokeFamily(ctx context.Context, familyID string, until time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, familyRevokedKey(familyID), 1, ttl).Err()
+}
+
+// IsFamilyRevoked reports whether a refresh token family was revoked.
+func (r *redisTokenRepo) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	n, err := r.client.Exists(ctx, familyRevokedKey(familyID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// SaveActionToken records an issued action token, e.g., an email verification link, until it expires.
+func (r *redisTokenRepo) SaveActionToken(ctx context.Context, action, jti, userID string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	return r.client.Set(ctx, actionKey(action, jti), userID, time.Until(expiresAt)).Err()
+}
+
+// ConsumeActionToken atomically removes an action token and returns the user it was issued to.
+// It returns ErrNoResourcesFound if the token expired or was already used.
+func (r *redisTokenRepo) ConsumeActionToken(ctx context.Context, action, jti string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	userID, err := r.client.GetDel(ctx, actionKey(action, jti)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrNoResourcesFound
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+func revokedKey(jti string) string {
+	return fmt.Sprintf("auth:revoked:%s", jti)
+}
+
+func generationKey(userID string) string {
+	return fmt.Sprintf("auth:generation:%s", userID)
+}
+
+func refreshKey(hash string) string {
+	return fmt.Sprintf("auth:refresh:%s", hash)
+}
+
+func refreshUsedKey(hash string) string {
+	return fmt.Sprintf("auth:refresh_used:%s", hash)
+}
+
+func familyRevokedKey(familyID string) string {
+	return fmt.Sprintf("auth:family_revoked:%s", familyID)
+}
+
+func actionKey(action, jti string) string {
+	return fmt.Sprintf("auth:action:%s:%s", action, jti)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/cprakhar/relief-ops/shared/util"
+)
+
+// Account actions authorized by emailed single-use tokens.
+const (
+	actionVerifyEmail   = "verify_email"
+	actionResetPassword = "reset_password"
+)
+
+var (
+	ErrInvalidActionToken   = errors.New("invalid or expired link")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+)
+
+// AccountConfig configures the emailed account flows.
+type AccountConfig struct {
+	WebURL              string        // base URL of the links sent by email
+	VerifyEmailExpiry   time.Duration // email verification link lifetime
+	ResetPasswordExpiry time.Duration // password reset link lifetime
+	InviteExpiry        time.Duration // role invite code lifetime
+	MFAChallengeExpiry  time.Duration // time to enter an MFA code after the password
+	APIKeyRateLimit     int           // requests per minute of API keys created without a rate limit
+}
+
+// RequestEmailVerification emails a user a link to confirm their email address.
+func (s *userService) RequestEmailVerification(ctx context.Context, userID string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	expiry := s.accountCfg.VerifyEmailExpiry
+	token, err := s.issueActionToken(ctx, actionVerifyEmail, userID, expiry)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Name      string
+		VerifyURL string
+		ExpiresIn string
+	}{
+		Name:      user.Name,
+		VerifyURL: fmt.Sprintf("%s/verify-email?token=%s", s.accountCfg.WebURL, token),
+		ExpiresIn: humanDuration(expiry),
+	}
+
+	s.sendMail(mail.VerifyEmailTemplate, user, data)
+	return nil
+}
+
+// ConfirmEmailVerification marks the email address of the user a verification token was issued to as verified.
+func (s *userService) ConfirmEmailVerification(ctx context.Context, token string) error {
+	userID, err := s.consumeActionToken(ctx, actionVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+	return s.repo.SetEmailVerified(ctx, userID)
+}
+
+// RequestPasswordReset emails a user a link to choose a new password. Unknown email addresses are ignored,
+// so the response does not reveal which addresses have an account.
+func (s *userService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil
+		}
+		return err
+	}
+
+	expiry := s.accountCfg.ResetPasswordExpiry
+	token, err := s.issueActionToken(ctx, actionResetPassword, user.ID.Hex(), expiry)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Name      string
+		ResetURL  string
+		ExpiresIn string
+	}{
+		Name:      user.Name,
+		ResetURL:  fmt.Sprintf("%s/reset-password?token=%s", s.accountCfg.WebURL, token),
+		ExpiresIn: humanDuration(expiry),
+	}
+
+	s.sendMail(mail.ResetPasswordTemplate, user, data)
+	return nil
+}
+
+// ResetPassword sets a new password for the user a password reset token was issued to, and signs the
+// user out everywhere since the old password may have been compromised.
+func (s *userService) ResetPassword(ctx context.Context, token, password string) error {
+	userID, err := s.consumeActionToken(ctx, actionResetPassword, token)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := util.EncryptPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	// The reset link was received at the user's address, which proves owning it
+	if err := s.repo.SetEmailVerified(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.tokens.BumpGeneration(ctx, userID); err != nil {
+		return err
+	}
+
+	if user, err := s.repo.GetByID(ctx, userID); err == nil {
+		s.sendSecurityNotice(mail.PasswordChangedTemplate, user)
+	}
+	return nil
+}
+
+// sendSecurityNotice emails a user about a change to their account's security, with a link to reset
+// their password in case it wasn't them.
+func (s *userService) sendSecurityNotice(templateFile string, user *types.User) {
+	data := struct {
+		Name     string
+		ResetURL string
+	}{
+		Name:     user.Name,
+		ResetURL: fmt.Sprintf("%s/forgot-password", s.accountCfg.WebURL),
+	}
+
+	s.sendMail(templateFile, user, data)
+}
+
+// issueActionToken creates a single-use token authorizing an action for a user.
+func (s *userService) issueActionToken(ctx context.Context, action, userID string, expiry time.Duration) (string, error) {
+	token, jti, err := util.GenerateActionToken(action, userID, s.jwtCfg.Keys, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokens.SaveActionToken(ctx, action, jti, userID, time.Now().Add(expiry)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeActionToken validates an action token and uses it up, returning the user it was issued to.
+func (s *userService) consumeActionToken(ctx context.Context, action, token string) (string, error) {
+	claims, err := util.ParseActionToken(token, action, s.jwtCfg.Keys)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidActionToken, err)
+	}
+
+	userID, err := s.tokens.ConsumeActionToken(ctx, action, claims.ID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return "", ErrInvalidActionToken
+		}
+		return "", err
+	}
+	if userID != claims.Subject {
+		return "", ErrInvalidActionToken
+	}
+	return userID, nil
+}
+
+// sendMail sends an email in the background, so slow mail delivery does not hold up the request.
+func (s *userService) sendMail(templateFile string, user *types.User, data any) {
+	go func() {
+		if _, err := s.mailer.Send(templateFile, mail.RecipientOf(user), data, false); err != nil {
+			logs.L().Errorw("Failed to send email", "template", templateFile, "email", user.Email, "error", err)
+		}
+	}()
+}
+
+// humanDuration formats a link lifetime for emails, e.g., "24 hours" or "30 minutes".
+func humanDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if h := int(d / time.Hour); h != 1 {
+			return fmt.Sprintf("%d hours", h)
+		}
+		return "1 hour"
+	}
+
+	if m := int(d.Round(time.Minute) / time.Minute); m != 1 {
+		return fmt.Sprintf("%d minutes", m)
+	}
+	return "1 minute"
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/notify"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	maxAlertSubscriptions   = 10
+	maxAlertNameLength      = 100
+	maxAlertHazards         = 10
+	maxAlertPolygonVertices = 100
+
+	// alertDedupeTTL is how long a user is remembered as alerted about a disaster.
+	alertDedupeTTL = 7 * 24 * time.Hour
+
+	disasterAlertKind = "disaster_alert"
+)
+
+var (
+	ErrInvalidSubscription  = errors.New("invalid alert subscription")
+	ErrTooManySubscriptions = errors.New("too many alert subscriptions")
+)
+
+// AlertConfig configures the alerts sent to users subscribed to an area. Each user gets at most MaxPerWindow
+// alerts within a sliding window; further alerts are dropped, so a burst of approvals doesn't flood anyone.
+type AlertConfig struct {
+	Window       time.Duration // sliding window alerts are counted in
+	MaxPerWindow int           // alerts a user gets within the window
+}
+
+// DisasterAlert is an approved disaster users subscribed to its area are alerted about.
+type DisasterAlert struct {
+	DisasterID string
+	Title      string
+	Location   types.Coordinates
+	Tags       []string
+}
+
+// CreateAlertSubscription saves an area a user wants to be alerted about. Subscriptions without channels
+// are alerted on the channels of the user's notification settings.
+func (s *userService) CreateAlertSubscription(ctx context.Context, userID string, sub *types.AlertSubscription) (*types.AlertSubscription, error) {
+	if err := normalizeSubscription(sub); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.subscriptions.CountSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if n >= maxAlertSubscriptions {
+		return nil, fmt.Errorf("%w: at most %d per user", ErrTooManySubscriptions, maxAlertSubscriptions)
+	}
+
+	sub.UserID = user.ID
+	if err := s.subscriptions.CreateSubscription(ctx, sub); err != nil {
+		if errors.Is(err, repo.ErrInvalidGeometry) {
+			return nil, fmt.Errorf("%w: polygon must not intersect itself", ErrInvalidSubscription)
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+// ListAlertSubscriptions retrieves the alert subscriptions of a user.
+func (s *userService) ListAlertSubscriptions(ctx context.Context, userID string) ([]*types.AlertSubscription, error) {
+	return s.subscriptions.ListSubscriptions(ctx, userID)
+}
+
+// DeleteAlertSubscription deletes an alert subscription of a user.
+func (s *userService) DeleteAlertSubscription(ctx context.Context, userID, id string) error {
+	return s.subscriptions.DeleteSubscription(ctx, userID, id)
+}
+
+// AlertSubscribers alerts the users subscribed to the area of an approved disaster and returns how many were
+// alerted. Users are alerted once per disaster, however many of their areas match, and alerts over a user's
+// limit are dropped. Failures to alert a single user are logged rather than returned.
+func (s *userService) AlertSubscribers(ctx context.Context, alert *DisasterAlert) (int, error) {
+	tags := make([]string, 0, len(alert.Tags))
+	for _, t := range alert.Tags {
+		tags = append(tags, strings.ToLower(strings.TrimSpace(t)))
+	}
+
+	subs, err := s.subscriptions.MatchSubscriptions(ctx, alert.Location, tags)
+	if err != nil {
+		return 0, err
+	}
+
+	// Users with several matching subscriptions are alerted on the channels of all of them
+	var users []bson.ObjectID
+	channels := make(map[bson.ObjectID][]string)
+	for _, sub := range subs {
+		if _, ok := channels[sub.UserID]; !ok {
+			users = append(users, sub.UserID)
+			channels[sub.UserID] = nil
+		}
+		for _, c := range sub.Channels {
+			if !slices.Contains(channels[sub.UserID], c) {
+				channels[sub.UserID] = append(channels[sub.UserID], c)
+			}
+		}
+	}
+
+	logger := logs.L()
+	alerted := 0
+	for _, userID := range users {
+		claimed, err := s.alertLimits.ClaimAlert(ctx, alert.DisasterID, userID.Hex(), alertDedupeTTL)
+		if err != nil {
+			logger.Warnw("Failed to claim disaster alert", "disasterID", alert.DisasterID, "userID", userID.Hex(), "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		recorded, err := s.alertLimits.RecordAlert(ctx, userID.Hex(), s.alertCfg.Window, s.alertCfg.MaxPerWindow)
+		if err != nil {
+			logger.Warnw("Failed to count disaster alert", "disasterID", alert.DisasterID, "userID", userID.Hex(), "error", err)
+			continue
+		}
+		if !recorded {
+			logger.Infow("Dropped disaster alert over the user's limit", "disasterID", alert.DisasterID, "userID", userID.Hex())
+			continue
+		}
+
+		user, err := s.repo.GetByID(ctx, userID.Hex())
+		if err != nil {
+			logger.Warnw("Failed to get subscribed user", "userID", userID.Hex(), "error", err)
+			continue
+		}
+
+		if s.sendAlert(ctx, user, alert, channels[userID]) {
+			alerted++
+		}
+	}
+	return alerted, nil
+}
+
+// sendAlert notifies a user of a disaster alert on the channels of their matching subscriptions, or else their
+// notification settings, reporting whether it was delivered.
+func (s *userService) sendAlert(ctx context.Context, user *types.User, alert *DisasterAlert, channels []string) bool {
+	hazards := strings.Join(alert.Tags, ", ")
+	disasterURL := fmt.Sprintf("%s/disasters/%s", s.accountCfg.WebURL, alert.DisasterID)
+	data := struct {
+		Name        string
+		Title       string
+		Hazards     string
+		DisasterURL string
+		ManageURL   string
+	}{
+		Name:        user.Name,
+		Title:       alert.Title,
+		Hazards:     hazards,
+		DisasterURL: disasterURL,
+		ManageURL:   fmt.Sprintf("%s/settings/alerts", s.accountCfg.WebURL),
+	}
+
+	text := "A disaster was confirmed in an area you watch."
+	if hazards != "" {
+		text = fmt.Sprintf("A disaster (%s) was confirmed in an area you watch.", hazards)
+	}
+	msg := &notify.Message{
+		Kind:     disasterAlertKind,
+		Template: mail.DisasterAlertTemplate,
+		Data:     data,
+		Title:    "Disaster alert: " + alert.Title,
+		Text:     text,
+		URL:      disasterURL,
+		Channels: channels,
+	}
+
+	if _, err := s.notifier.Notify(ctx, user, msg); err != nil {
+		logs.L().Warnw("Failed to deliver disaster alert", "disasterID", alert.DisasterID, "userID", user.ID.Hex(), "error", err)
+		return false
+	}
+	return true
+}
+
+// normalizeSubscription validates an alert subscription and normalizes its hazards and channels.
+func normalizeSubscription(sub *types.AlertSubscription) error {
+	sub.Name = strings.TrimSpace(sub.Name)
+	if sub.Name == "" || len(sub.Name) > maxAlertNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidSubscription, maxAlertNameLength)
+	}
+
+	switch {
+	case sub.Center != nil && len(sub.Polygon) > 0:
+		return fmt.Errorf("%w: give either a center and radius or a polygon", ErrInvalidSubscription)
+	case sub.Center != nil:
+		if !validCoordinates(*sub.Center) {
+			return fmt.Errorf("%w: center is out of range", ErrInvalidSubscription)
+		}
+		if sub.Radius < 1 || sub.Radius > repo.MaxAlertRadius {
+			return fmt.Errorf("%w: radius must be 1 to %d meters", ErrInvalidSubscription, repo.MaxAlertRadius)
+		}
+	case len(sub.Polygon) > 0:
+		if len(sub.Polygon) < 3 || len(sub.Polygon) > maxAlertPolygonVertices {
+			return fmt.Errorf("%w: polygon must have 3 to %d points", ErrInvalidSubscription, maxAlertPolygonVertices)
+		}
+		for _, c := range sub.Polygon {
+			if !validCoordinates(c) {
+				return fmt.Errorf("%w: polygon has a point out of range", ErrInvalidSubscription)
+			}
+		}
+		sub.Radius = 0
+	default:
+		return fmt.Errorf("%w: an area is required", ErrInvalidSubscription)
+	}
+
+	var hazards []string
+	for _, h := range sub.Hazards {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h != "" && !slices.Contains(hazards, h) {
+			hazards = append(hazards, h)
+		}
+	}
+	if len(hazards) > maxAlertHazards {
+		return fmt.Errorf("%w: at most %d hazards", ErrInvalidSubscription, maxAlertHazards)
+	}
+	sub.Hazards = hazards
+
+	channels := []string{}
+	for _, c := range sub.Channels {
+		if !slices.Contains(types.NotificationChannels, c) {
+			return fmt.Errorf("%w: unknown channel %q", ErrInvalidSubscription, c)
+		}
+		if !slices.Contains(channels, c) {
+			channels = append(channels, c)
+		}
+	}
+	sub.Channels = channels
+	return nil
+}
+
+func validCoordinates(c types.Coordinates) bool {
+	return c.Latitude >= -90 && c.Latitude <= 90 && c.Longitude >= -180 && c.Longitude <= 180
+}
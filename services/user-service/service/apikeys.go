@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+const (
+	// apiKeyScheme starts every API key, so leaked keys are easy to recognize.
+	apiKeyScheme = "rok_"
+
+	// apiKeyPrefixLength is the length of the part of a key stored in the clear to look it up.
+	apiKeyPrefixLength = 8
+
+	// apiKeyTouchInterval is how often the last use of a key is recorded at most, so busy keys don't
+	// write on every request.
+	apiKeyTouchInterval = time.Minute
+
+	// MaxAPIKeyRateLimit is the highest rate limit of an API key, in requests per minute.
+	MaxAPIKeyRateLimit = 6000
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrUnknownAPIKey = errors.New("unknown, expired or revoked API key")
+)
+
+// CreateAPIKey creates an API key for an organization on behalf of an org admin. Keys without a rate limit
+// get the default one. Only the key's hash is stored, so the returned key can't be retrieved again.
+func (s *userService) CreateAPIKey(ctx context.Context, actorID, orgID, name string, perms []authz.Permission, rateLimit int, expiresAt *time.Time) (string, *types.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxOrgNameLength {
+		return "", nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidAPIKey, maxOrgNameLength)
+	}
+	if len(perms) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one permission is required", ErrInvalidAPIKey)
+	}
+	var permissions []string
+	for _, p := range perms {
+		if !slices.Contains(authz.APIKeyPermissions, p) {
+			return "", nil, fmt.Errorf("%w: permission %q can't be granted to API keys", ErrInvalidAPIKey, p)
+		}
+		if !slices.Contains(permissions, string(p)) {
+			permissions = append(permissions, string(p))
+		}
+	}
+	if rateLimit == 0 {
+		rateLimit = s.accountCfg.APIKeyRateLimit
+	}
+	if rateLimit < 1 || rateLimit > MaxAPIKeyRateLimit {
+		return "", nil, fmt.Errorf("%w: rate limit must be 1 to %d requests per minute", ErrInvalidAPIKey, MaxAPIKeyRateLimit)
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return "", nil, fmt.Errorf("%w: expiry must be in the future", ErrInvalidAPIKey)
+	}
+
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+		return "", nil, err
+	}
+	org, err := s.orgs.GetOrg(ctx, orgID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	prefix := rand.Text()[:apiKeyPrefixLength]
+	key := apiKeyScheme + prefix + "_" + rand.Text()
+	apiKey := &types.APIKey{
+		OrgID:       org.ID,
+		Name:        name,
+		Prefix:      prefix,
+		KeyHash:     hashToken(key),
+		Permissions: permissions,
+		RateLimit:   rateLimit,
+		CreatedBy:   actorID,
+		ExpiresAt:   expiresAt,
+	}
+	if err := s.apiKeys.CreateKey(ctx, apiKey); err != nil {
+		return "", nil, err
+	}
+	return key, apiKey, nil
+}
+
+// ListAPIKeys retrieves the API keys of an organization, for its org admins.
+func (s *userService) ListAPIKeys(ctx context.Context, actorID, orgID string) ([]*types.APIKey, error) {
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.apiKeys.ListKeys(ctx, orgID)
+}
+
+// RevokeAPIKey revokes an API key of an organization on behalf of an org admin.
+func (s *userService) RevokeAPIKey(ctx context.Context, actorID, orgID, keyID string) (*types.APIKey, error) {
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.apiKeys.RevokeKey(ctx, orgID, keyID)
+}
+
+// VerifyAPIKey returns the API key a request presented and the user it acts as, if the key is active and its
+// creator is still an admin of the key's organization, and records its use.
+func (s *userService) VerifyAPIKey(ctx context.Context, key string) (*types.APIKey, *types.User, error) {
+	prefix, _, ok := strings.Cut(strings.TrimPrefix(key, apiKeyScheme), "_")
+	if !strings.HasPrefix(key, apiKeyScheme) || !ok || len(prefix) != apiKeyPrefixLength {
+		return nil, nil, ErrUnknownAPIKey
+	}
+
+	apiKey, err := s.apiKeys.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil, nil, ErrUnknownAPIKey
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashToken(key))) != 1 || !apiKey.Active(now) {
+		return nil, nil, ErrUnknownAPIKey
+	}
+
+	// Keys act as their creator, so they stop working once the creator is no longer an org admin
+	if err := s.requireOrgRole(ctx, apiKey.OrgID.Hex(), apiKey.CreatedBy, types.OrgRoleAdmin); err != nil {
+		if errors.Is(err, ErrNotOrgMember) || errors.Is(err, ErrOrgForbidden) || errors.Is(err, ErrForbidden) || errors.Is(err, repo.ErrNoResourcesFound) {
+			logs.L().Infow("Refused API key of a creator who is no longer an org admin", "keyID", apiKey.ID.Hex(), "userID", apiKey.CreatedBy)
+			return nil, nil, ErrUnknownAPIKey
+		}
+		return nil, nil, err
+	}
+	creator, err := s.repo.GetByID(ctx, apiKey.CreatedBy)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil, nil, ErrUnknownAPIKey
+		}
+		return nil, nil, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeys.TouchKey(ctx, apiKey.ID, now); err != nil {
+			logs.L().Warnw("Failed to record API key use", "keyID", apiKey.ID.Hex(), "error", err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+	return apiKey, creator, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// maxDigestReports is how many reports a digest lists; the rest are only counted.
+const maxDigestReports = 50
+
+// DigestConfig configures the digests admins who chose digest delivery receive new disaster reports in.
+type DigestConfig struct {
+	Interval time.Duration // how often digests are sent
+}
+
+// DisasterReport is a newly reported disaster admins are notified of for review.
+type DisasterReport struct {
+	DisasterID  string
+	Title       string
+	Severity    string
+	Location    types.Coordinates
+	Tags        []string
+	VolunteerID string
+}
+
+// NotifyAdmins notifies admins of a new disaster report. Admins who chose digest delivery get it in their next
+// digest, unless the report is high or critical; everyone else is emailed right away.
+func (s *userService) NotifyAdmins(ctx context.Context, report *DisasterReport) error {
+	admins, err := s.GetAdmins(ctx)
+	if err != nil {
+		return err
+	}
+
+	if report.Severity == "" {
+		report.Severity = types.SeverityMedium
+	}
+	urgent := slices.Index(types.SeverityLevels, report.Severity) >= slices.Index(types.SeverityLevels, types.SeverityHigh)
+
+	logger := logs.L()
+	var immediate []*types.User
+	for _, admin := range admins {
+		if urgent || admin.Notifications.ReportDelivery != types.ReportDeliveryDigest {
+			immediate = append(immediate, admin)
+			continue
+		}
+
+		entry := &repo.DigestReport{
+			DisasterID:  report.DisasterID,
+			Title:       report.Title,
+			Severity:    report.Severity,
+			Location:    report.Location,
+			Tags:        report.Tags,
+			VolunteerID: report.VolunteerID,
+			ReportedAt:  time.Now(),
+		}
+		// Better a separate email than a report nobody hears about
+		if err := s.digests.AddToDigest(ctx, admin.ID.Hex(), entry); err != nil {
+			logger.Warnw("Failed to add report to digest, sending it right away", "disasterID", report.DisasterID, "userID", admin.ID.Hex(), "error", err)
+			immediate = append(immediate, admin)
+		}
+	}
+
+	if len(immediate) == 0 {
+		return nil
+	}
+
+	data := struct {
+		DisasterID  string
+		Title       string
+		Severity    string
+		Location    string
+		VolunteerID string
+		ReviewURL   string
+	}{
+		DisasterID:  report.DisasterID,
+		Title:       report.Title,
+		Severity:    report.Severity,
+		Location:    formatLocation(report.Location),
+		VolunteerID: report.VolunteerID,
+		ReviewURL:   s.reviewURL(report.DisasterID),
+	}
+	return s.mailer.NotifyMultiple(immediate, data, false)
+}
+
+// RunDigests periodically sends every admin the reports buffered for them, until the context is cancelled.
+func (s *userService) RunDigests(ctx context.Context) error {
+	logger := logs.L()
+
+	ticker := time.NewTicker(s.digestCfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		admins, err := s.digests.PendingDigests(ctx)
+		if err != nil {
+			logger.Errorw("Failed to list pending digests", "error", err)
+			continue
+		}
+
+		sent := 0
+		for _, adminID := range admins {
+			if ctx.Err() != nil {
+				break
+			}
+			if s.sendDigest(ctx, adminID) {
+				sent++
+			}
+		}
+		if sent > 0 {
+			logger.Infow("Report digests sent", "admins", sent)
+		}
+	}
+}
+
+// sendDigest emails an admin the reports buffered for them, reporting whether it was sent. Reports are put back
+// for the next digest if the email fails.
+func (s *userService) sendDigest(ctx context.Context, adminID string) bool {
+	logger := logs.L()
+
+	reports, err := s.digests.TakeDigest(ctx, adminID)
+	if err != nil {
+		logger.Errorw("Failed to take digest", "userID", adminID, "error", err)
+		return false
+	}
+
+	// A redelivered report event buffers the same report twice
+	seen := make(map[string]bool, len(reports))
+	reports = slices.DeleteFunc(reports, func(r *repo.DigestReport) bool {
+		dup := seen[r.DisasterID]
+		seen[r.DisasterID] = true
+		return dup
+	})
+	if len(reports) == 0 {
+		return false
+	}
+
+	admin, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		logger.Warnw("Failed to get admin for digest, dropping it", "userID", adminID, "reports", len(reports), "error", err)
+		return false
+	}
+
+	// Most severe first, oldest first within a severity
+	slices.SortStableFunc(reports, func(a, b *repo.DigestReport) int {
+		if c := slices.Index(types.SeverityLevels, b.Severity) - slices.Index(types.SeverityLevels, a.Severity); c != 0 {
+			return c
+		}
+		return a.ReportedAt.Compare(b.ReportedAt)
+	})
+
+	type digestEntry struct {
+		Title     string
+		Severity  string
+		Location  string
+		Hazards   string
+		ReviewURL string
+	}
+	data := struct {
+		Count        int
+		More         int
+		Reports      []digestEntry
+		DashboardURL string
+	}{
+		Count:        len(reports),
+		More:         max(len(reports)-maxDigestReports, 0),
+		DashboardURL: fmt.Sprintf("%s/admin/review", s.accountCfg.WebURL),
+	}
+	for _, r := range reports[:min(len(reports), maxDigestReports)] {
+		data.Reports = append(data.Reports, digestEntry{
+			Title:     r.Title,
+			Severity:  r.Severity,
+			Location:  formatLocation(r.Location),
+			Hazards:   strings.Join(r.Tags, ", "),
+			ReviewURL: s.reviewURL(r.DisasterID),
+		})
+	}
+
+	if _, err := s.mailer.Send(mail.AdminDigestTemplate, mail.RecipientOf(admin), data, false); err != nil {
+		logger.Errorw("Failed to send report digest", "userID", adminID, "reports", len(reports), "error", err)
+		if err := s.digests.RestoreDigest(ctx, adminID, reports); err != nil {
+			logger.Errorw("Failed to restore digest", "userID", adminID, "error", err)
+		}
+		return false
+	}
+	return true
+}
+
+func (s *userService) reviewURL(disasterID string) string {
+	return fmt.Sprintf("%s/admin/review/%s", s.accountCfg.WebURL, disasterID)
+}
+
+// formatLocation formats coordinates for emails, to about 10 meters.
+func formatLocation(c types.Coordinates) string {
+	return fmt.Sprintf("%.4f, %.4f", c.Latitude, c.Longitude)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/cprakhar/relief-ops/shared/util"
+)
+
+// actionUnlockAccount is the account action authorized by the link emailed when an account gets locked.
+const actionUnlockAccount = "unlock_account"
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrTooManyAttempts    = errors.New("too many failed login attempts")
+	ErrAccountLocked      = errors.New("account is temporarily locked after too many failed login attempts")
+)
+
+// ThrottledError is returned for a login attempt refused because of earlier failed attempts.
+type ThrottledError struct {
+	Err        error         // ErrTooManyAttempts or ErrAccountLocked
+	RetryAfter time.Duration // how long until another attempt is considered
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%v, retry in %s", e.Err, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// LoginConfig configures the throttling of failed logins. Failures are counted per account and per client IP
+// address within a sliding window. After DelayAfter failures, each further attempt on the account must wait
+// a delay that doubles with every failure; after LockAfter failures, the account is locked and its owner is
+// emailed a link to unlock it.
+type LoginConfig struct {
+	Window          time.Duration // sliding window failures are counted in
+	DelayAfter      int           // account failures before attempts are delayed
+	BaseDelay       time.Duration // first delay, doubled with every further failure
+	MaxDelay        time.Duration // longest delay between attempts
+	LockAfter       int           // account failures that lock the account
+	LockoutDuration time.Duration // how long a locked account stays locked, also the unlock link lifetime
+	MaxIPFailures   int           // failures from one IP address before its attempts are refused
+}
+
+// dummyPasswordHash is checked against the password of logins to unknown addresses. It is a bcrypt hash at
+// the cost util.EncryptPassword uses, so the check takes as long as for a real account.
+const dummyPasswordHash = "$2a$10$oKtSVQOANEfSVLjV5kEMg.MLTZbqeXRsLupSoAhtoMpeN0Ig5y9Ia"
+
+// Login authenticates a user by email and password. Attempts are throttled after repeated failures,
+// returning a *ThrottledError without checking the password. Users with MFA enabled get an MFA challenge
+// instead of tokens, to be completed by VerifyMFALogin.
+func (s *userService) Login(ctx context.Context, email, password, clientIP string) (*types.User, *TokenPair, *MFAChallenge, error) {
+	account := strings.ToLower(strings.TrimSpace(email))
+	if err := s.checkLoginThrottle(ctx, account, clientIP); err != nil {
+		return nil, nil, nil, err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			// Unknown addresses take as long as wrong passwords and count and lock like real accounts, so
+			// neither response times nor lockouts reveal which accounts exist
+			util.ValidatePassword(dummyPasswordHash, password)
+			return nil, nil, nil, s.loginFailed(ctx, nil, account, clientIP)
+		}
+		return nil, nil, nil, err
+	}
+
+	if ok := util.ValidatePassword(user.Password, password); !ok {
+		return nil, nil, nil, s.loginFailed(ctx, user, account, clientIP)
+	}
+
+	if err := s.attempts.ResetFailures(ctx, repo.FailureScopeAccount, account); err != nil {
+		logs.L().Warnw("Failed to reset login failures", "email", account, "error", err)
+	}
+
+	if user.MFA.Enabled {
+		challenge, err := s.issueMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return user, nil, challenge, nil
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "", false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return user, tokens, nil, nil
+}
+
+// UnlockAccount lifts the lockout of the account a token from an unlock link was issued for.
+func (s *userService) UnlockAccount(ctx context.Context, token string) error {
+	userID, err := s.consumeActionToken(ctx, actionUnlockAccount, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	account := strings.ToLower(user.Email)
+	if err := s.attempts.Unlock(ctx, account); err != nil {
+		return err
+	}
+	return s.attempts.ResetFailures(ctx, repo.FailureScopeAccount, account)
+}
+
+// checkLoginThrottle refuses a login attempt if its client IP address failed too often, or its account
+// is locked or must wait before the next attempt.
+func (s *userService) checkLoginThrottle(ctx context.Context, account, clientIP string) error {
+	cfg := s.loginCfg
+
+	if clientIP != "" {
+		w, err := s.attempts.Failures(ctx, repo.FailureScopeIP, clientIP, cfg.Window)
+		if err != nil {
+			return err
+		}
+		if w.Count >= cfg.MaxIPFailures {
+			// The window slides, so attempts resume once the oldest failure leaves it
+			return &ThrottledError{Err: ErrTooManyAttempts, RetryAfter: time.Until(w.First.Add(cfg.Window))}
+		}
+	}
+
+	locked, err := s.attempts.LockedFor(ctx, account)
+	if err != nil {
+		return err
+	}
+	if locked > 0 {
+		return &ThrottledError{Err: ErrAccountLocked, RetryAfter: locked}
+	}
+
+	w, err := s.attempts.Failures(ctx, repo.FailureScopeAccount, account, cfg.Window)
+	if err != nil {
+		return err
+	}
+	if wait := time.Until(w.Last.Add(s.loginDelay(w.Count))); wait > 0 {
+		return &ThrottledError{Err: ErrTooManyAttempts, RetryAfter: wait}
+	}
+	return nil
+}
+
+// loginFailed records a failed login attempt, locking the account once it failed too often.
+// The user is nil if no account has the email address.
+func (s *userService) loginFailed(ctx context.Context, user *types.User, account, clientIP string) error {
+	cfg := s.loginCfg
+
+	if clientIP != "" {
+		if _, err := s.attempts.RecordFailure(ctx, repo.FailureScopeIP, clientIP, cfg.Window); err != nil {
+			return err
+		}
+	}
+
+	w, err := s.attempts.RecordFailure(ctx, repo.FailureScopeAccount, account, cfg.Window)
+	if err != nil {
+		return err
+	}
+	if w.Count < cfg.LockAfter {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.attempts.Lock(ctx, account, cfg.LockoutDuration); err != nil {
+		return err
+	}
+	// The lock takes over, so the account starts afresh once it is lifted
+	if err := s.attempts.ResetFailures(ctx, repo.FailureScopeAccount, account); err != nil {
+		logs.L().Warnw("Failed to reset login failures", "email", account, "error", err)
+	}
+
+	if user != nil {
+		s.sendUnlockEmail(ctx, user)
+	}
+	return &ThrottledError{Err: ErrAccountLocked, RetryAfter: cfg.LockoutDuration}
+}
+
+// sendUnlockEmail emails the owner of a locked account a link to unlock it. The account is already locked,
+// so failures are only logged.
+func (s *userService) sendUnlockEmail(ctx context.Context, user *types.User) {
+	expiry := s.loginCfg.LockoutDuration
+	token, err := s.issueActionToken(ctx, actionUnlockAccount, user.ID.Hex(), expiry)
+	if err != nil {
+		logs.L().Errorw("Failed to issue unlock token", "userID", user.ID.Hex(), "error", err)
+		return
+	}
+
+	data := struct {
+		Name      string
+		UnlockURL string
+		ResetURL  string
+		LockedFor string
+	}{
+		Name:      user.Name,
+		UnlockURL: fmt.Sprintf("%s/unlock-account?token=%s", s.accountCfg.WebURL, token),
+		ResetURL:  fmt.Sprintf("%s/forgot-password", s.accountCfg.WebURL),
+		LockedFor: humanDuration(expiry),
+	}
+
+	s.sendMail(mail.UnlockAccountTemplate, user, data)
+}
+
+// loginDelay returns how long an account must wait after its last failed attempt, given its failure count.
+func (s *userService) loginDelay(failures int) time.Duration {
+	cfg := s.loginCfg
+	if failures < cfg.DelayAfter || cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := cfg.BaseDelay
+	for i := cfg.DelayAfter; i < failures && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxDelay)
+}
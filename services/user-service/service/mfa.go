@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"github.com/cprakhar/relief-ops/shared/util"
+)
+
+const (
+	// actionMFALogin is the account action authorized by an MFA challenge token, completing a sign in.
+	actionMFALogin = "mfa_login"
+
+	mfaIssuer         = "Relief Ops" // shown by authenticator apps
+	mfaSkew           = 1            // time steps of clock drift tolerated either way
+	recoveryCodeCount = 10
+)
+
+var (
+	ErrMFANotEnabled        = errors.New("MFA is not enabled")
+	ErrMFAAlreadyEnabled    = errors.New("MFA is already enabled")
+	ErrMFANotStarted        = errors.New("no MFA enrollment in progress")
+	ErrInvalidMFACode       = errors.New("invalid MFA code")
+	ErrInvalidMFAChallenge  = errors.New("invalid or expired MFA challenge, please log in again")
+	errMFACodeAlreadyUsed   = fmt.Errorf("%w: code was already used", ErrInvalidMFACode)
+	errRecoveryCodeNotFound = fmt.Errorf("%w: unknown recovery code", ErrInvalidMFACode)
+)
+
+// MFAChallenge is returned by the first sign in step of users with MFA enabled, in place of tokens.
+type MFAChallenge struct {
+	Token     string // single-use token to pass to VerifyMFALogin along with a code
+	ExpiresAt time.Time
+}
+
+// MFAEnrollment is a started MFA enrollment, to be added to an authenticator app and confirmed with a code.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string // otpauth:// URI, usually shown as a QR code
+}
+
+// BeginMFAEnrollment generates a new TOTP secret for a user. MFA is only enabled once a code generated
+// from the secret is confirmed, so a secret that never made it into an authenticator app can't lock the user out.
+func (s *userService) BeginMFAEnrollment(ctx context.Context, userID string) (*MFAEnrollment, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFA.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetPendingMFASecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: util.TOTPProvisioningURI(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFAEnrollment enables MFA once the user proved their authenticator app generates valid codes,
+// and returns the recovery codes to store in a safe place.
+func (s *userService) ConfirmMFAEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFA.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFA.PendingSecret == "" {
+		return nil, ErrMFANotStarted
+	}
+
+	step, ok := util.ValidateTOTP(user.MFA.PendingSecret, code, time.Now(), mfaSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes := newRecoveryCodes()
+	if err := s.repo.EnableMFA(ctx, userID, user.MFA.PendingSecret, hashes, step); err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			// Enrollment was restarted meanwhile, so the code belongs to a discarded secret
+			return nil, ErrMFANotStarted
+		}
+		return nil, err
+	}
+
+	s.sendSecurityNotice(mail.MFAEnabledTemplate, user)
+	return codes, nil
+}
+
+// DisableMFA turns off MFA for a user, given a current or recovery code, and signs the user out everywhere
+// since existing sessions were verified with the second factor being removed.
+func (s *userService) DisableMFA(ctx context.Context, userID, code string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableMFA(ctx, userID); err != nil {
+		return err
+	}
+	if _, err := s.tokens.BumpGeneration(ctx, userID); err != nil {
+		return err
+	}
+
+	s.sendSecurityNotice(mail.MFADisabledTemplate, user)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, given a current or recovery code.
+func (s *userService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes := newRecoveryCodes()
+	if err := s.repo.SetRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyMFALogin completes the sign in of a user with MFA enabled, given the challenge from the first step
+// and a current or recovery code. Wrong codes don't use up the challenge, but count as failed logins.
+func (s *userService) VerifyMFALogin(ctx context.Context, challengeToken, code string) (*types.User, *TokenPair, error) {
+	claims, err := util.ParseActionToken(challengeToken, actionMFALogin, s.jwtCfg.Keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidMFAChallenge, err)
+	}
+
+	user, err := s.repo.GetByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		if errors.Is(err, ErrMFANotEnabled) {
+			return nil, nil, ErrInvalidMFAChallenge
+		}
+		return nil, nil, err
+	}
+
+	if _, err := s.consumeActionToken(ctx, actionMFALogin, challengeToken); err != nil {
+		if errors.Is(err, ErrInvalidActionToken) {
+			return nil, nil, ErrInvalidMFAChallenge
+		}
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "", true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// issueMFAChallenge creates the single-use token that stands in for a sign in until a code is verified.
+func (s *userService) issueMFAChallenge(ctx context.Context, user *types.User) (*MFAChallenge, error) {
+	expiry := s.accountCfg.MFAChallengeExpiry
+	token, err := s.issueActionToken(ctx, actionMFALogin, user.ID.Hex(), expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &MFAChallenge{Token: token, ExpiresAt: time.Now().Add(expiry)}, nil
+}
+
+// checkMFACode verifies a TOTP or recovery code of a user with MFA enabled. Codes are guessable, so wrong ones
+// are throttled along with failed logins of the account.
+func (s *userService) checkMFACode(ctx context.Context, user *types.User, code string) error {
+	if !user.MFA.Enabled {
+		return ErrMFANotEnabled
+	}
+
+	account := strings.ToLower(user.Email)
+	if err := s.checkLoginThrottle(ctx, account, ""); err != nil {
+		return err
+	}
+
+	err := s.verifyMFACode(ctx, user, code)
+	if !errors.Is(err, ErrInvalidMFACode) {
+		return err
+	}
+	if ferr := s.loginFailed(ctx, user, account, ""); !errors.Is(ferr, ErrInvalidCredentials) {
+		return ferr
+	}
+	return err
+}
+
+// verifyMFACode accepts a TOTP code once, or uses up a recovery code.
+func (s *userService) verifyMFACode(ctx context.Context, user *types.User, code string) error {
+	if step, ok := util.ValidateTOTP(user.MFA.Secret, code, time.Now(), mfaSkew); ok {
+		fresh, err := s.repo.UseMFAStep(ctx, user.ID.Hex(), step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return errMFACodeAlreadyUsed
+		}
+		return nil
+	}
+
+	found, err := s.repo.ConsumeRecoveryCode(ctx, user.ID.Hex(), hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errRecoveryCodeNotFound
+	}
+	return nil
+}
+
+// newRecoveryCodes generates recovery codes formatted as XXXXX-XXXXX, along with their hashes to store.
+func newRecoveryCodes() ([]string, []string) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw := rand.Text()[:10]
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(raw)
+	}
+	return codes, hashes
+}
+
+// normalizeRecoveryCode strips the formatting users may or may not type along with a recovery code.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+const (
+	maxPushTokens     = 10
+	maxWebhookURLLen  = 2048
+	defaultDeliveries = 20
+	maxDeliveries     = 100
+)
+
+var ErrInvalidNotificationSettings = errors.New("invalid notification settings")
+
+var (
+	phonePattern     = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	pushTokenPattern = regexp.MustCompile(`^Expo(nent)?PushToken\[[A-Za-z0-9_-]+\]$`)
+	localePattern    = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+)
+
+// GetNotificationSettings retrieves how a user wants to be notified. Users who never chose are notified by email.
+func (s *userService) GetNotificationSettings(ctx context.Context, userID string) (*types.NotificationSettings, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := user.Notifications
+	if len(settings.Channels) == 0 {
+		settings.Channels = []string{types.ChannelEmail}
+	}
+	if settings.ReportDelivery == "" {
+		settings.ReportDelivery = types.ReportDeliveryImmediate
+	}
+	return &settings, nil
+}
+
+// UpdateNotificationSettings replaces how a user wants to be notified. Every chosen channel must be configured
+// and have an address; addresses of other channels are kept so users can switch back.
+func (s *userService) UpdateNotificationSettings(ctx context.Context, userID string, settings *types.NotificationSettings) (*types.NotificationSettings, error) {
+	if err := s.normalizeNotificationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateNotificationSettings(ctx, userID, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// ListDeliveries retrieves the most recent notifications sent to a user, with their delivery status.
+func (s *userService) ListDeliveries(ctx context.Context, userID string, limit int) ([]*types.Delivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveries
+	}
+	return s.deliveries.ListDeliveries(ctx, userID, int64(min(limit, maxDeliveries)))
+}
+
+// normalizeNotificationSettings validates notification settings and drops duplicate channels and push tokens.
+func (s *userService) normalizeNotificationSettings(settings *types.NotificationSettings) error {
+	var channels []string
+	for _, c := range settings.Channels {
+		if !slices.Contains(types.NotificationChannels, c) {
+			return fmt.Errorf("%w: unknown channel %q", ErrInvalidNotificationSettings, c)
+		}
+		if !s.notifier.Available(c) {
+			return fmt.Errorf("%w: channel %q is not available", ErrInvalidNotificationSettings, c)
+		}
+		if !slices.Contains(channels, c) {
+			channels = append(channels, c)
+		}
+	}
+	if len(channels) == 0 {
+		channels = []string{types.ChannelEmail}
+	}
+	settings.Channels = channels
+
+	settings.Phone = strings.TrimSpace(settings.Phone)
+	if settings.Phone != "" && !phonePattern.MatchString(settings.Phone) {
+		return fmt.Errorf("%w: phone must be in international format, e.g., +919812345678", ErrInvalidNotificationSettings)
+	}
+
+	var tokens []string
+	for _, t := range settings.PushTokens {
+		t = strings.TrimSpace(t)
+		if !pushTokenPattern.MatchString(t) {
+			return fmt.Errorf("%w: invalid push token %q", ErrInvalidNotificationSettings, t)
+		}
+		if !slices.Contains(tokens, t) {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) > maxPushTokens {
+		return fmt.Errorf("%w: at most %d push tokens", ErrInvalidNotificationSettings, maxPushTokens)
+	}
+	settings.PushTokens = tokens
+
+	settings.WebhookURL = strings.TrimSpace(settings.WebhookURL)
+	if settings.WebhookURL != "" {
+		u, err := url.Parse(settings.WebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil || len(settings.WebhookURL) > maxWebhookURLLen {
+			return fmt.Errorf("%w: webhook URL must be an http or https URL", ErrInvalidNotificationSettings)
+		}
+	}
+
+	// Locales without translations fall back to English when rendered
+	settings.Locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(settings.Locale), "_", "-"))
+	if settings.Locale != "" && !localePattern.MatchString(settings.Locale) {
+		return fmt.Errorf("%w: locale must be a language tag, e.g., hi or pt-BR", ErrInvalidNotificationSettings)
+	}
+
+	switch settings.ReportDelivery {
+	case "":
+		settings.ReportDelivery = types.ReportDeliveryImmediate
+	case types.ReportDeliveryImmediate, types.ReportDeliveryDigest:
+	default:
+		return fmt.Errorf("%w: report delivery must be immediate or digest", ErrInvalidNotificationSettings)
+	}
+
+	for _, c := range settings.Channels {
+		switch {
+		case c == types.ChannelSMS && settings.Phone == "":
+			return fmt.Errorf("%w: sms needs a phone number", ErrInvalidNotificationSettings)
+		case c == types.ChannelPush && len(settings.PushTokens) == 0:
+			return fmt.Errorf("%w: push needs a push token", ErrInvalidNotificationSettings)
+		case c == types.ChannelWebhook && settings.WebhookURL == "":
+			return fmt.Errorf("%w: webhook needs a webhook URL", ErrInvalidNotificationSettings)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const maxOrgNameLength = 100
+
+var (
+	ErrInvalidOrganization = errors.New("invalid organization")
+	ErrInvalidOrgRole      = errors.New("invalid organization role")
+	ErrOrgForbidden        = errors.New("not allowed to manage this organization")
+	ErrNotOrgMember        = errors.New("not a member of this organization")
+	ErrAlreadyOrgMember    = errors.New("already a member of this organization")
+	ErrLastOrgAdmin        = errors.New("cannot remove the last org admin")
+	ErrInvalidOrgInvite    = errors.New("invalid or expired organization invite")
+)
+
+// OrgMembership is an organization a user belongs to, along with the user's membership.
+type OrgMembership struct {
+	Org        *types.Organization
+	Membership *types.Membership
+}
+
+// CreateOrganization creates an organization with the acting user as its first org admin.
+func (s *userService) CreateOrganization(ctx context.Context, actorID, name, description string) (*types.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxOrgNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidOrganization, maxOrgNameLength)
+	}
+
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	org := &types.Organization{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		CreatedBy:   actorID,
+	}
+	if err := s.orgs.CreateOrg(ctx, org); err != nil {
+		return nil, err
+	}
+
+	membership := &types.Membership{OrgID: org.ID, UserID: actor.ID, Role: types.OrgRoleAdmin}
+	if err := s.orgs.AddMember(ctx, membership); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetOrganization retrieves an organization by its ID.
+func (s *userService) GetOrganization(ctx context.Context, id string) (*types.Organization, error) {
+	return s.orgs.GetOrg(ctx, id)
+}
+
+// ListOrganizations retrieves the organizations a user belongs to.
+func (s *userService) ListOrganizations(ctx context.Context, userID string) ([]*OrgMembership, error) {
+	memberships, err := s.orgs.ListMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*OrgMembership, 0, len(memberships))
+	for _, m := range memberships {
+		org, err := s.orgs.GetOrg(ctx, m.OrgID.Hex())
+		if err != nil {
+			if errors.Is(err, repo.ErrNoResourcesFound) {
+				continue
+			}
+			return nil, err
+		}
+		orgs = append(orgs, &OrgMembership{Org: org, Membership: m})
+	}
+	return orgs, nil
+}
+
+// GetOrgMembership retrieves the membership of a user in an organization, e.g., to check that a report
+// attributed to the organization was filed by one of its members.
+func (s *userService) GetOrgMembership(ctx context.Context, orgID, userID string) (*types.Membership, error) {
+	m, err := s.orgs.GetMember(ctx, orgID, userID)
+	if errors.Is(err, repo.ErrNoResourcesFound) {
+		return nil, ErrNotOrgMember
+	}
+	return m, err
+}
+
+// ListOrgMembers retrieves the members of an organization, optionally only those of a team, for its members.
+func (s *userService) ListOrgMembers(ctx context.Context, actorID, orgID, teamID string) ([]*repo.OrgMember, error) {
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.orgs.ListMembers(ctx, orgID, teamID)
+}
+
+// InviteOrgMember emails an invite to join an organization with a role, on behalf of an org admin.
+// Only the code's hash is stored, so the returned code can't be retrieved again.
+func (s *userService) InviteOrgMember(ctx context.Context, actorID, orgID, email string, role types.OrgRole) (string, *types.OrgInvite, error) {
+	if !role.Valid() {
+		return "", nil, ErrInvalidOrgRole
+	}
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+		return "", nil, err
+	}
+
+	org, err := s.orgs.GetOrg(ctx, orgID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	code := rand.Text()
+	invite := &types.OrgInvite{
+		OrgID:     org.ID,
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Role:      role,
+		CodeHash:  hashToken(code),
+		InvitedBy: actorID,
+		ExpiresAt: time.Now().Add(s.accountCfg.InviteExpiry),
+	}
+	if err := s.orgs.CreateInvite(ctx, invite); err != nil {
+		return "", nil, err
+	}
+
+	s.sendOrgInviteEmail(ctx, org, invite, code)
+	return code, invite, nil
+}
+
+// AcceptOrgInvite adds a user to the organization an invite is for. Invites are bound to the invited email
+// address, so a forwarded invite can't be accepted by someone else.
+func (s *userService) AcceptOrgInvite(ctx context.Context, userID, code string) (*types.Membership, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := s.orgs.ClaimInvite(ctx, hashToken(code), strings.ToLower(user.Email), userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil, ErrInvalidOrgInvite
+		}
+		return nil, err
+	}
+
+	membership := &types.Membership{
+		OrgID:     invite.OrgID,
+		UserID:    user.ID,
+		Role:      invite.Role,
+		InvitedBy: invite.InvitedBy,
+	}
+	if err := s.orgs.AddMember(ctx, membership); err != nil {
+		// Leave the invite usable, e.g., for a retry after a transient failure
+		if rerr := s.orgs.ReleaseInvite(ctx, invite.ID); rerr != nil {
+			logs.L().Errorw("Failed to release organization invite", "inviteID", invite.ID.Hex(), "error", rerr)
+		}
+		if errors.Is(err, repo.ErrResourceConflict) {
+			return nil, ErrAlreadyOrgMember
+		}
+		return nil, err
+	}
+	return membership, nil
+}
+
+// SetOrgMemberRole changes the organization role of a member on behalf of an org admin.
+func (s *userService) SetOrgMemberRole(ctx context.Context, actorID, orgID, userID string, role types.OrgRole) (*types.Membership, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidOrgRole
+	}
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	m, err := s.GetOrgMembership(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Role == types.OrgRoleAdmin && role != types.OrgRoleAdmin {
+		if err := s.keepOrgAdmin(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orgs.UpdateMemberRole(ctx, orgID, userID, role); err != nil {
+		return nil, err
+	}
+	m.Role = role
+	return m, nil
+}
+
+// RemoveOrgMember removes a member from an organization on behalf of an org admin, or the member leaving.
+func (s *userService) RemoveOrgMember(ctx context.Context, actorID, orgID, userID string) error {
+	if actorID != userID {
+		if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleAdmin); err != nil {
+			return err
+		}
+	}
+
+	m, err := s.GetOrgMembership(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if m.Role == types.OrgRoleAdmin {
+		if err := s.keepOrgAdmin(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	return s.orgs.RemoveMember(ctx, orgID, userID)
+}
+
+// CreateTeam creates a team within an organization on behalf of a coordinator or org admin.
+func (s *userService) CreateTeam(ctx context.Context, actorID, orgID, name, description string) (*types.Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxOrgNameLength {
+		return nil, fmt.Errorf("%w: team name must be 1 to %d characters", ErrInvalidOrganization, maxOrgNameLength)
+	}
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleCoordinator); err != nil {
+		return nil, err
+	}
+
+	oid, err := bson.ObjectIDFromHex(orgID)
+	if err != nil {
+		return nil, repo.ErrNoResourcesFound
+	}
+
+	team := &types.Team{
+		OrgID:       oid,
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		CreatedBy:   actorID,
+	}
+	if err := s.orgs.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// ListTeams retrieves the teams of an organization, for its members.
+func (s *userService) ListTeams(ctx context.Context, actorID, orgID string) ([]*types.Team, error) {
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.orgs.ListTeams(ctx, orgID)
+}
+
+// GetTeam retrieves a team of an organization.
+func (s *userService) GetTeam(ctx context.Context, orgID, teamID string) (*types.Team, error) {
+	return s.orgs.GetTeam(ctx, orgID, teamID)
+}
+
+// AddTeamMember adds a member of an organization to one of its teams, on behalf of a coordinator or org admin.
+func (s *userService) AddTeamMember(ctx context.Context, actorID, orgID, teamID, userID string) (*types.Membership, error) {
+	return s.updateTeamMember(ctx, actorID, orgID, teamID, userID, s.orgs.AddToTeam)
+}
+
+// RemoveTeamMember removes a member from a team, on behalf of a coordinator or org admin.
+func (s *userService) RemoveTeamMember(ctx context.Context, actorID, orgID, teamID, userID string) (*types.Membership, error) {
+	return s.updateTeamMember(ctx, actorID, orgID, teamID, userID, s.orgs.RemoveFromTeam)
+}
+
+func (s *userService) updateTeamMember(ctx context.Context, actorID, orgID, teamID, userID string,
+	update func(ctx context.Context, orgID, userID, teamID string) error) (*types.Membership, error) {
+	if err := s.requireOrgRole(ctx, orgID, actorID, types.OrgRoleCoordinator); err != nil {
+		return nil, err
+	}
+	if _, err := s.orgs.GetTeam(ctx, orgID, teamID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetOrgMembership(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := update(ctx, orgID, userID, teamID); err != nil {
+		return nil, err
+	}
+	return s.orgs.GetMember(ctx, orgID, userID)
+}
+
+// requireOrgRole checks that the acting user has at least a role in an organization. Platform admins not scoped
+// to regions may act in every organization.
+func (s *userService) requireOrgRole(ctx context.Context, orgID, actorID string, min types.OrgRole) error {
+	if _, err := s.orgs.GetOrg(ctx, orgID); err != nil {
+		return err
+	}
+
+	m, err := s.orgs.GetMember(ctx, orgID, actorID)
+	if err != nil && !errors.Is(err, repo.ErrNoResourcesFound) {
+		return err
+	}
+	if m != nil && m.Role.Rank() >= min.Rank() {
+		return nil
+	}
+
+	if perr := s.requirePermission(ctx, actorID, authz.ManageUsers); !errors.Is(perr, ErrForbidden) {
+		return perr
+	}
+	if m == nil {
+		return ErrNotOrgMember
+	}
+	return ErrOrgForbidden
+}
+
+// keepOrgAdmin refuses to remove an org admin if they are the organization's last one.
+func (s *userService) keepOrgAdmin(ctx context.Context, orgID string) error {
+	admins, err := s.orgs.CountMembers(ctx, orgID, types.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastOrgAdmin
+	}
+	return nil
+}
+
+// sendOrgInviteEmail emails an organization invite. The code is also returned to the inviting admin,
+// so failures are only logged.
+func (s *userService) sendOrgInviteEmail(ctx context.Context, org *types.Organization, invite *types.OrgInvite, code string) {
+	recipient := &types.User{Email: invite.Email}
+	if user, err := s.repo.GetByEmail(ctx, invite.Email); err == nil {
+		recipient = user
+	}
+
+	data := struct {
+		Name      string
+		OrgName   string
+		Role      string
+		JoinURL   string
+		ExpiresIn string
+	}{
+		Name:      recipient.Name,
+		OrgName:   org.Name,
+		Role:      strings.ReplaceAll(string(invite.Role), "_", " "),
+		JoinURL:   fmt.Sprintf("%s/orgs/join?code=%s", s.accountCfg.WebURL, code),
+		ExpiresIn: humanDuration(s.accountCfg.InviteExpiry),
+	}
+
+	s.sendMail(mail.OrgInviteTemplate, recipient, data)
+}
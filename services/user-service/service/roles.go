@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+var (
+	ErrInvalidRole   = errors.New("invalid role")
+	ErrInvalidInvite = errors.New("invalid or expired invite code")
+	ErrForbidden     = errors.New("not allowed to manage roles")
+	ErrRoleUnchanged = errors.New("user already has this role and regions")
+	ErrLastAdmin     = errors.New("cannot remove the last admin not scoped to regions")
+)
+
+// SetUserRole changes the role of a user on behalf of an admin and records the change in the audit log.
+// Regions scope the role's permissions to geographic areas; empty grants them everywhere.
+func (s *userService) SetUserRole(ctx context.Context, actorID, userID string, role types.Role, regions []types.Region, reason string) (*types.RoleChange, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+	if err := authz.ValidateRegions(regions); err != nil {
+		return nil, err
+	}
+	if len(regions) > 0 && len(authz.DefaultPolicy[role]) == 0 {
+		return nil, fmt.Errorf("%w: role %s has no permissions to scope", authz.ErrInvalidRegion, role)
+	}
+	if err := s.requirePermission(ctx, actorID, authz.ManageUsers); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	regionsChanged := !reflect.DeepEqual(user.Regions, regions) && (len(user.Regions) > 0 || len(regions) > 0)
+	if user.Role == role && !regionsChanged {
+		return nil, ErrRoleUnchanged
+	}
+
+	// Only admins not scoped to regions can manage users, so keep at least one of them
+	if authz.DefaultPolicy.Allowed(principalOf(user), authz.ManageUsers) {
+		admins, err := s.repo.GetAllByRole(ctx, user.Role)
+		if err != nil {
+			return nil, err
+		}
+		managers := 0
+		for _, a := range admins {
+			if authz.DefaultPolicy.Allowed(principalOf(a), authz.ManageUsers) {
+				managers++
+			}
+		}
+		if managers <= 1 && !authz.DefaultPolicy.Allowed(&authz.Principal{Role: role, Regions: regions}, authz.ManageUsers) {
+			return nil, ErrLastAdmin
+		}
+	}
+
+	if err := s.repo.UpdateRole(ctx, userID, role, regions); err != nil {
+		return nil, err
+	}
+
+	change := &types.RoleChange{
+		UserID:    userID,
+		OldRole:   user.Role,
+		NewRole:   role,
+		ChangedBy: actorID,
+		Source:    types.RoleChangeAdmin,
+		Reason:    reason,
+		Regions:   authz.RegionNames(regions),
+	}
+	if err := s.roles.RecordRoleChange(ctx, change); err != nil {
+		return nil, err
+	}
+
+	// Tokens carry the role and its regions, so sign out a user whose permissions may have shrunk
+	// rather than wait for the next token refresh
+	if role.Rank() < user.Role.Rank() || regionsChanged {
+		if _, err := s.tokens.BumpGeneration(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	data := struct {
+		Name    string
+		Role    string
+		Regions string
+	}{
+		Name:    user.Name,
+		Role:    strings.ReplaceAll(string(role), "_", " "),
+		Regions: strings.Join(change.Regions, ", "),
+	}
+	s.sendMail(mail.RoleChangedTemplate, user, data)
+
+	return change, nil
+}
+
+// CreateInvite generates an invite code granting a role at signup. Only the code's hash is stored,
+// so the returned code can't be retrieved again.
+func (s *userService) CreateInvite(ctx context.Context, actorID string, role types.Role) (string, *types.Invite, error) {
+	if !role.Valid() {
+		return "", nil, ErrInvalidRole
+	}
+	if err := s.requirePermission(ctx, actorID, authz.ManageUsers); err != nil {
+		return "", nil, err
+	}
+
+	code := rand.Text()
+	invite := &types.Invite{
+		CodeHash:  hashToken(code),
+		Role:      role,
+		CreatedBy: actorID,
+		ExpiresAt: time.Now().Add(s.accountCfg.InviteExpiry),
+	}
+
+	if _, err := s.roles.CreateInvite(ctx, invite); err != nil {
+		return "", nil, err
+	}
+	return code, invite, nil
+}
+
+// ListRoleChanges retrieves the role change audit log of a user, most recent first, on behalf of an admin
+// allowed to manage users everywhere.
+func (s *userService) ListRoleChanges(ctx context.Context, actorID, userID string) ([]*types.RoleChange, error) {
+	if err := s.requirePermission(ctx, actorID, authz.ManageUsers); err != nil {
+		return nil, err
+	}
+	return s.roles.ListRoleChanges(ctx, userID)
+}
+
+// recordInviteRedeemed completes a claimed invite and records the granted role in the audit log.
+// The user already exists at this point, so failures are only logged.
+func (s *userService) recordInviteRedeemed(ctx context.Context, userID string, invite *types.Invite) {
+	logger := logs.L()
+
+	if err := s.roles.CompleteInvite(ctx, invite.ID.Hex(), userID); err != nil {
+		logger.Errorw("Failed to complete invite", "inviteID", invite.ID.Hex(), "userID", userID, "error", err)
+	}
+
+	change := &types.RoleChange{
+		UserID:    userID,
+		NewRole:   invite.Role,
+		ChangedBy: invite.CreatedBy,
+		Source:    types.RoleChangeInvite,
+		InviteID:  invite.ID.Hex(),
+	}
+	if err := s.roles.RecordRoleChange(ctx, change); err != nil {
+		logger.Errorw("Failed to record role change", "inviteID", invite.ID.Hex(), "userID", userID, "error", err)
+	}
+}
+
+// requirePermission checks that the acting user has a permission that is not tied to a location,
+// by the role stored rather than the one claimed by the caller.
+func (s *userService) requirePermission(ctx context.Context, actorID string, perm authz.Permission) error {
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !authz.DefaultPolicy.Allowed(principalOf(actor), perm) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// principalOf returns the authorization principal of a user.
+func principalOf(user *types.User) *authz.Principal {
+	return &authz.Principal{UserID: user.ID.Hex(), Role: user.Role, Regions: user.Regions}
+}
@@ -2,46 +2,184 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/cprakhar/relief-ops/services/user-service/mail"
+	"github.com/cprakhar/relief-ops/services/user-service/notify"
 	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/observe/logs"
 	"github.com/cprakhar/relief-ops/shared/types"
 	"github.com/cprakhar/relief-ops/shared/util"
 )
 
-const (
-	AdminRole     = "admin"
-	UserRole      = "user"
-	VolunteerRole = "volunteer"
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenRevoked = errors.New("token has been revoked")
+	ErrTokenReused  = errors.New("refresh token reuse detected")
 )
 
 type JwtConfig struct {
-	Secret string
-	Expiry time.Duration
+	Keys          *util.KeyRing
+	Expiry        time.Duration // access token lifetime
+	RefreshExpiry time.Duration // refresh token lifetime
+}
+
+// TokenPair is a short-lived access token along with the refresh token used to renew it.
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
 }
 
 type userService struct {
-	repo   repo.UserRepo
-	jwtCfg *JwtConfig
+	repo          repo.UserRepo
+	tokens        repo.TokenRepo
+	roles         repo.RoleRepo
+	attempts      repo.LoginAttemptRepo
+	profiles      repo.ProfileRepo
+	orgs          repo.OrgRepo
+	apiKeys       repo.APIKeyRepo
+	subscriptions repo.SubscriptionRepo
+	alertLimits   repo.AlertLimitRepo
+	deliveries    repo.DeliveryRepo
+	digests       repo.DigestRepo
+	mailer        mail.Client
+	notifier      *notify.Notifier
+	jwtCfg        *JwtConfig
+	accountCfg    *AccountConfig
+	loginCfg      *LoginConfig
+	alertCfg      *AlertConfig
+	digestCfg     *DigestConfig
 }
 
 // UserService defines the interface for user service operations.
 type UserService interface {
-	CreateUser(ctx context.Context, user *types.User) (string, error)
+	CreateUser(ctx context.Context, user *types.User, inviteCode string) (string, error)
 	GetUserByEmail(ctx context.Context, email string) (*types.User, error)
-	Login(ctx context.Context, email, password string) (*types.User, string, error)
-	OAuthSignIn(ctx context.Context, user *types.User) (string, error)
+	Login(ctx context.Context, email, password, clientIP string) (*types.User, *TokenPair, *MFAChallenge, error)
+	OAuthSignIn(ctx context.Context, user *types.User) (*TokenPair, *MFAChallenge, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*types.User, *TokenPair, error)
 	GetUserByID(ctx context.Context, id string) (*types.User, error)
 	GetAdmins(ctx context.Context) ([]*types.User, error)
+	ValidateToken(ctx context.Context, token string) (*util.Claims, error)
+	IsTokenRevoked(ctx context.Context, jti, userID string, generation int64) (bool, error)
+	JSONWebKeys() (*util.JSONWebKeySet, error)
+	RevokeToken(ctx context.Context, token, refreshToken string, allSessions bool) error
+	RequestEmailVerification(ctx context.Context, userID string) error
+	ConfirmEmailVerification(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	UnlockAccount(ctx context.Context, token string) error
+	SetUserRole(ctx context.Context, actorID, userID string, role types.Role, regions []types.Region, reason string) (*types.RoleChange, error)
+	CreateInvite(ctx context.Context, actorID string, role types.Role) (string, *types.Invite, error)
+	ListRoleChanges(ctx context.Context, actorID, userID string) ([]*types.RoleChange, error)
+	BeginMFAEnrollment(ctx context.Context, userID string) (*MFAEnrollment, error)
+	ConfirmMFAEnrollment(ctx context.Context, userID, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	VerifyMFALogin(ctx context.Context, challengeToken, code string) (*types.User, *TokenPair, error)
+	GetVolunteerProfile(ctx context.Context, actorID, userID string) (*types.VolunteerProfile, error)
+	UpdateVolunteerProfile(ctx context.Context, userID string, profile *types.VolunteerProfile) (*types.VolunteerProfile, error)
+	FindVolunteers(ctx context.Context, actorID string, q *repo.VolunteerQuery, availableAt time.Time) ([]*repo.VolunteerMatch, error)
+	CreateOrganization(ctx context.Context, actorID, name, description string) (*types.Organization, error)
+	GetOrganization(ctx context.Context, id string) (*types.Organization, error)
+	ListOrganizations(ctx context.Context, userID string) ([]*OrgMembership, error)
+	GetOrgMembership(ctx context.Context, orgID, userID string) (*types.Membership, error)
+	ListOrgMembers(ctx context.Context, actorID, orgID, teamID string) ([]*repo.OrgMember, error)
+	InviteOrgMember(ctx context.Context, actorID, orgID, email string, role types.OrgRole) (string, *types.OrgInvite, error)
+	AcceptOrgInvite(ctx context.Context, userID, code string) (*types.Membership, error)
+	SetOrgMemberRole(ctx context.Context, actorID, orgID, userID string, role types.OrgRole) (*types.Membership, error)
+	RemoveOrgMember(ctx context.Context, actorID, orgID, userID string) error
+	CreateTeam(ctx context.Context, actorID, orgID, name, description string) (*types.Team, error)
+	ListTeams(ctx context.Context, actorID, orgID string) ([]*types.Team, error)
+	GetTeam(ctx context.Context, orgID, teamID string) (*types.Team, error)
+	AddTeamMember(ctx context.Context, actorID, orgID, teamID, userID string) (*types.Membership, error)
+	RemoveTeamMember(ctx context.Context, actorID, orgID, teamID, userID string) (*types.Membership, error)
+	CreateAPIKey(ctx context.Context, actorID, orgID, name string, perms []authz.Permission, rateLimit int, expiresAt *time.Time) (string, *types.APIKey, error)
+	ListAPIKeys(ctx context.Context, actorID, orgID string) ([]*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, actorID, orgID, keyID string) (*types.APIKey, error)
+	VerifyAPIKey(ctx context.Context, key string) (*types.APIKey, *types.User, error)
+	CreateAlertSubscription(ctx context.Context, userID string, sub *types.AlertSubscription) (*types.AlertSubscription, error)
+	ListAlertSubscriptions(ctx context.Context, userID string) ([]*types.AlertSubscription, error)
+	DeleteAlertSubscription(ctx context.Context, userID, id string) error
+	AlertSubscribers(ctx context.Context, alert *DisasterAlert) (int, error)
+	GetNotificationSettings(ctx context.Context, userID string) (*types.NotificationSettings, error)
+	UpdateNotificationSettings(ctx context.Context, userID string, settings *types.NotificationSettings) (*types.NotificationSettings, error)
+	ListDeliveries(ctx context.Context, userID string, limit int) ([]*types.Delivery, error)
+	NotifyAdmins(ctx context.Context, report *DisasterReport) error
+	RunDigests(ctx context.Context) error
+}
+
+// Deps holds the repositories, clients and configuration the user service is built from.
+type Deps struct {
+	Users         repo.UserRepo
+	Tokens        repo.TokenRepo
+	Roles         repo.RoleRepo
+	Attempts      repo.LoginAttemptRepo
+	Profiles      repo.ProfileRepo
+	Orgs          repo.OrgRepo
+	APIKeys       repo.APIKeyRepo
+	Subscriptions repo.SubscriptionRepo
+	AlertLimits   repo.AlertLimitRepo
+	Deliveries    repo.DeliveryRepo
+	Digests       repo.DigestRepo
+	Mailer        mail.Client
+	Notifier      *notify.Notifier
+	JwtCfg        *JwtConfig
+	AccountCfg    *AccountConfig
+	LoginCfg      *LoginConfig
+	AlertCfg      *AlertConfig
+	DigestCfg     *DigestConfig
 }
 
 // NewUserService creates a new instance of userService.
-func NewUserService(r repo.UserRepo, secret string, expiry time.Duration) UserService {
-	return &userService{repo: r, jwtCfg: &JwtConfig{Secret: secret, Expiry: expiry}}
+func NewUserService(deps Deps) UserService {
+	return &userService{
+		repo:          deps.Users,
+		tokens:        deps.Tokens,
+		roles:         deps.Roles,
+		attempts:      deps.Attempts,
+		profiles:      deps.Profiles,
+		orgs:          deps.Orgs,
+		apiKeys:       deps.APIKeys,
+		subscriptions: deps.Subscriptions,
+		alertLimits:   deps.AlertLimits,
+		deliveries:    deps.Deliveries,
+		digests:       deps.Digests,
+		mailer:        deps.Mailer,
+		notifier:      deps.Notifier,
+		jwtCfg:        deps.JwtCfg,
+		accountCfg:    deps.AccountCfg,
+		loginCfg:      deps.LoginCfg,
+		alertCfg:      deps.AlertCfg,
+		digestCfg:     deps.DigestCfg,
+	}
 }
 
-// CreateUser creates a new user entry.
-func (s *userService) CreateUser(ctx context.Context, user *types.User) (string, error) {
+// CreateUser creates a new user entry with the default role, or the role granted by an invite code.
+func (s *userService) CreateUser(ctx context.Context, user *types.User, inviteCode string) (string, error) {
+	user.Role = types.DefaultRole
+
+	var invite *types.Invite
+	if inviteCode != "" {
+		var err error
+		invite, err = s.roles.ClaimInvite(ctx, hashToken(inviteCode), user.Email)
+		if err != nil {
+			if errors.Is(err, repo.ErrNoResourcesFound) {
+				return "", ErrInvalidInvite
+			}
+			return "", err
+		}
+		user.Role = invite.Role
+	}
+
 	// Hash the password before storing
 	hashedPassword, err := util.EncryptPassword(user.Password)
 	if err != nil {
@@ -49,61 +187,243 @@ func (s *userService) CreateUser(ctx context.Context, user *types.User) (string,
 	}
 	user.Password = hashedPassword
 
-	return s.repo.Create(ctx, user)
+	id, err := s.repo.Create(ctx, user)
+	if err != nil {
+		if invite != nil {
+			if err := s.roles.ReleaseInvite(ctx, invite.ID.Hex()); err != nil {
+				logs.L().Warnw("Failed to release invite", "inviteID", invite.ID.Hex(), "error", err)
+			}
+		}
+		return "", err
+	}
+
+	if invite != nil {
+		s.recordInviteRedeemed(ctx, id, invite)
+	}
+
+	// Users signing in through an OAuth provider come with a verified email address
+	if !user.EmailVerified {
+		if err := s.RequestEmailVerification(ctx, id); err != nil {
+			logs.L().Warnw("Failed to send email verification", "userID", id, "error", err)
+		}
+	}
+	return id, nil
 }
 
 // GetAdmins retrieves all users with the admin role.
 func (s *userService) GetAdmins(ctx context.Context) ([]*types.User, error) {
-	return s.repo.GetAllByRole(ctx, AdminRole)
+	return s.repo.GetAllByRole(ctx, types.RoleAdmin)
+}
+
+// GetUserByID retrieves a user by their ID.
+func (s *userService) GetUserByID(ctx context.Context, id string) (*types.User, error) {
+	return s.repo.GetByID(ctx, id)
 }
 
-// Login authenticates a user and returns a JWT token upon successful authentication.
-func (s *userService) Login(ctx context.Context, email, password string) (*types.User, string, error) {
-	user, err := s.repo.GetByEmail(ctx, email)
+// OAuthSignIn handles user sign-in via OAuth providers. Users with MFA enabled get an MFA challenge instead of tokens.
+func (s *userService) OAuthSignIn(ctx context.Context, user *types.User) (*TokenPair, *MFAChallenge, error) {
+	if user.MFA.Enabled {
+		challenge, err := s.issueMFAChallenge(ctx, user)
+		return nil, challenge, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "", false)
+	return tokens, nil, err
+}
+
+// GetUserByEmail retrieves a user by their email.
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (*types.User, error) {
+	return s.repo.GetByEmail(ctx, email)
+}
+
+// ValidateToken parses a JWT token and checks it was neither revoked nor issued before the user
+// logged out everywhere.
+func (s *userService) ValidateToken(ctx context.Context, token string) (*util.Claims, error) {
+	claims, err := util.ParseToken(token, s.jwtCfg.Keys)
 	if err != nil {
-		return nil, "", err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
-	if ok := util.ValidatePassword(user.Password, password); !ok {
-		return nil, "", err
+	revoked, err := s.IsTokenRevoked(ctx, claims.ID, claims.UserID, claims.Generation)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
 	}
 
-	userDetails := &util.UserDetails{
-		UserID: user.ID.Hex(),
-		Email:  user.Email,
-		Role:   user.Role,
+	return claims, nil
+}
+
+// IsTokenRevoked reports whether a token, identified by its jti, was revoked or issued at a token
+// generation before the user last logged out everywhere. It lets token holders that verify signatures
+// themselves check revocation.
+func (s *userService) IsTokenRevoked(ctx context.Context, jti, userID string, generation int64) (bool, error) {
+	revoked, err := s.tokens.IsRevoked(ctx, jti)
+	if err != nil || revoked {
+		return revoked, err
 	}
 
-	token, err := util.GenerateToken(userDetails, s.jwtCfg.Secret, s.jwtCfg.Expiry)
+	gen, err := s.tokens.Generation(ctx, userID)
 	if err != nil {
-		return nil, "", err
+		return false, err
 	}
+	return generation < gen, nil
+}
 
-	return user, token, nil
+// JSONWebKeys returns the public keys tokens are verified with.
+func (s *userService) JSONWebKeys() (*util.JSONWebKeySet, error) {
+	return s.jwtCfg.Keys.JSONWebKeys()
 }
 
-// GetUserByID retrieves a user by their ID.
-func (s *userService) GetUserByID(ctx context.Context, id string) (*types.User, error) {
-	return s.repo.GetByID(ctx, id)
+// RefreshToken exchanges a refresh token for a new token pair. Refresh tokens are single use: presenting
+// one again means it leaked, so its whole family is revoked.
+func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*types.User, *TokenPair, error) {
+	hash := hashToken(refreshToken)
+
+	stored, err := s.tokens.GetRefreshToken(ctx, hash)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResourcesFound) {
+			return nil, nil, ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	revoked, err := s.tokens.IsFamilyRevoked(ctx, stored.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, ErrTokenRevoked
+	}
+
+	first, err := s.tokens.MarkRefreshTokenUsed(ctx, hash, stored.ExpiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !first {
+		if err := s.tokens.RevokeFamily(ctx, stored.FamilyID, time.Now().Add(s.jwtCfg.RefreshExpiry)); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrTokenReused
+	}
+
+	gen, err := s.tokens.Generation(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if stored.Generation < gen {
+		return nil, nil, ErrTokenRevoked
+	}
+
+	user, err := s.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A second factor verified at sign in lasts for the family, unless MFA was turned off since
+	tokens, err := s.issueTokens(ctx, user, stored.FamilyID, stored.MFA && user.MFA.Enabled)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// RevokeToken revokes an access token until it expires and the family of a refresh token; either may be empty.
+// With allSessions, every token issued to the user so far is revoked as well.
+func (s *userService) RevokeToken(ctx context.Context, token, refreshToken string, allSessions bool) error {
+	var userID string
+
+	if refreshToken != "" {
+		stored, err := s.tokens.GetRefreshToken(ctx, hashToken(refreshToken))
+		switch {
+		case err == nil:
+			userID = stored.UserID
+			if err := s.tokens.RevokeFamily(ctx, stored.FamilyID, time.Now().Add(s.jwtCfg.RefreshExpiry)); err != nil {
+				return err
+			}
+		case !errors.Is(err, repo.ErrNoResourcesFound):
+			return err
+		}
+	}
+
+	if token != "" {
+		claims, err := s.ValidateToken(ctx, token)
+		switch {
+		case err == nil:
+			userID = claims.UserID
+			if err := s.tokens.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+				return err
+			}
+		case userID != "" && (errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenRevoked)):
+			// The access token already lapsed; revoking the refresh token is enough
+		default:
+			return err
+		}
+	}
+
+	if userID == "" {
+		return ErrInvalidToken
+	}
+
+	if allSessions {
+		if _, err := s.tokens.BumpGeneration(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// OAuthSignIn handles user sign-in via OAuth providers.
-func (s *userService) OAuthSignIn(ctx context.Context, user *types.User) (string, error) {
-	uDetails := &util.UserDetails{
-		UserID: user.ID.Hex(),
-		Email:  user.Email,
-		Role:   user.Role,
+// issueTokens generates an access token and a refresh token for a user, bound to the user's current token
+// generation. The refresh token joins the given family, or starts a new one if empty. mfa records whether the
+// sign in was verified with a second factor.
+func (s *userService) issueTokens(ctx context.Context, user *types.User, familyID string, mfa bool) (*TokenPair, error) {
+	gen, err := s.tokens.Generation(ctx, user.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	userDetails := &util.UserDetails{
+		UserID:        user.ID.Hex(),
+		Email:         user.Email,
+		Role:          string(user.Role),
+		Generation:    gen,
+		EmailVerified: user.EmailVerified,
+		Regions:       user.Regions,
+		MFA:           mfa,
 	}
 
-	token, err := util.GenerateToken(uDetails, s.jwtCfg.Secret, s.jwtCfg.Expiry)
+	now := time.Now()
+	accessToken, err := util.GenerateToken(userDetails, s.jwtCfg.Keys, s.jwtCfg.Expiry)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if familyID == "" {
+		familyID = rand.Text()
+	}
+	refreshToken := rand.Text()
+	stored := &repo.RefreshToken{
+		UserID:     user.ID.Hex(),
+		FamilyID:   familyID,
+		Generation: gen,
+		MFA:        mfa,
+		ExpiresAt:  now.Add(s.jwtCfg.RefreshExpiry),
+	}
+	if err := s.tokens.SaveRefreshToken(ctx, hashToken(refreshToken), stored); err != nil {
+		return nil, err
 	}
 
-	return token, nil
+	return &TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  now.Add(s.jwtCfg.Expiry),
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
 }
 
-// GetUserByEmail retrieves a user by their email.
-func (s *userService) GetUserByEmail(ctx context.Context, email string) (*types.User, error) {
-	return s.repo.GetByEmail(ctx, email)
+// hashToken hashes an opaque token for storage, so a leaked store does not leak usable tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
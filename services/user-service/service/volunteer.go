@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cprakhar/relief-ops/services/user-service/repo"
+	"github.com/cprakhar/relief-ops/shared/authz"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+const (
+	DefaultVolunteerRadius = 25_000  // meters
+	MaxVolunteerRadius     = 200_000 // meters
+	DefaultVolunteerLimit  = 10
+	MaxVolunteerLimit      = 50
+
+	// volunteerCandidates is how many of the nearest matching volunteers are checked for availability.
+	volunteerCandidates = 500
+
+	maxAvailabilityWindows = 50
+	minutesPerDay          = 24 * 60
+)
+
+var (
+	ErrNotVolunteer          = errors.New("only volunteers have a volunteer profile")
+	ErrInvalidProfile        = errors.New("invalid volunteer profile")
+	ErrProfilesForbidden     = errors.New("not allowed to view volunteer profiles")
+	ErrInvalidVolunteerQuery = errors.New("invalid volunteer search")
+)
+
+// validLanguage matches ISO 639-1 and 639-3 language codes.
+var validLanguage = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// GetVolunteerProfile retrieves the volunteer profile of a user, for the volunteer or a dispatcher. Dispatchers
+// scoped to regions may only view profiles with a home base inside their regions.
+func (s *userService) GetVolunteerProfile(ctx context.Context, actorID, userID string) (*types.VolunteerProfile, error) {
+	if actorID == userID {
+		return s.profiles.GetProfile(ctx, userID)
+	}
+
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !authz.DefaultPolicy.Has(actor.Role, authz.Dispatch) {
+		return nil, ErrProfilesForbidden
+	}
+
+	profile, err := s.profiles.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A profile without a home base can't be placed inside a region, so only unscoped dispatchers see it
+	allowed := authz.DefaultPolicy.Allowed(principalOf(actor), authz.Dispatch)
+	if profile.Home != nil && len(profile.Home.Coordinates) == 2 {
+		home := types.Coordinates{Latitude: profile.Home.Coordinates[1], Longitude: profile.Home.Coordinates[0]}
+		allowed = authz.DefaultPolicy.AllowedAt(principalOf(actor), authz.Dispatch, home)
+	}
+	if !allowed {
+		return nil, ErrProfilesForbidden
+	}
+	return profile, nil
+}
+
+// UpdateVolunteerProfile replaces the volunteer profile of a user after normalizing and validating it.
+func (s *userService) UpdateVolunteerProfile(ctx context.Context, userID string, profile *types.VolunteerProfile) (*types.VolunteerProfile, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != types.RoleVolunteer {
+		return nil, ErrNotVolunteer
+	}
+
+	if err := normalizeProfile(profile); err != nil {
+		return nil, err
+	}
+	profile.UserID = user.ID
+
+	if err := s.profiles.UpsertProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// FindVolunteers searches volunteers around a location on behalf of a dispatcher, nearest first. With a non-zero
+// availableAt, only volunteers available at that time are returned. Dispatchers scoped to regions may only
+// search around locations inside their regions.
+func (s *userService) FindVolunteers(ctx context.Context, actorID string, q *repo.VolunteerQuery, availableAt time.Time) ([]*repo.VolunteerMatch, error) {
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	loc := types.Coordinates{Latitude: q.Lat, Longitude: q.Lon}
+	if !authz.DefaultPolicy.AllowedAt(principalOf(actor), authz.Dispatch, loc) {
+		return nil, ErrProfilesForbidden
+	}
+
+	if q.Lat < -90 || q.Lat > 90 || q.Lon < -180 || q.Lon > 180 {
+		return nil, fmt.Errorf("%w: location out of range", ErrInvalidVolunteerQuery)
+	}
+	q.Skills = compactTags(q.Skills)
+	for _, skill := range q.Skills {
+		if !slices.Contains(types.VolunteerSkills, skill) {
+			return nil, fmt.Errorf("%w: unknown skill %s", ErrInvalidVolunteerQuery, skill)
+		}
+	}
+	q.Vehicles = compactTags(q.Vehicles)
+	for _, vehicle := range q.Vehicles {
+		if !slices.Contains(types.Vehicles, vehicle) {
+			return nil, fmt.Errorf("%w: unknown vehicle %s", ErrInvalidVolunteerQuery, vehicle)
+		}
+	}
+	for i, lang := range q.Languages {
+		q.Languages[i] = strings.ToLower(lang)
+	}
+
+	if q.RadiusMeters <= 0 {
+		q.RadiusMeters = DefaultVolunteerRadius
+	}
+	q.RadiusMeters = min(q.RadiusMeters, MaxVolunteerRadius)
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultVolunteerLimit
+	}
+	limit = min(limit, MaxVolunteerLimit)
+
+	// Availability depends on each volunteer's time zone, so it is checked here on the nearest candidates
+	if !availableAt.IsZero() {
+		q.Limit = volunteerCandidates
+	} else {
+		q.Limit = limit
+	}
+
+	candidates, err := s.profiles.FindVolunteers(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*repo.VolunteerMatch
+	for _, c := range candidates {
+		if len(matches) == limit {
+			break
+		}
+		if availableAt.IsZero() || isAvailable(&c.VolunteerProfile, availableAt) {
+			matches = append(matches, c)
+		}
+	}
+	return matches, nil
+}
+
+// normalizeProfile deduplicates and sorts the profile's tags and checks them against the vocabularies.
+func normalizeProfile(p *types.VolunteerProfile) error {
+	p.Skills = compactTags(p.Skills)
+	for _, skill := range p.Skills {
+		if !slices.Contains(types.VolunteerSkills, skill) {
+			return fmt.Errorf("%w: unknown skill %s", ErrInvalidProfile, skill)
+		}
+	}
+
+	p.Vehicles = compactTags(p.Vehicles)
+	for _, vehicle := range p.Vehicles {
+		if !slices.Contains(types.Vehicles, vehicle) {
+			return fmt.Errorf("%w: unknown vehicle %s", ErrInvalidProfile, vehicle)
+		}
+	}
+
+	p.Languages = compactTags(p.Languages)
+	for _, lang := range p.Languages {
+		if !validLanguage.MatchString(lang) {
+			return fmt.Errorf("%w: %q is not an ISO 639 language code", ErrInvalidProfile, lang)
+		}
+	}
+
+	if p.TimeZone == "" {
+		p.TimeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(p.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %s", ErrInvalidProfile, p.TimeZone)
+	}
+
+	if len(p.Availability) > maxAvailabilityWindows {
+		return fmt.Errorf("%w: more than %d availability windows", ErrInvalidProfile, maxAvailabilityWindows)
+	}
+	for _, w := range p.Availability {
+		if w.Day < time.Sunday || w.Day > time.Saturday {
+			return fmt.Errorf("%w: invalid day %d", ErrInvalidProfile, w.Day)
+		}
+		if w.Start < 0 || w.End > minutesPerDay || w.Start >= w.End {
+			return fmt.Errorf("%w: invalid window %d-%d on %s", ErrInvalidProfile, w.Start, w.End, w.Day)
+		}
+	}
+
+	if p.Home != nil {
+		if len(p.Home.Coordinates) != 2 {
+			return fmt.Errorf("%w: home must be a point", ErrInvalidProfile)
+		}
+		lon, lat := p.Home.Coordinates[0], p.Home.Coordinates[1]
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return fmt.Errorf("%w: home is out of range", ErrInvalidProfile)
+		}
+		p.Home.Type = "Point"
+	}
+	return nil
+}
+
+// isAvailable reports whether a volunteer is available at a time, going by the profile's weekly windows.
+func isAvailable(p *types.VolunteerProfile, t time.Time) bool {
+	local := t.In(profileLocation(p))
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range p.Availability {
+		if w.Day == local.Weekday() && w.Start <= minute && minute < w.End {
+			return true
+		}
+	}
+	return false
+}
+
+// profileLocation returns the time zone of a profile's availability windows.
+func profileLocation(p *types.VolunteerProfile) *time.Location {
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// compactTags lowercases, sorts and deduplicates tags.
+func compactTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			out = append(out, t)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,119 @@
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cprakhar/relief-ops/shared/geo"
+	"github.com/cprakhar/relief-ops/shared/types"
+)
+
+// Permission is an action a role may be granted.
+type Permission string
+
+// Permissions.
+const (
+	ReviewDisasters Permission = "disasters:review" // approve or reject reported disasters
+	Dispatch        Permission = "dispatch:manage"  // dispatch volunteers and resources, and update any assignment
+	ManageUsers     Permission = "users:manage"     // change user roles and create invites
+	ReportDisasters Permission = "disasters:report" // report disasters on behalf of an organization, API keys only
+	ReadDisasters   Permission = "disasters:read"   // pull disasters, API keys only
+)
+
+// APIKeyPermissions lists the permissions an API key may be granted. Keys are created by org admins, so they
+// can't carry permissions of platform roles.
+var APIKeyPermissions = []Permission{ReportDisasters, ReadDisasters}
+
+// MaxRegionVertices is the most polygon vertices a user's regions may have in total. Regions are carried
+// in access tokens, which must stay small enough to fit in a cookie.
+const MaxRegionVertices = 40
+
+var ErrInvalidRegion = errors.New("invalid region")
+
+// Principal is the user an action is performed by, as far as authorization is concerned.
+type Principal struct {
+	UserID  string
+	Role    types.Role
+	Regions []types.Region // the role's permissions only apply inside these regions; empty means everywhere
+}
+
+// Scoped reports whether the principal's permissions are limited to regions.
+func (p *Principal) Scoped() bool {
+	return len(p.Regions) > 0
+}
+
+// Policy maps roles to the permissions they grant.
+type Policy map[types.Role][]Permission
+
+// DefaultPolicy is the policy shared by the gateway and the services.
+var DefaultPolicy = Policy{
+	types.RoleAdmin: {ReviewDisasters, Dispatch, ManageUsers},
+}
+
+// Has reports whether a role grants a permission, wherever it applies.
+func (p Policy) Has(role types.Role, perm Permission) bool {
+	return slices.Contains(p[role], perm)
+}
+
+// Allowed reports whether a principal may perform an action that is not tied to a location.
+// Principals scoped to regions are denied, since such actions can't be placed inside their regions.
+func (p Policy) Allowed(pr *Principal, perm Permission) bool {
+	return pr != nil && p.Has(pr.Role, perm) && !pr.Scoped()
+}
+
+// AllowedAt reports whether a principal may perform an action at a location.
+func (p Policy) AllowedAt(pr *Principal, perm Permission, loc types.Coordinates) bool {
+	if pr == nil || !p.Has(pr.Role, perm) {
+		return false
+	}
+	if !pr.Scoped() {
+		return true
+	}
+
+	for _, r := range pr.Regions {
+		if geo.InPolygon(loc.Latitude, loc.Longitude, r.Polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRegions checks regions before a role is scoped to them.
+func ValidateRegions(regions []types.Region) error {
+	names := make(map[string]bool, len(regions))
+	vertices := 0
+	for _, r := range regions {
+		if r.Name == "" {
+			return fmt.Errorf("%w: missing name", ErrInvalidRegion)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("%w: duplicate name %q", ErrInvalidRegion, r.Name)
+		}
+		names[r.Name] = true
+
+		if len(r.Polygon) < 3 {
+			return fmt.Errorf("%w: %s needs at least 3 points", ErrInvalidRegion, r.Name)
+		}
+		for _, c := range r.Polygon {
+			if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+				return fmt.Errorf("%w: %s has a point out of range", ErrInvalidRegion, r.Name)
+			}
+		}
+		vertices += len(r.Polygon)
+	}
+
+	if vertices > MaxRegionVertices {
+		return fmt.Errorf("%w: more than %d points in total", ErrInvalidRegion, MaxRegionVertices)
+	}
+	return nil
+}
+
+// RegionNames returns the names of regions.
+func RegionNames(regions []types.Region) []string {
+	var names []string
+	for _, r := range regions {
+		names = append(names, r.Name)
+	}
+	return names
+}
@@ -1,16 +1,67 @@
 package events
 
-import "github.com/cprakhar/relief-ops/shared/types"
+import (
+	"time"
+
+	"github.com/cprakhar/relief-ops/shared/types"
+)
 
 // Event types
 const (
 	ResourceCommandFind   = "resource.cmd.find"
+	ResourceEventFound    = "resource.evt.found"
 	UserNotifyAdminReview = "user.notify.admin_review"
+	SupplyEventCommitted  = "supply.evt.committed"
+	DispatchEventUpdated  = "dispatch.evt.updated"
+	DisasterEventApproved = "disaster.evt.approved"
 )
 
 type DisasterEventCreatedPayload struct {
 	DisasterID  string            `json:"disaster_id"`
+	Title       string            `json:"title"`
+	Severity    string            `json:"severity"`
 	Location    types.Coordinates `json:"location"`
-	Range       int               `json:"range"`
+	Range       int               `json:"range"` // search radius in meters, 0 lets the resource service pick it
+	Tags        []string          `json:"tags,omitempty"`
 	VolunteerID string            `json:"volunteer_id"`
 }
+
+type DisasterEventApprovedPayload struct {
+	DisasterID string            `json:"disaster_id"`
+	Title      string            `json:"title"`
+	Location   types.Coordinates `json:"location"`
+	Tags       []string          `json:"tags,omitempty"`
+	OrgID      string            `json:"org_id,omitempty"`
+	ApprovedBy string            `json:"approved_by"`
+	ApprovedAt time.Time         `json:"approved_at"`
+}
+
+type ResourceEventFoundPayload struct {
+	DisasterID string                   `json:"disaster_id"`
+	Radius     int                      `json:"radius"`    // search radius in meters the resources were matched within
+	Resources  []types.ResourceSnapshot `json:"resources"` // nearest matched resources
+	Counts     map[string]int64         `json:"counts"`    // all matched resources per category
+	Total      int64                    `json:"total"`
+	FoundAt    time.Time                `json:"found_at"`
+}
+
+type SupplyEventCommittedPayload struct {
+	CommitmentID string  `json:"commitment_id"`
+	DisasterID   string  `json:"disaster_id"`
+	NeedID       string  `json:"need_id"`
+	DepotID      string  `json:"depot_id"`
+	Item         string  `json:"item"`
+	Quantity     int64   `json:"quantity"`
+	Distance     float64 `json:"distance"`
+	CommittedBy  string  `json:"committed_by"`
+}
+
+type DispatchEventUpdatedPayload struct {
+	AssignmentID string    `json:"assignment_id"`
+	DisasterID   string    `json:"disaster_id"`
+	AssigneeType string    `json:"assignee_type"`
+	AssigneeID   string    `json:"assignee_id"`
+	Status       string    `json:"status"`
+	ChangedBy    string    `json:"changed_by"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
@@ -0,0 +1,17 @@
+package geo
+
+import "github.com/cprakhar/relief-ops/shared/types"
+
+// InPolygon reports whether a point given in degrees lies inside a polygon ring. The ring may be open
+// or closed, and is treated as planar, which is accurate enough for regions that don't span the antimeridian.
+func InPolygon(lat, lon float64, ring []types.Coordinates) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > lat) != (b.Latitude > lat) &&
+			lon < (b.Longitude-a.Longitude)*(lat-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,291 @@
+// Package mvt encodes point features as Mapbox Vector Tiles (https://github.com/mapbox/vector-tile-spec).
+package mvt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// Extent is the size of a tile in tile coordinates.
+	Extent = 4096
+
+	// MaxZoom is the highest supported zoom level.
+	MaxZoom = 22
+
+	// maxLatitude is the latitude limit of the Web Mercator projection.
+	maxLatitude = 85.05112878
+
+	version   = 2
+	geomPoint = 1
+	cmdMoveTo = 1
+)
+
+// Vector tile protobuf field numbers.
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureID       = 1
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueDouble = 3
+	valueInt    = 4
+	valueBool   = 7
+)
+
+// Tile addresses a tile in the XYZ (slippy map) scheme.
+type Tile struct {
+	Z, X, Y uint32
+}
+
+// Validate checks the tile lies within the tile grid of its zoom level.
+func (t Tile) Validate() error {
+	if t.Z > MaxZoom {
+		return fmt.Errorf("zoom %d exceeds maximum of %d", t.Z, MaxZoom)
+	}
+	if n := uint32(1) << t.Z; t.X >= n || t.Y >= n {
+		return fmt.Errorf("tile %d/%d/%d is outside the tile grid", t.Z, t.X, t.Y)
+	}
+	return nil
+}
+
+// Bounds returns the longitude and latitude bounds of the tile, extended on each side
+// by buffer tile coordinates (clamped to the projection limits).
+func (t Tile) Bounds(buffer int64) (minLon, minLat, maxLon, maxLat float64) {
+	n := float64(uint32(1) << t.Z)
+	b := float64(buffer) / Extent
+
+	minLon = max((float64(t.X)-b)/n*360-180, -180)
+	maxLon = min((float64(t.X)+1+b)/n*360-180, 180)
+	maxLat = min(tileLatitude(float64(t.Y)-b, n), maxLatitude)
+	minLat = max(tileLatitude(float64(t.Y)+1+b, n), -maxLatitude)
+	return minLon, minLat, maxLon, maxLat
+}
+
+// Project converts a coordinate to tile coordinates relative to the tile's top-left corner.
+func (t Tile) Project(lat, lon float64) (x, y int64) {
+	n := float64(uint32(1) << t.Z)
+	lat = max(min(lat, maxLatitude), -maxLatitude)
+	rad := lat * math.Pi / 180
+
+	tx := (lon + 180) / 360 * n
+	ty := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+
+	x = int64(math.Round((tx - float64(t.X)) * Extent))
+	y = int64(math.Round((ty - float64(t.Y)) * Extent))
+	return x, y
+}
+
+// Contains reports whether tile coordinates lie within the tile extended by buffer.
+func (t Tile) Contains(x, y, buffer int64) bool {
+	return x >= -buffer && x <= Extent+buffer && y >= -buffer && y <= Extent+buffer
+}
+
+func tileLatitude(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
+
+// Feature is a point feature in tile coordinates.
+// Property values may be strings, integers, floats or booleans.
+type Feature struct {
+	ID         uint64 // optional, 0 leaves the ID unset
+	X, Y       int64
+	Properties map[string]any
+}
+
+// Layer is a named set of features.
+type Layer struct {
+	Name     string
+	Features []*Feature
+}
+
+// Cluster merges features falling into the same grid cell of cellSize tile coordinates into a single
+// feature at their centroid, carrying "cluster" and "point_count" properties. Lone features are kept as is.
+func Cluster(features []*Feature, cellSize int64) []*Feature {
+	type cell struct{ x, y int64 }
+
+	var order []cell
+	groups := make(map[cell][]*Feature)
+	for _, f := range features {
+		c := cell{floorDiv(f.X, cellSize), floorDiv(f.Y, cellSize)}
+		if _, ok := groups[c]; !ok {
+			order = append(order, c)
+		}
+		groups[c] = append(groups[c], f)
+	}
+
+	clustered := make([]*Feature, 0, len(order))
+	for _, c := range order {
+		members := groups[c]
+		if len(members) == 1 {
+			clustered = append(clustered, members[0])
+			continue
+		}
+
+		var sumX, sumY int64
+		for _, m := range members {
+			sumX += m.X
+			sumY += m.Y
+		}
+		n := int64(len(members))
+		clustered = append(clustered, &Feature{
+			X: sumX / n,
+			Y: sumY / n,
+			Properties: map[string]any{
+				"cluster":     true,
+				"point_count": n,
+			},
+		})
+	}
+	return clustered
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Encode encodes layers as a vector tile. Layers without features are omitted.
+func Encode(layers ...*Layer) ([]byte, error) {
+	var tile []byte
+	for _, l := range layers {
+		if len(l.Features) == 0 {
+			continue
+		}
+		layer, err := encodeLayer(l)
+		if err != nil {
+			return nil, err
+		}
+		tile = protowire.AppendTag(tile, tileLayers, protowire.BytesType)
+		tile = protowire.AppendBytes(tile, layer)
+	}
+	return tile, nil
+}
+
+func encodeLayer(l *Layer) ([]byte, error) {
+	var (
+		keys     []string
+		keyIndex = make(map[string]uint64)
+		values   [][]byte
+		valIndex = make(map[string]uint64)
+		features []byte
+	)
+
+	for _, f := range l.Features {
+		names := make([]string, 0, len(f.Properties))
+		for k := range f.Properties {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		var tags []byte
+		for _, k := range names {
+			v, err := encodeValue(f.Properties[k])
+			if err != nil {
+				return nil, fmt.Errorf("layer %s property %s: %w", l.Name, k, err)
+			}
+			if v == nil {
+				continue // nil properties are omitted
+			}
+
+			ki, ok := keyIndex[k]
+			if !ok {
+				ki = uint64(len(keys))
+				keyIndex[k] = ki
+				keys = append(keys, k)
+			}
+			vi, ok := valIndex[string(v)]
+			if !ok {
+				vi = uint64(len(values))
+				valIndex[string(v)] = vi
+				values = append(values, v)
+			}
+			tags = protowire.AppendVarint(tags, ki)
+			tags = protowire.AppendVarint(tags, vi)
+		}
+
+		// A single MoveTo command relative to the tile origin
+		var geometry []byte
+		geometry = protowire.AppendVarint(geometry, cmdMoveTo|1<<3)
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(f.X))
+		geometry = protowire.AppendVarint(geometry, protowire.EncodeZigZag(f.Y))
+
+		var feature []byte
+		if f.ID != 0 {
+			feature = protowire.AppendTag(feature, featureID, protowire.VarintType)
+			feature = protowire.AppendVarint(feature, f.ID)
+		}
+		if len(tags) > 0 {
+			feature = protowire.AppendTag(feature, featureTags, protowire.BytesType)
+			feature = protowire.AppendBytes(feature, tags)
+		}
+		feature = protowire.AppendTag(feature, featureType, protowire.VarintType)
+		feature = protowire.AppendVarint(feature, geomPoint)
+		feature = protowire.AppendTag(feature, featureGeometry, protowire.BytesType)
+		feature = protowire.AppendBytes(feature, geometry)
+
+		features = protowire.AppendTag(features, layerFeatures, protowire.BytesType)
+		features = protowire.AppendBytes(features, feature)
+	}
+
+	var layer []byte
+	layer = protowire.AppendTag(layer, layerVersion, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, version)
+	layer = protowire.AppendTag(layer, layerName, protowire.BytesType)
+	layer = protowire.AppendString(layer, l.Name)
+	layer = append(layer, features...)
+	for _, k := range keys {
+		layer = protowire.AppendTag(layer, layerKeys, protowire.BytesType)
+		layer = protowire.AppendString(layer, k)
+	}
+	for _, v := range values {
+		layer = protowire.AppendTag(layer, layerValues, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, v)
+	}
+	layer = protowire.AppendTag(layer, layerExtent, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, Extent)
+	return layer, nil
+}
+
+// encodeValue encodes a property value as a vector tile Value message.
+func encodeValue(v any) ([]byte, error) {
+	var b []byte
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		b = protowire.AppendTag(b, valueString, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	case bool:
+		b = protowire.AppendTag(b, valueBool, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v))
+	case int:
+		b = protowire.AppendTag(b, valueInt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case int64:
+		b = protowire.AppendTag(b, valueInt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case float64:
+		b = protowire.AppendTag(b, valueDouble, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v))
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+	return b, nil
+}
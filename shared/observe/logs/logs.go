@@ -111,6 +111,13 @@ func Init(serviceName string) (*zap.SugaredLogger, error) {
 	return sugar, nil
 }
 
+// Set replaces the global logger, e.g., with a no-op logger in tests.
+func Set(l *zap.SugaredLogger) {
+	mu.Lock()
+	globalSugar = l
+	mu.Unlock()
+}
+
 // L returns the global sugared logger. Panics if Init not called.
 func L() *zap.SugaredLogger {
 	mu.RLock()
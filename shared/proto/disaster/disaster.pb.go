@@ -25,6 +25,7 @@ const (
 type ListDisastersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Bounds        *Bounds                `protobuf:"bytes,2,opt,name=bounds,proto3" json:"bounds,omitempty"` // optional, restricts disasters to a bounding box
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +67,81 @@ func (x *ListDisastersRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ListDisastersRequest) GetBounds() *Bounds {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+type Bounds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLat        float64                `protobuf:"fixed64,1,opt,name=minLat,proto3" json:"minLat,omitempty"`
+	MinLon        float64                `protobuf:"fixed64,2,opt,name=minLon,proto3" json:"minLon,omitempty"`
+	MaxLat        float64                `protobuf:"fixed64,3,opt,name=maxLat,proto3" json:"maxLat,omitempty"`
+	MaxLon        float64                `protobuf:"fixed64,4,opt,name=maxLon,proto3" json:"maxLon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bounds) Reset() {
+	*x = Bounds{}
+	mi := &file_disaster_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bounds) ProtoMessage() {}
+
+func (x *Bounds) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bounds.ProtoReflect.Descriptor instead.
+func (*Bounds) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Bounds) GetMinLat() float64 {
+	if x != nil {
+		return x.MinLat
+	}
+	return 0
+}
+
+func (x *Bounds) GetMinLon() float64 {
+	if x != nil {
+		return x.MinLon
+	}
+	return 0
+}
+
+func (x *Bounds) GetMaxLat() float64 {
+	if x != nil {
+		return x.MaxLat
+	}
+	return 0
+}
+
+func (x *Bounds) GetMaxLon() float64 {
+	if x != nil {
+		return x.MaxLon
+	}
+	return 0
+}
+
 type ListDisastersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Disasters     []*GetDisasterResponse `protobuf:"bytes,1,rep,name=disasters,proto3" json:"disasters,omitempty"`
@@ -75,7 +151,7 @@ type ListDisastersResponse struct {
 
 func (x *ListDisastersResponse) Reset() {
 	*x = ListDisastersResponse{}
-	mi := &file_disaster_proto_msgTypes[1]
+	mi := &file_disaster_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -87,7 +163,7 @@ func (x *ListDisastersResponse) String() string {
 func (*ListDisastersResponse) ProtoMessage() {}
 
 func (x *ListDisastersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[1]
+	mi := &file_disaster_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -100,7 +176,7 @@ func (x *ListDisastersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDisastersResponse.ProtoReflect.Descriptor instead.
 func (*ListDisastersResponse) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{1}
+	return file_disaster_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ListDisastersResponse) GetDisasters() []*GetDisasterResponse {
@@ -115,13 +191,15 @@ type ReviewDisasterRequest struct {
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	AdminID       string                 `protobuf:"bytes,2,opt,name=adminID,proto3" json:"adminID,omitempty"`
 	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	AdminRole     string                 `protobuf:"bytes,4,opt,name=adminRole,proto3" json:"adminRole,omitempty"`
+	AdminRegions  []*Region              `protobuf:"bytes,5,rep,name=adminRegions,proto3" json:"adminRegions,omitempty"` // empty when the admin is not scoped to regions
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReviewDisasterRequest) Reset() {
 	*x = ReviewDisasterRequest{}
-	mi := &file_disaster_proto_msgTypes[2]
+	mi := &file_disaster_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -133,7 +211,7 @@ func (x *ReviewDisasterRequest) String() string {
 func (*ReviewDisasterRequest) ProtoMessage() {}
 
 func (x *ReviewDisasterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[2]
+	mi := &file_disaster_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -146,7 +224,7 @@ func (x *ReviewDisasterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewDisasterRequest.ProtoReflect.Descriptor instead.
 func (*ReviewDisasterRequest) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{2}
+	return file_disaster_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ReviewDisasterRequest) GetId() string {
@@ -170,6 +248,20 @@ func (x *ReviewDisasterRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ReviewDisasterRequest) GetAdminRole() string {
+	if x != nil {
+		return x.AdminRole
+	}
+	return ""
+}
+
+func (x *ReviewDisasterRequest) GetAdminRegions() []*Region {
+	if x != nil {
+		return x.AdminRegions
+	}
+	return nil
+}
+
 type ReviewDisasterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -180,7 +272,7 @@ type ReviewDisasterResponse struct {
 
 func (x *ReviewDisasterResponse) Reset() {
 	*x = ReviewDisasterResponse{}
-	mi := &file_disaster_proto_msgTypes[3]
+	mi := &file_disaster_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -192,7 +284,7 @@ func (x *ReviewDisasterResponse) String() string {
 func (*ReviewDisasterResponse) ProtoMessage() {}
 
 func (x *ReviewDisasterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[3]
+	mi := &file_disaster_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,7 +297,7 @@ func (x *ReviewDisasterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewDisasterResponse.ProtoReflect.Descriptor instead.
 func (*ReviewDisasterResponse) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{3}
+	return file_disaster_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ReviewDisasterResponse) GetId() string {
@@ -230,13 +322,15 @@ type ReportDisasterRequest struct {
 	ImageURLs     []string               `protobuf:"bytes,4,rep,name=imageURLs,proto3" json:"imageURLs,omitempty"`
 	VolunteerID   string                 `protobuf:"bytes,5,opt,name=volunteerID,proto3" json:"volunteerID,omitempty"`
 	Location      *Coordinates           `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	OrgID         string                 `protobuf:"bytes,7,opt,name=orgID,proto3" json:"orgID,omitempty"`       // organization the report is filed for, if any
+	Severity      string                 `protobuf:"bytes,8,opt,name=severity,proto3" json:"severity,omitempty"` // low, medium, high or critical; medium when empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReportDisasterRequest) Reset() {
 	*x = ReportDisasterRequest{}
-	mi := &file_disaster_proto_msgTypes[4]
+	mi := &file_disaster_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -248,7 +342,7 @@ func (x *ReportDisasterRequest) String() string {
 func (*ReportDisasterRequest) ProtoMessage() {}
 
 func (x *ReportDisasterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[4]
+	mi := &file_disaster_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +355,7 @@ func (x *ReportDisasterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportDisasterRequest.ProtoReflect.Descriptor instead.
 func (*ReportDisasterRequest) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{4}
+	return file_disaster_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ReportDisasterRequest) GetTitle() string {
@@ -306,6 +400,20 @@ func (x *ReportDisasterRequest) GetLocation() *Coordinates {
 	return nil
 }
 
+func (x *ReportDisasterRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *ReportDisasterRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
 type Coordinates struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
@@ -316,7 +424,7 @@ type Coordinates struct {
 
 func (x *Coordinates) Reset() {
 	*x = Coordinates{}
-	mi := &file_disaster_proto_msgTypes[5]
+	mi := &file_disaster_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +436,7 @@ func (x *Coordinates) String() string {
 func (*Coordinates) ProtoMessage() {}
 
 func (x *Coordinates) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[5]
+	mi := &file_disaster_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +449,7 @@ func (x *Coordinates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
 func (*Coordinates) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{5}
+	return file_disaster_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Coordinates) GetLatitude() float64 {
@@ -358,6 +466,58 @@ func (x *Coordinates) GetLongitude() float64 {
 	return 0
 }
 
+type Region struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Polygon       []*Coordinates         `protobuf:"bytes,2,rep,name=polygon,proto3" json:"polygon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Region) Reset() {
+	*x = Region{}
+	mi := &file_disaster_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Region) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Region) ProtoMessage() {}
+
+func (x *Region) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Region.ProtoReflect.Descriptor instead.
+func (*Region) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Region) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Region) GetPolygon() []*Coordinates {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
 type ReportDisasterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -368,7 +528,7 @@ type ReportDisasterResponse struct {
 
 func (x *ReportDisasterResponse) Reset() {
 	*x = ReportDisasterResponse{}
-	mi := &file_disaster_proto_msgTypes[6]
+	mi := &file_disaster_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -380,7 +540,7 @@ func (x *ReportDisasterResponse) String() string {
 func (*ReportDisasterResponse) ProtoMessage() {}
 
 func (x *ReportDisasterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[6]
+	mi := &file_disaster_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,7 +553,7 @@ func (x *ReportDisasterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportDisasterResponse.ProtoReflect.Descriptor instead.
 func (*ReportDisasterResponse) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{6}
+	return file_disaster_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReportDisasterResponse) GetId() string {
@@ -419,7 +579,7 @@ type GetDisasterRequest struct {
 
 func (x *GetDisasterRequest) Reset() {
 	*x = GetDisasterRequest{}
-	mi := &file_disaster_proto_msgTypes[7]
+	mi := &file_disaster_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +591,7 @@ func (x *GetDisasterRequest) String() string {
 func (*GetDisasterRequest) ProtoMessage() {}
 
 func (x *GetDisasterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[7]
+	mi := &file_disaster_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +604,7 @@ func (x *GetDisasterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDisasterRequest.ProtoReflect.Descriptor instead.
 func (*GetDisasterRequest) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{7}
+	return file_disaster_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetDisasterRequest) GetId() string {
@@ -455,25 +615,30 @@ func (x *GetDisasterRequest) GetId() string {
 }
 
 type GetDisasterResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	ImageURLs     []string               `protobuf:"bytes,5,rep,name=imageURLs,proto3" json:"imageURLs,omitempty"`
-	VolunteerID   string                 `protobuf:"bytes,6,opt,name=volunteerID,proto3" json:"volunteerID,omitempty"`
-	Location      *Coordinates           `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
-	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
-	Resources     []*Resource            `protobuf:"bytes,11,rep,name=resources,proto3" json:"resources,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Tags             []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	ImageURLs        []string               `protobuf:"bytes,5,rep,name=imageURLs,proto3" json:"imageURLs,omitempty"`
+	VolunteerID      string                 `protobuf:"bytes,6,opt,name=volunteerID,proto3" json:"volunteerID,omitempty"`
+	Location         *Coordinates           `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	Status           string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Resources        []*Resource            `protobuf:"bytes,11,rep,name=resources,proto3" json:"resources,omitempty"`                                                                                      // resources found around the disaster, nearest first
+	ResourceCounts   map[string]int64       `protobuf:"bytes,12,rep,name=resourceCounts,proto3" json:"resourceCounts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // resources found per category
+	ResourcesFoundAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=resourcesFoundAt,proto3" json:"resourcesFoundAt,omitempty"`
+	SearchRadius     int64                  `protobuf:"varint,14,opt,name=searchRadius,proto3" json:"searchRadius,omitempty"` // meters around the disaster the resources were searched within
+	OrgID            string                 `protobuf:"bytes,15,opt,name=orgID,proto3" json:"orgID,omitempty"`
+	Severity         string                 `protobuf:"bytes,16,opt,name=severity,proto3" json:"severity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetDisasterResponse) Reset() {
 	*x = GetDisasterResponse{}
-	mi := &file_disaster_proto_msgTypes[8]
+	mi := &file_disaster_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -485,7 +650,7 @@ func (x *GetDisasterResponse) String() string {
 func (*GetDisasterResponse) ProtoMessage() {}
 
 func (x *GetDisasterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[8]
+	mi := &file_disaster_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +663,7 @@ func (x *GetDisasterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDisasterResponse.ProtoReflect.Descriptor instead.
 func (*GetDisasterResponse) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{8}
+	return file_disaster_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetDisasterResponse) GetId() string {
@@ -578,19 +743,55 @@ func (x *GetDisasterResponse) GetResources() []*Resource {
 	return nil
 }
 
+func (x *GetDisasterResponse) GetResourceCounts() map[string]int64 {
+	if x != nil {
+		return x.ResourceCounts
+	}
+	return nil
+}
+
+func (x *GetDisasterResponse) GetResourcesFoundAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResourcesFoundAt
+	}
+	return nil
+}
+
+func (x *GetDisasterResponse) GetSearchRadius() int64 {
+	if x != nil {
+		return x.SearchRadius
+	}
+	return 0
+}
+
+func (x *GetDisasterResponse) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *GetDisasterResponse) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
 type Resource struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	Location      *Coordinates           `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Distance      float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_disaster_proto_msgTypes[9]
+	mi := &file_disaster_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -602,7 +803,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_disaster_proto_msgTypes[9]
+	mi := &file_disaster_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +816,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_disaster_proto_rawDescGZIP(), []int{9}
+	return file_disaster_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Resource) GetId() string {
@@ -646,60 +847,749 @@ func (x *Resource) GetLocation() *Coordinates {
 	return nil
 }
 
-var File_disaster_proto protoreflect.FileDescriptor
+func (x *Resource) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
 
-const file_disaster_proto_rawDesc = "" +
-	"\n" +
-	"\x0edisaster.proto\x12\bdisaster\x1a\x1fgoogle/protobuf/timestamp.proto\".\n" +
-	"\x14ListDisastersRequest\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"T\n" +
-	"\x15ListDisastersResponse\x12;\n" +
-	"\tdisasters\x18\x01 \x03(\v2\x1d.disaster.GetDisasterResponseR\tdisasters\"Y\n" +
-	"\x15ReviewDisasterRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
-	"\aadminID\x18\x02 \x01(\tR\aadminID\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"@\n" +
-	"\x16ReviewDisasterResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"\xd6\x01\n" +
-	"\x15ReportDisasterRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1c\n" +
-	"\timageURLs\x18\x04 \x03(\tR\timageURLs\x12 \n" +
-	"\vvolunteerID\x18\x05 \x01(\tR\vvolunteerID\x121\n" +
-	"\blocation\x18\x06 \x01(\v2\x15.disaster.CoordinatesR\blocation\"G\n" +
-	"\vCoordinates\x12\x1a\n" +
-	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"@\n" +
-	"\x16ReportDisasterResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"$\n" +
-	"\x12GetDisasterRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa2\x03\n" +
-	"\x13GetDisasterResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1c\n" +
-	"\timageURLs\x18\x05 \x03(\tR\timageURLs\x12 \n" +
-	"\vvolunteerID\x18\x06 \x01(\tR\vvolunteerID\x121\n" +
-	"\blocation\x18\a \x01(\v2\x15.disaster.CoordinatesR\blocation\x128\n" +
-	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
-	"\x06status\x18\n" +
-	" \x01(\tR\x06status\x120\n" +
-	"\tresources\x18\v \x03(\v2\x12.disaster.ResourceR\tresources\"u\n" +
-	"\bResource\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x121\n" +
-	"\blocation\x18\x04 \x01(\v2\x15.disaster.CoordinatesR\blocation2\xd9\x02\n" +
+type AssignDispatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterID    string                 `protobuf:"bytes,1,opt,name=disasterID,proto3" json:"disasterID,omitempty"`
+	AssigneeType  string                 `protobuf:"bytes,2,opt,name=assigneeType,proto3" json:"assigneeType,omitempty"` // "volunteer", "resource" or "team"
+	AssigneeID    string                 `protobuf:"bytes,3,opt,name=assigneeID,proto3" json:"assigneeID,omitempty"`
+	AssigneeName  string                 `protobuf:"bytes,4,opt,name=assigneeName,proto3" json:"assigneeName,omitempty"`
+	Notes         string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	AdminID       string                 `protobuf:"bytes,6,opt,name=adminID,proto3" json:"adminID,omitempty"`
+	AdminRole     string                 `protobuf:"bytes,7,opt,name=adminRole,proto3" json:"adminRole,omitempty"`
+	AdminRegions  []*Region              `protobuf:"bytes,8,rep,name=adminRegions,proto3" json:"adminRegions,omitempty"`
+	AssigneeOrgID string                 `protobuf:"bytes,9,opt,name=assigneeOrgID,proto3" json:"assigneeOrgID,omitempty"` // organization of a team assignee
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignDispatchRequest) Reset() {
+	*x = AssignDispatchRequest{}
+	mi := &file_disaster_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignDispatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignDispatchRequest) ProtoMessage() {}
+
+func (x *AssignDispatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignDispatchRequest.ProtoReflect.Descriptor instead.
+func (*AssignDispatchRequest) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignDispatchRequest) GetDisasterID() string {
+	if x != nil {
+		return x.DisasterID
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAssigneeType() string {
+	if x != nil {
+		return x.AssigneeType
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAssigneeID() string {
+	if x != nil {
+		return x.AssigneeID
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAssigneeName() string {
+	if x != nil {
+		return x.AssigneeName
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAdminID() string {
+	if x != nil {
+		return x.AdminID
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAdminRole() string {
+	if x != nil {
+		return x.AdminRole
+	}
+	return ""
+}
+
+func (x *AssignDispatchRequest) GetAdminRegions() []*Region {
+	if x != nil {
+		return x.AdminRegions
+	}
+	return nil
+}
+
+func (x *AssignDispatchRequest) GetAssigneeOrgID() string {
+	if x != nil {
+		return x.AssigneeOrgID
+	}
+	return ""
+}
+
+type UpdateAssignmentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ActorID       string                 `protobuf:"bytes,3,opt,name=actorID,proto3" json:"actorID,omitempty"`
+	ActorRole     string                 `protobuf:"bytes,4,opt,name=actorRole,proto3" json:"actorRole,omitempty"`
+	ActorRegions  []*Region              `protobuf:"bytes,5,rep,name=actorRegions,proto3" json:"actorRegions,omitempty"`
+	ActorTeamIDs  []string               `protobuf:"bytes,6,rep,name=actorTeamIDs,proto3" json:"actorTeamIDs,omitempty"` // teams the actor belongs to, whose assignments they may update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssignmentStatusRequest) Reset() {
+	*x = UpdateAssignmentStatusRequest{}
+	mi := &file_disaster_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssignmentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssignmentStatusRequest) ProtoMessage() {}
+
+func (x *UpdateAssignmentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssignmentStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssignmentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateAssignmentStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentStatusRequest) GetActorID() string {
+	if x != nil {
+		return x.ActorID
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentStatusRequest) GetActorRole() string {
+	if x != nil {
+		return x.ActorRole
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentStatusRequest) GetActorRegions() []*Region {
+	if x != nil {
+		return x.ActorRegions
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentStatusRequest) GetActorTeamIDs() []string {
+	if x != nil {
+		return x.ActorTeamIDs
+	}
+	return nil
+}
+
+type AssignmentProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,2,opt,name=changedBy,proto3" json:"changedBy,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentProgress) Reset() {
+	*x = AssignmentProgress{}
+	mi := &file_disaster_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentProgress) ProtoMessage() {}
+
+func (x *AssignmentProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentProgress.ProtoReflect.Descriptor instead.
+func (*AssignmentProgress) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AssignmentProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AssignmentProgress) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *AssignmentProgress) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type Assignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisasterID    string                 `protobuf:"bytes,2,opt,name=disasterID,proto3" json:"disasterID,omitempty"`
+	AssigneeType  string                 `protobuf:"bytes,3,opt,name=assigneeType,proto3" json:"assigneeType,omitempty"`
+	AssigneeID    string                 `protobuf:"bytes,4,opt,name=assigneeID,proto3" json:"assigneeID,omitempty"`
+	AssigneeName  string                 `protobuf:"bytes,5,opt,name=assigneeName,proto3" json:"assigneeName,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Active        bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	Notes         string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	AssignedBy    string                 `protobuf:"bytes,9,opt,name=assignedBy,proto3" json:"assignedBy,omitempty"`
+	History       []*AssignmentProgress  `protobuf:"bytes,10,rep,name=history,proto3" json:"history,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	AssigneeOrgID string                 `protobuf:"bytes,13,opt,name=assigneeOrgID,proto3" json:"assigneeOrgID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_disaster_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Assignment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Assignment) GetDisasterID() string {
+	if x != nil {
+		return x.DisasterID
+	}
+	return ""
+}
+
+func (x *Assignment) GetAssigneeType() string {
+	if x != nil {
+		return x.AssigneeType
+	}
+	return ""
+}
+
+func (x *Assignment) GetAssigneeID() string {
+	if x != nil {
+		return x.AssigneeID
+	}
+	return ""
+}
+
+func (x *Assignment) GetAssigneeName() string {
+	if x != nil {
+		return x.AssigneeName
+	}
+	return ""
+}
+
+func (x *Assignment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Assignment) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Assignment) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Assignment) GetAssignedBy() string {
+	if x != nil {
+		return x.AssignedBy
+	}
+	return ""
+}
+
+func (x *Assignment) GetHistory() []*AssignmentProgress {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Assignment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetAssigneeOrgID() string {
+	if x != nil {
+		return x.AssigneeOrgID
+	}
+	return ""
+}
+
+type GetDispatchBoardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterID    string                 `protobuf:"bytes,1,opt,name=disasterID,proto3" json:"disasterID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDispatchBoardRequest) Reset() {
+	*x = GetDispatchBoardRequest{}
+	mi := &file_disaster_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDispatchBoardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDispatchBoardRequest) ProtoMessage() {}
+
+func (x *GetDispatchBoardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDispatchBoardRequest.ProtoReflect.Descriptor instead.
+func (*GetDispatchBoardRequest) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetDispatchBoardRequest) GetDisasterID() string {
+	if x != nil {
+		return x.DisasterID
+	}
+	return ""
+}
+
+type GetDispatchBoardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*Assignment          `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDispatchBoardResponse) Reset() {
+	*x = GetDispatchBoardResponse{}
+	mi := &file_disaster_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDispatchBoardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDispatchBoardResponse) ProtoMessage() {}
+
+func (x *GetDispatchBoardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDispatchBoardResponse.ProtoReflect.Descriptor instead.
+func (*GetDispatchBoardResponse) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDispatchBoardResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type ListAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssigneeType  string                 `protobuf:"bytes,1,opt,name=assigneeType,proto3" json:"assigneeType,omitempty"`
+	AssigneeID    string                 `protobuf:"bytes,2,opt,name=assigneeID,proto3" json:"assigneeID,omitempty"`
+	ActiveOnly    bool                   `protobuf:"varint,3,opt,name=activeOnly,proto3" json:"activeOnly,omitempty"`
+	TeamIDs       []string               `protobuf:"bytes,4,rep,name=teamIDs,proto3" json:"teamIDs,omitempty"` // also lists the assignments of these teams
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsRequest) Reset() {
+	*x = ListAssignmentsRequest{}
+	mi := &file_disaster_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAssignmentsRequest) GetAssigneeType() string {
+	if x != nil {
+		return x.AssigneeType
+	}
+	return ""
+}
+
+func (x *ListAssignmentsRequest) GetAssigneeID() string {
+	if x != nil {
+		return x.AssigneeID
+	}
+	return ""
+}
+
+func (x *ListAssignmentsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+func (x *ListAssignmentsRequest) GetTeamIDs() []string {
+	if x != nil {
+		return x.TeamIDs
+	}
+	return nil
+}
+
+type ListAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*Assignment          `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsResponse) Reset() {
+	*x = ListAssignmentsResponse{}
+	mi := &file_disaster_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disaster_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_disaster_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListAssignmentsResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+var File_disaster_proto protoreflect.FileDescriptor
+
+const file_disaster_proto_rawDesc = "" +
+	"\n" +
+	"\x0edisaster.proto\x12\bdisaster\x1a\x1fgoogle/protobuf/timestamp.proto\"X\n" +
+	"\x14ListDisastersRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12(\n" +
+	"\x06bounds\x18\x02 \x01(\v2\x10.disaster.BoundsR\x06bounds\"h\n" +
+	"\x06Bounds\x12\x16\n" +
+	"\x06minLat\x18\x01 \x01(\x01R\x06minLat\x12\x16\n" +
+	"\x06minLon\x18\x02 \x01(\x01R\x06minLon\x12\x16\n" +
+	"\x06maxLat\x18\x03 \x01(\x01R\x06maxLat\x12\x16\n" +
+	"\x06maxLon\x18\x04 \x01(\x01R\x06maxLon\"T\n" +
+	"\x15ListDisastersResponse\x12;\n" +
+	"\tdisasters\x18\x01 \x03(\v2\x1d.disaster.GetDisasterResponseR\tdisasters\"\xad\x01\n" +
+	"\x15ReviewDisasterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aadminID\x18\x02 \x01(\tR\aadminID\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1c\n" +
+	"\tadminRole\x18\x04 \x01(\tR\tadminRole\x124\n" +
+	"\fadminRegions\x18\x05 \x03(\v2\x10.disaster.RegionR\fadminRegions\"@\n" +
+	"\x16ReviewDisasterResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x88\x02\n" +
+	"\x15ReportDisasterRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1c\n" +
+	"\timageURLs\x18\x04 \x03(\tR\timageURLs\x12 \n" +
+	"\vvolunteerID\x18\x05 \x01(\tR\vvolunteerID\x121\n" +
+	"\blocation\x18\x06 \x01(\v2\x15.disaster.CoordinatesR\blocation\x12\x14\n" +
+	"\x05orgID\x18\a \x01(\tR\x05orgID\x12\x1a\n" +
+	"\bseverity\x18\b \x01(\tR\bseverity\"G\n" +
+	"\vCoordinates\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"M\n" +
+	"\x06Region\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\apolygon\x18\x02 \x03(\v2\x15.disaster.CoordinatesR\apolygon\"@\n" +
+	"\x16ReportDisasterResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"$\n" +
+	"\x12GetDisasterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xde\x05\n" +
+	"\x13GetDisasterResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1c\n" +
+	"\timageURLs\x18\x05 \x03(\tR\timageURLs\x12 \n" +
+	"\vvolunteerID\x18\x06 \x01(\tR\vvolunteerID\x121\n" +
+	"\blocation\x18\a \x01(\v2\x15.disaster.CoordinatesR\blocation\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x120\n" +
+	"\tresources\x18\v \x03(\v2\x12.disaster.ResourceR\tresources\x12Y\n" +
+	"\x0eresourceCounts\x18\f \x03(\v21.disaster.GetDisasterResponse.ResourceCountsEntryR\x0eresourceCounts\x12F\n" +
+	"\x10resourcesFoundAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x10resourcesFoundAt\x12\"\n" +
+	"\fsearchRadius\x18\x0e \x01(\x03R\fsearchRadius\x12\x14\n" +
+	"\x05orgID\x18\x0f \x01(\tR\x05orgID\x12\x1a\n" +
+	"\bseverity\x18\x10 \x01(\tR\bseverity\x1aA\n" +
+	"\x13ResourceCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x91\x01\n" +
+	"\bResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x121\n" +
+	"\blocation\x18\x04 \x01(\v2\x15.disaster.CoordinatesR\blocation\x12\x1a\n" +
+	"\bdistance\x18\x05 \x01(\x01R\bdistance\"\xc9\x02\n" +
+	"\x15AssignDispatchRequest\x12\x1e\n" +
+	"\n" +
+	"disasterID\x18\x01 \x01(\tR\n" +
+	"disasterID\x12\"\n" +
+	"\fassigneeType\x18\x02 \x01(\tR\fassigneeType\x12\x1e\n" +
+	"\n" +
+	"assigneeID\x18\x03 \x01(\tR\n" +
+	"assigneeID\x12\"\n" +
+	"\fassigneeName\x18\x04 \x01(\tR\fassigneeName\x12\x14\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\x12\x18\n" +
+	"\aadminID\x18\x06 \x01(\tR\aadminID\x12\x1c\n" +
+	"\tadminRole\x18\a \x01(\tR\tadminRole\x124\n" +
+	"\fadminRegions\x18\b \x03(\v2\x10.disaster.RegionR\fadminRegions\x12$\n" +
+	"\rassigneeOrgID\x18\t \x01(\tR\rassigneeOrgID\"\xd9\x01\n" +
+	"\x1dUpdateAssignmentStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aactorID\x18\x03 \x01(\tR\aactorID\x12\x1c\n" +
+	"\tactorRole\x18\x04 \x01(\tR\tactorRole\x124\n" +
+	"\factorRegions\x18\x05 \x03(\v2\x10.disaster.RegionR\factorRegions\x12\"\n" +
+	"\factorTeamIDs\x18\x06 \x03(\tR\factorTeamIDs\"\x84\x01\n" +
+	"\x12AssignmentProgress\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1c\n" +
+	"\tchangedBy\x18\x02 \x01(\tR\tchangedBy\x128\n" +
+	"\tchangedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"\xdc\x03\n" +
+	"\n" +
+	"Assignment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"disasterID\x18\x02 \x01(\tR\n" +
+	"disasterID\x12\"\n" +
+	"\fassigneeType\x18\x03 \x01(\tR\fassigneeType\x12\x1e\n" +
+	"\n" +
+	"assigneeID\x18\x04 \x01(\tR\n" +
+	"assigneeID\x12\"\n" +
+	"\fassigneeName\x18\x05 \x01(\tR\fassigneeName\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x12\x14\n" +
+	"\x05notes\x18\b \x01(\tR\x05notes\x12\x1e\n" +
+	"\n" +
+	"assignedBy\x18\t \x01(\tR\n" +
+	"assignedBy\x126\n" +
+	"\ahistory\x18\n" +
+	" \x03(\v2\x1c.disaster.AssignmentProgressR\ahistory\x128\n" +
+	"\tcreatedAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\rassigneeOrgID\x18\r \x01(\tR\rassigneeOrgID\"9\n" +
+	"\x17GetDispatchBoardRequest\x12\x1e\n" +
+	"\n" +
+	"disasterID\x18\x01 \x01(\tR\n" +
+	"disasterID\"R\n" +
+	"\x18GetDispatchBoardResponse\x126\n" +
+	"\vassignments\x18\x01 \x03(\v2\x14.disaster.AssignmentR\vassignments\"\x96\x01\n" +
+	"\x16ListAssignmentsRequest\x12\"\n" +
+	"\fassigneeType\x18\x01 \x01(\tR\fassigneeType\x12\x1e\n" +
+	"\n" +
+	"assigneeID\x18\x02 \x01(\tR\n" +
+	"assigneeID\x12\x1e\n" +
+	"\n" +
+	"activeOnly\x18\x03 \x01(\bR\n" +
+	"activeOnly\x12\x18\n" +
+	"\ateamIDs\x18\x04 \x03(\tR\ateamIDs\"Q\n" +
+	"\x17ListAssignmentsResponse\x126\n" +
+	"\vassignments\x18\x01 \x03(\v2\x14.disaster.AssignmentR\vassignments2\xae\x05\n" +
 	"\x0fDisasterService\x12S\n" +
 	"\x0eReportDisaster\x12\x1f.disaster.ReportDisasterRequest\x1a .disaster.ReportDisasterResponse\x12J\n" +
 	"\vGetDisaster\x12\x1c.disaster.GetDisasterRequest\x1a\x1d.disaster.GetDisasterResponse\x12S\n" +
 	"\x0eReviewDisaster\x12\x1f.disaster.ReviewDisasterRequest\x1a .disaster.ReviewDisasterResponse\x12P\n" +
-	"\rListDisasters\x12\x1e.disaster.ListDisastersRequest\x1a\x1f.disaster.ListDisastersResponseB Z\x1eshared/proto/disaster;disasterb\x06proto3"
+	"\rListDisasters\x12\x1e.disaster.ListDisastersRequest\x1a\x1f.disaster.ListDisastersResponse\x12G\n" +
+	"\x0eAssignDispatch\x12\x1f.disaster.AssignDispatchRequest\x1a\x14.disaster.Assignment\x12W\n" +
+	"\x16UpdateAssignmentStatus\x12'.disaster.UpdateAssignmentStatusRequest\x1a\x14.disaster.Assignment\x12Y\n" +
+	"\x10GetDispatchBoard\x12!.disaster.GetDispatchBoardRequest\x1a\".disaster.GetDispatchBoardResponse\x12V\n" +
+	"\x0fListAssignments\x12 .disaster.ListAssignmentsRequest\x1a!.disaster.ListAssignmentsResponseB Z\x1eshared/proto/disaster;disasterb\x06proto3"
 
 var (
 	file_disaster_proto_rawDescOnce sync.Once
@@ -713,41 +1603,73 @@ func file_disaster_proto_rawDescGZIP() []byte {
 	return file_disaster_proto_rawDescData
 }
 
-var file_disaster_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_disaster_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_disaster_proto_goTypes = []any{
-	(*ListDisastersRequest)(nil),   // 0: disaster.ListDisastersRequest
-	(*ListDisastersResponse)(nil),  // 1: disaster.ListDisastersResponse
-	(*ReviewDisasterRequest)(nil),  // 2: disaster.ReviewDisasterRequest
-	(*ReviewDisasterResponse)(nil), // 3: disaster.ReviewDisasterResponse
-	(*ReportDisasterRequest)(nil),  // 4: disaster.ReportDisasterRequest
-	(*Coordinates)(nil),            // 5: disaster.Coordinates
-	(*ReportDisasterResponse)(nil), // 6: disaster.ReportDisasterResponse
-	(*GetDisasterRequest)(nil),     // 7: disaster.GetDisasterRequest
-	(*GetDisasterResponse)(nil),    // 8: disaster.GetDisasterResponse
-	(*Resource)(nil),               // 9: disaster.Resource
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+	(*ListDisastersRequest)(nil),          // 0: disaster.ListDisastersRequest
+	(*Bounds)(nil),                        // 1: disaster.Bounds
+	(*ListDisastersResponse)(nil),         // 2: disaster.ListDisastersResponse
+	(*ReviewDisasterRequest)(nil),         // 3: disaster.ReviewDisasterRequest
+	(*ReviewDisasterResponse)(nil),        // 4: disaster.ReviewDisasterResponse
+	(*ReportDisasterRequest)(nil),         // 5: disaster.ReportDisasterRequest
+	(*Coordinates)(nil),                   // 6: disaster.Coordinates
+	(*Region)(nil),                        // 7: disaster.Region
+	(*ReportDisasterResponse)(nil),        // 8: disaster.ReportDisasterResponse
+	(*GetDisasterRequest)(nil),            // 9: disaster.GetDisasterRequest
+	(*GetDisasterResponse)(nil),           // 10: disaster.GetDisasterResponse
+	(*Resource)(nil),                      // 11: disaster.Resource
+	(*AssignDispatchRequest)(nil),         // 12: disaster.AssignDispatchRequest
+	(*UpdateAssignmentStatusRequest)(nil), // 13: disaster.UpdateAssignmentStatusRequest
+	(*AssignmentProgress)(nil),            // 14: disaster.AssignmentProgress
+	(*Assignment)(nil),                    // 15: disaster.Assignment
+	(*GetDispatchBoardRequest)(nil),       // 16: disaster.GetDispatchBoardRequest
+	(*GetDispatchBoardResponse)(nil),      // 17: disaster.GetDispatchBoardResponse
+	(*ListAssignmentsRequest)(nil),        // 18: disaster.ListAssignmentsRequest
+	(*ListAssignmentsResponse)(nil),       // 19: disaster.ListAssignmentsResponse
+	nil,                                   // 20: disaster.GetDisasterResponse.ResourceCountsEntry
+	(*timestamppb.Timestamp)(nil),         // 21: google.protobuf.Timestamp
 }
 var file_disaster_proto_depIdxs = []int32{
-	8,  // 0: disaster.ListDisastersResponse.disasters:type_name -> disaster.GetDisasterResponse
-	5,  // 1: disaster.ReportDisasterRequest.location:type_name -> disaster.Coordinates
-	5,  // 2: disaster.GetDisasterResponse.location:type_name -> disaster.Coordinates
-	10, // 3: disaster.GetDisasterResponse.createdAt:type_name -> google.protobuf.Timestamp
-	10, // 4: disaster.GetDisasterResponse.updatedAt:type_name -> google.protobuf.Timestamp
-	9,  // 5: disaster.GetDisasterResponse.resources:type_name -> disaster.Resource
-	5,  // 6: disaster.Resource.location:type_name -> disaster.Coordinates
-	4,  // 7: disaster.DisasterService.ReportDisaster:input_type -> disaster.ReportDisasterRequest
-	7,  // 8: disaster.DisasterService.GetDisaster:input_type -> disaster.GetDisasterRequest
-	2,  // 9: disaster.DisasterService.ReviewDisaster:input_type -> disaster.ReviewDisasterRequest
-	0,  // 10: disaster.DisasterService.ListDisasters:input_type -> disaster.ListDisastersRequest
-	6,  // 11: disaster.DisasterService.ReportDisaster:output_type -> disaster.ReportDisasterResponse
-	8,  // 12: disaster.DisasterService.GetDisaster:output_type -> disaster.GetDisasterResponse
-	3,  // 13: disaster.DisasterService.ReviewDisaster:output_type -> disaster.ReviewDisasterResponse
-	1,  // 14: disaster.DisasterService.ListDisasters:output_type -> disaster.ListDisastersResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	1,  // 0: disaster.ListDisastersRequest.bounds:type_name -> disaster.Bounds
+	10, // 1: disaster.ListDisastersResponse.disasters:type_name -> disaster.GetDisasterResponse
+	7,  // 2: disaster.ReviewDisasterRequest.adminRegions:type_name -> disaster.Region
+	6,  // 3: disaster.ReportDisasterRequest.location:type_name -> disaster.Coordinates
+	6,  // 4: disaster.Region.polygon:type_name -> disaster.Coordinates
+	6,  // 5: disaster.GetDisasterResponse.location:type_name -> disaster.Coordinates
+	21, // 6: disaster.GetDisasterResponse.createdAt:type_name -> google.protobuf.Timestamp
+	21, // 7: disaster.GetDisasterResponse.updatedAt:type_name -> google.protobuf.Timestamp
+	11, // 8: disaster.GetDisasterResponse.resources:type_name -> disaster.Resource
+	20, // 9: disaster.GetDisasterResponse.resourceCounts:type_name -> disaster.GetDisasterResponse.ResourceCountsEntry
+	21, // 10: disaster.GetDisasterResponse.resourcesFoundAt:type_name -> google.protobuf.Timestamp
+	6,  // 11: disaster.Resource.location:type_name -> disaster.Coordinates
+	7,  // 12: disaster.AssignDispatchRequest.adminRegions:type_name -> disaster.Region
+	7,  // 13: disaster.UpdateAssignmentStatusRequest.actorRegions:type_name -> disaster.Region
+	21, // 14: disaster.AssignmentProgress.changedAt:type_name -> google.protobuf.Timestamp
+	14, // 15: disaster.Assignment.history:type_name -> disaster.AssignmentProgress
+	21, // 16: disaster.Assignment.createdAt:type_name -> google.protobuf.Timestamp
+	21, // 17: disaster.Assignment.updatedAt:type_name -> google.protobuf.Timestamp
+	15, // 18: disaster.GetDispatchBoardResponse.assignments:type_name -> disaster.Assignment
+	15, // 19: disaster.ListAssignmentsResponse.assignments:type_name -> disaster.Assignment
+	5,  // 20: disaster.DisasterService.ReportDisaster:input_type -> disaster.ReportDisasterRequest
+	9,  // 21: disaster.DisasterService.GetDisaster:input_type -> disaster.GetDisasterRequest
+	3,  // 22: disaster.DisasterService.ReviewDisaster:input_type -> disaster.ReviewDisasterRequest
+	0,  // 23: disaster.DisasterService.ListDisasters:input_type -> disaster.ListDisastersRequest
+	12, // 24: disaster.DisasterService.AssignDispatch:input_type -> disaster.AssignDispatchRequest
+	13, // 25: disaster.DisasterService.UpdateAssignmentStatus:input_type -> disaster.UpdateAssignmentStatusRequest
+	16, // 26: disaster.DisasterService.GetDispatchBoard:input_type -> disaster.GetDispatchBoardRequest
+	18, // 27: disaster.DisasterService.ListAssignments:input_type -> disaster.ListAssignmentsRequest
+	8,  // 28: disaster.DisasterService.ReportDisaster:output_type -> disaster.ReportDisasterResponse
+	10, // 29: disaster.DisasterService.GetDisaster:output_type -> disaster.GetDisasterResponse
+	4,  // 30: disaster.DisasterService.ReviewDisaster:output_type -> disaster.ReviewDisasterResponse
+	2,  // 31: disaster.DisasterService.ListDisasters:output_type -> disaster.ListDisastersResponse
+	15, // 32: disaster.DisasterService.AssignDispatch:output_type -> disaster.Assignment
+	15, // 33: disaster.DisasterService.UpdateAssignmentStatus:output_type -> disaster.Assignment
+	17, // 34: disaster.DisasterService.GetDispatchBoard:output_type -> disaster.GetDispatchBoardResponse
+	19, // 35: disaster.DisasterService.ListAssignments:output_type -> disaster.ListAssignmentsResponse
+	28, // [28:36] is the sub-list for method output_type
+	20, // [20:28] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_disaster_proto_init() }
@@ -761,7 +1683,7 @@ func file_disaster_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_disaster_proto_rawDesc), len(file_disaster_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
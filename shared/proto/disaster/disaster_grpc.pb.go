@@ -19,10 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DisasterService_ReportDisaster_FullMethodName = "/disaster.DisasterService/ReportDisaster"
-	DisasterService_GetDisaster_FullMethodName    = "/disaster.DisasterService/GetDisaster"
-	DisasterService_ReviewDisaster_FullMethodName = "/disaster.DisasterService/ReviewDisaster"
-	DisasterService_ListDisasters_FullMethodName  = "/disaster.DisasterService/ListDisasters"
+	DisasterService_ReportDisaster_FullMethodName         = "/disaster.DisasterService/ReportDisaster"
+	DisasterService_GetDisaster_FullMethodName            = "/disaster.DisasterService/GetDisaster"
+	DisasterService_ReviewDisaster_FullMethodName         = "/disaster.DisasterService/ReviewDisaster"
+	DisasterService_ListDisasters_FullMethodName          = "/disaster.DisasterService/ListDisasters"
+	DisasterService_AssignDispatch_FullMethodName         = "/disaster.DisasterService/AssignDispatch"
+	DisasterService_UpdateAssignmentStatus_FullMethodName = "/disaster.DisasterService/UpdateAssignmentStatus"
+	DisasterService_GetDispatchBoard_FullMethodName       = "/disaster.DisasterService/GetDispatchBoard"
+	DisasterService_ListAssignments_FullMethodName        = "/disaster.DisasterService/ListAssignments"
 )
 
 // DisasterServiceClient is the client API for DisasterService service.
@@ -33,6 +37,10 @@ type DisasterServiceClient interface {
 	GetDisaster(ctx context.Context, in *GetDisasterRequest, opts ...grpc.CallOption) (*GetDisasterResponse, error)
 	ReviewDisaster(ctx context.Context, in *ReviewDisasterRequest, opts ...grpc.CallOption) (*ReviewDisasterResponse, error)
 	ListDisasters(ctx context.Context, in *ListDisastersRequest, opts ...grpc.CallOption) (*ListDisastersResponse, error)
+	AssignDispatch(ctx context.Context, in *AssignDispatchRequest, opts ...grpc.CallOption) (*Assignment, error)
+	UpdateAssignmentStatus(ctx context.Context, in *UpdateAssignmentStatusRequest, opts ...grpc.CallOption) (*Assignment, error)
+	GetDispatchBoard(ctx context.Context, in *GetDispatchBoardRequest, opts ...grpc.CallOption) (*GetDispatchBoardResponse, error)
+	ListAssignments(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error)
 }
 
 type disasterServiceClient struct {
@@ -83,6 +91,46 @@ func (c *disasterServiceClient) ListDisasters(ctx context.Context, in *ListDisas
 	return out, nil
 }
 
+func (c *disasterServiceClient) AssignDispatch(ctx context.Context, in *AssignDispatchRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, DisasterService_AssignDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disasterServiceClient) UpdateAssignmentStatus(ctx context.Context, in *UpdateAssignmentStatusRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, DisasterService_UpdateAssignmentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disasterServiceClient) GetDispatchBoard(ctx context.Context, in *GetDispatchBoardRequest, opts ...grpc.CallOption) (*GetDispatchBoardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDispatchBoardResponse)
+	err := c.cc.Invoke(ctx, DisasterService_GetDispatchBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disasterServiceClient) ListAssignments(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssignmentsResponse)
+	err := c.cc.Invoke(ctx, DisasterService_ListAssignments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisasterServiceServer is the server API for DisasterService service.
 // All implementations must embed UnimplementedDisasterServiceServer
 // for forward compatibility.
@@ -91,6 +139,10 @@ type DisasterServiceServer interface {
 	GetDisaster(context.Context, *GetDisasterRequest) (*GetDisasterResponse, error)
 	ReviewDisaster(context.Context, *ReviewDisasterRequest) (*ReviewDisasterResponse, error)
 	ListDisasters(context.Context, *ListDisastersRequest) (*ListDisastersResponse, error)
+	AssignDispatch(context.Context, *AssignDispatchRequest) (*Assignment, error)
+	UpdateAssignmentStatus(context.Context, *UpdateAssignmentStatusRequest) (*Assignment, error)
+	GetDispatchBoard(context.Context, *GetDispatchBoardRequest) (*GetDispatchBoardResponse, error)
+	ListAssignments(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error)
 	mustEmbedUnimplementedDisasterServiceServer()
 }
 
@@ -113,6 +165,18 @@ func (UnimplementedDisasterServiceServer) ReviewDisaster(context.Context, *Revie
 func (UnimplementedDisasterServiceServer) ListDisasters(context.Context, *ListDisastersRequest) (*ListDisastersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDisasters not implemented")
 }
+func (UnimplementedDisasterServiceServer) AssignDispatch(context.Context, *AssignDispatchRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignDispatch not implemented")
+}
+func (UnimplementedDisasterServiceServer) UpdateAssignmentStatus(context.Context, *UpdateAssignmentStatusRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssignmentStatus not implemented")
+}
+func (UnimplementedDisasterServiceServer) GetDispatchBoard(context.Context, *GetDispatchBoardRequest) (*GetDispatchBoardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispatchBoard not implemented")
+}
+func (UnimplementedDisasterServiceServer) ListAssignments(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignments not implemented")
+}
 func (UnimplementedDisasterServiceServer) mustEmbedUnimplementedDisasterServiceServer() {}
 func (UnimplementedDisasterServiceServer) testEmbeddedByValue()                         {}
 
@@ -206,6 +270,78 @@ func _DisasterService_ListDisasters_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DisasterService_AssignDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignDispatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisasterServiceServer).AssignDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisasterService_AssignDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisasterServiceServer).AssignDispatch(ctx, req.(*AssignDispatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisasterService_UpdateAssignmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssignmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisasterServiceServer).UpdateAssignmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisasterService_UpdateAssignmentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisasterServiceServer).UpdateAssignmentStatus(ctx, req.(*UpdateAssignmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisasterService_GetDispatchBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDispatchBoardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisasterServiceServer).GetDispatchBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisasterService_GetDispatchBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisasterServiceServer).GetDispatchBoard(ctx, req.(*GetDispatchBoardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisasterService_ListAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisasterServiceServer).ListAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisasterService_ListAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisasterServiceServer).ListAssignments(ctx, req.(*ListAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DisasterService_ServiceDesc is the grpc.ServiceDesc for DisasterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +365,22 @@ var DisasterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDisasters",
 			Handler:    _DisasterService_ListDisasters_Handler,
 		},
+		{
+			MethodName: "AssignDispatch",
+			Handler:    _DisasterService_AssignDispatch_Handler,
+		},
+		{
+			MethodName: "UpdateAssignmentStatus",
+			Handler:    _DisasterService_UpdateAssignmentStatus_Handler,
+		},
+		{
+			MethodName: "GetDispatchBoard",
+			Handler:    _DisasterService_GetDispatchBoard_Handler,
+		},
+		{
+			MethodName: "ListAssignments",
+			Handler:    _DisasterService_ListAssignments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "disaster.proto",
@@ -9,6 +9,7 @@ package resource
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,6 +26,10 @@ type GetResourcesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Location      *Coordinates           `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
 	Within        int64                  `protobuf:"varint,2,opt,name=within,proto3" json:"within,omitempty"`
+	Categories    []string               `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	PerCategory   int32                  `protobuf:"varint,4,opt,name=per_category,json=perCategory,proto3" json:"per_category,omitempty"` // nearest N resources per category, 0 for no per-category limit
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +78,206 @@ func (x *GetResourcesRequest) GetWithin() int64 {
 	return 0
 }
 
+func (x *GetResourcesRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetResourcesRequest) GetPerCategory() int32 {
+	if x != nil {
+		return x.PerCategory
+	}
+	return 0
+}
+
+func (x *GetResourcesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetResourcesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type Bounds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLat        float64                `protobuf:"fixed64,1,opt,name=min_lat,json=minLat,proto3" json:"min_lat,omitempty"`
+	MinLon        float64                `protobuf:"fixed64,2,opt,name=min_lon,json=minLon,proto3" json:"min_lon,omitempty"`
+	MaxLat        float64                `protobuf:"fixed64,3,opt,name=max_lat,json=maxLat,proto3" json:"max_lat,omitempty"`
+	MaxLon        float64                `protobuf:"fixed64,4,opt,name=max_lon,json=maxLon,proto3" json:"max_lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bounds) Reset() {
+	*x = Bounds{}
+	mi := &file_resource_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bounds) ProtoMessage() {}
+
+func (x *Bounds) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bounds.ProtoReflect.Descriptor instead.
+func (*Bounds) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Bounds) GetMinLat() float64 {
+	if x != nil {
+		return x.MinLat
+	}
+	return 0
+}
+
+func (x *Bounds) GetMinLon() float64 {
+	if x != nil {
+		return x.MinLon
+	}
+	return 0
+}
+
+func (x *Bounds) GetMaxLat() float64 {
+	if x != nil {
+		return x.MaxLat
+	}
+	return 0
+}
+
+func (x *Bounds) GetMaxLon() float64 {
+	if x != nil {
+		return x.MaxLon
+	}
+	return 0
+}
+
+type GetResourcesInBoundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bounds        *Bounds                `protobuf:"bytes,1,opt,name=bounds,proto3" json:"bounds,omitempty"`
+	Categories    []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourcesInBoundsRequest) Reset() {
+	*x = GetResourcesInBoundsRequest{}
+	mi := &file_resource_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourcesInBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourcesInBoundsRequest) ProtoMessage() {}
+
+func (x *GetResourcesInBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourcesInBoundsRequest.ProtoReflect.Descriptor instead.
+func (*GetResourcesInBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetResourcesInBoundsRequest) GetBounds() *Bounds {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+func (x *GetResourcesInBoundsRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetResourcesInBoundsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourceRequest) Reset() {
+	*x = GetResourceRequest{}
+	mi := &file_resource_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourceRequest) ProtoMessage() {}
+
+func (x *GetResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourceRequest.ProtoReflect.Descriptor instead.
+func (*GetResourceRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetResourceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type GetResourcesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Resources     []*Resource            `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
@@ -82,7 +287,7 @@ type GetResourcesResponse struct {
 
 func (x *GetResourcesResponse) Reset() {
 	*x = GetResourcesResponse{}
-	mi := &file_resource_proto_msgTypes[1]
+	mi := &file_resource_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +299,7 @@ func (x *GetResourcesResponse) String() string {
 func (*GetResourcesResponse) ProtoMessage() {}
 
 func (x *GetResourcesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_proto_msgTypes[1]
+	mi := &file_resource_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +312,7 @@ func (x *GetResourcesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourcesResponse.ProtoReflect.Descriptor instead.
 func (*GetResourcesResponse) Descriptor() ([]byte, []int) {
-	return file_resource_proto_rawDescGZIP(), []int{1}
+	return file_resource_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetResourcesResponse) GetResources() []*Resource {
@@ -127,7 +332,7 @@ type Coordinates struct {
 
 func (x *Coordinates) Reset() {
 	*x = Coordinates{}
-	mi := &file_resource_proto_msgTypes[2]
+	mi := &file_resource_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -139,7 +344,7 @@ func (x *Coordinates) String() string {
 func (*Coordinates) ProtoMessage() {}
 
 func (x *Coordinates) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_proto_msgTypes[2]
+	mi := &file_resource_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -152,7 +357,7 @@ func (x *Coordinates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
 func (*Coordinates) Descriptor() ([]byte, []int) {
-	return file_resource_proto_rawDescGZIP(), []int{2}
+	return file_resource_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Coordinates) GetLongitude() float64 {
@@ -175,13 +380,17 @@ type Resource struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	AmenityType   string                 `protobuf:"bytes,3,opt,name=amenity_type,json=amenityType,proto3" json:"amenity_type,omitempty"`
 	Location      *Coordinates           `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Distance      float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"`                                                                            // meters from the requested location
+	Inventory     map[string]int64       `protobuf:"bytes,6,rep,name=inventory,proto3" json:"inventory,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // stock per supply item
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                                                                  // open or closed
+	OrgId         string                 `protobuf:"bytes,8,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`                                                                       // organization running the resource, if any
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_resource_proto_msgTypes[3]
+	mi := &file_resource_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -193,7 +402,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_resource_proto_msgTypes[3]
+	mi := &file_resource_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +415,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_resource_proto_rawDescGZIP(), []int{3}
+	return file_resource_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Resource) GetId() string {
@@ -237,26 +446,1932 @@ func (x *Resource) GetLocation() *Coordinates {
 	return nil
 }
 
-var File_resource_proto protoreflect.FileDescriptor
+func (x *Resource) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
 
-const file_resource_proto_rawDesc = "" +
-	"\n" +
-	"\x0eresource.proto\x12\bresource\"`\n" +
-	"\x13GetResourcesRequest\x121\n" +
-	"\blocation\x18\x01 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x16\n" +
-	"\x06within\x18\x02 \x01(\x03R\x06within\"H\n" +
-	"\x14GetResourcesResponse\x120\n" +
-	"\tresources\x18\x01 \x03(\v2\x12.resource.ResourceR\tresources\"G\n" +
-	"\vCoordinates\x12\x1c\n" +
-	"\tlongitude\x18\x01 \x01(\x01R\tlongitude\x12\x1a\n" +
-	"\blatitude\x18\x02 \x01(\x01R\blatitude\"\x84\x01\n" +
-	"\bResource\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
-	"\famenity_type\x18\x03 \x01(\tR\vamenityType\x121\n" +
-	"\blocation\x18\x04 \x01(\v2\x15.resource.CoordinatesR\blocation2f\n" +
-	"\x0fResourceService\x12S\n" +
-	"\x12GetNearbyResources\x12\x1d.resource.GetResourcesRequest\x1a\x1e.resource.GetResourcesResponseB Z\x1eshared/proto/resource;resourceb\x06proto3"
+func (x *Resource) GetInventory() map[string]int64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *Resource) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Resource) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type RankResourcesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Origin          *Coordinates           `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Within          int64                  `protobuf:"varint,2,opt,name=within,proto3" json:"within,omitempty"`
+	Categories      []string               `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	PerCategory     int32                  `protobuf:"varint,4,opt,name=per_category,json=perCategory,proto3" json:"per_category,omitempty"`
+	Limit           int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	IncludeGeometry bool                   `protobuf:"varint,7,opt,name=include_geometry,json=includeGeometry,proto3" json:"include_geometry,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RankResourcesRequest) Reset() {
+	*x = RankResourcesRequest{}
+	mi := &file_resource_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankResourcesRequest) ProtoMessage() {}
+
+func (x *RankResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankResourcesRequest.ProtoReflect.Descriptor instead.
+func (*RankResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RankResourcesRequest) GetOrigin() *Coordinates {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *RankResourcesRequest) GetWithin() int64 {
+	if x != nil {
+		return x.Within
+	}
+	return 0
+}
+
+func (x *RankResourcesRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *RankResourcesRequest) GetPerCategory() int32 {
+	if x != nil {
+		return x.PerCategory
+	}
+	return 0
+}
+
+func (x *RankResourcesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RankResourcesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *RankResourcesRequest) GetIncludeGeometry() bool {
+	if x != nil {
+		return x.IncludeGeometry
+	}
+	return false
+}
+
+type RankedResource struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Resource       *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Reachable      bool                   `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	TravelDistance float64                `protobuf:"fixed64,3,opt,name=travel_distance,json=travelDistance,proto3" json:"travel_distance,omitempty"` // meters along the road network
+	TravelTime     float64                `protobuf:"fixed64,4,opt,name=travel_time,json=travelTime,proto3" json:"travel_time,omitempty"`             // seconds
+	Geometry       []*Coordinates         `protobuf:"bytes,5,rep,name=geometry,proto3" json:"geometry,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RankedResource) Reset() {
+	*x = RankedResource{}
+	mi := &file_resource_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedResource) ProtoMessage() {}
+
+func (x *RankedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedResource.ProtoReflect.Descriptor instead.
+func (*RankedResource) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RankedResource) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *RankedResource) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *RankedResource) GetTravelDistance() float64 {
+	if x != nil {
+		return x.TravelDistance
+	}
+	return 0
+}
+
+func (x *RankedResource) GetTravelTime() float64 {
+	if x != nil {
+		return x.TravelTime
+	}
+	return 0
+}
+
+func (x *RankedResource) GetGeometry() []*Coordinates {
+	if x != nil {
+		return x.Geometry
+	}
+	return nil
+}
+
+type RankResourcesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resources     []*RankedResource      `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankResourcesResponse) Reset() {
+	*x = RankResourcesResponse{}
+	mi := &file_resource_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankResourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankResourcesResponse) ProtoMessage() {}
+
+func (x *RankResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankResourcesResponse.ProtoReflect.Descriptor instead.
+func (*RankResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RankResourcesResponse) GetResources() []*RankedResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type BlockRoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WayId         int64                  `protobuf:"varint,1,opt,name=way_id,json=wayId,proto3" json:"way_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	AdminId       string                 `protobuf:"bytes,3,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockRoadRequest) Reset() {
+	*x = BlockRoadRequest{}
+	mi := &file_resource_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRoadRequest) ProtoMessage() {}
+
+func (x *BlockRoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRoadRequest.ProtoReflect.Descriptor instead.
+func (*BlockRoadRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlockRoadRequest) GetWayId() int64 {
+	if x != nil {
+		return x.WayId
+	}
+	return 0
+}
+
+func (x *BlockRoadRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BlockRoadRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+type BlockedRoad struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WayId         int64                  `protobuf:"varint,1,opt,name=way_id,json=wayId,proto3" json:"way_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	BlockedBy     string                 `protobuf:"bytes,3,opt,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedRoad) Reset() {
+	*x = BlockedRoad{}
+	mi := &file_resource_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedRoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedRoad) ProtoMessage() {}
+
+func (x *BlockedRoad) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedRoad.ProtoReflect.Descriptor instead.
+func (*BlockedRoad) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BlockedRoad) GetWayId() int64 {
+	if x != nil {
+		return x.WayId
+	}
+	return 0
+}
+
+func (x *BlockedRoad) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BlockedRoad) GetBlockedBy() string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return ""
+}
+
+func (x *BlockedRoad) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type UnblockRoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WayId         int64                  `protobuf:"varint,1,opt,name=way_id,json=wayId,proto3" json:"way_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockRoadRequest) Reset() {
+	*x = UnblockRoadRequest{}
+	mi := &file_resource_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockRoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockRoadRequest) ProtoMessage() {}
+
+func (x *UnblockRoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockRoadRequest.ProtoReflect.Descriptor instead.
+func (*UnblockRoadRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnblockRoadRequest) GetWayId() int64 {
+	if x != nil {
+		return x.WayId
+	}
+	return 0
+}
+
+type UnblockRoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WayId         int64                  `protobuf:"varint,1,opt,name=way_id,json=wayId,proto3" json:"way_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockRoadResponse) Reset() {
+	*x = UnblockRoadResponse{}
+	mi := &file_resource_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockRoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockRoadResponse) ProtoMessage() {}
+
+func (x *UnblockRoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockRoadResponse.ProtoReflect.Descriptor instead.
+func (*UnblockRoadResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnblockRoadResponse) GetWayId() int64 {
+	if x != nil {
+		return x.WayId
+	}
+	return 0
+}
+
+type ListBlockedRoadsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRoadsRequest) Reset() {
+	*x = ListBlockedRoadsRequest{}
+	mi := &file_resource_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRoadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRoadsRequest) ProtoMessage() {}
+
+func (x *ListBlockedRoadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRoadsRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRoadsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{14}
+}
+
+type ListBlockedRoadsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roads         []*BlockedRoad         `protobuf:"bytes,1,rep,name=roads,proto3" json:"roads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRoadsResponse) Reset() {
+	*x = ListBlockedRoadsResponse{}
+	mi := &file_resource_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRoadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRoadsResponse) ProtoMessage() {}
+
+func (x *ListBlockedRoadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRoadsResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedRoadsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListBlockedRoadsResponse) GetRoads() []*BlockedRoad {
+	if x != nil {
+		return x.Roads
+	}
+	return nil
+}
+
+type CreateResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AmenityType   string                 `protobuf:"bytes,2,opt,name=amenity_type,json=amenityType,proto3" json:"amenity_type,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Inventory     map[string]int64       `protobuf:"bytes,4,rep,name=inventory,proto3" json:"inventory,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	OrgId         string                 `protobuf:"bytes,5,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResourceRequest) Reset() {
+	*x = CreateResourceRequest{}
+	mi := &file_resource_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResourceRequest) ProtoMessage() {}
+
+func (x *CreateResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResourceRequest.ProtoReflect.Descriptor instead.
+func (*CreateResourceRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateResourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateResourceRequest) GetAmenityType() string {
+	if x != nil {
+		return x.AmenityType
+	}
+	return ""
+}
+
+func (x *CreateResourceRequest) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CreateResourceRequest) GetInventory() map[string]int64 {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *CreateResourceRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type SetInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResourceId    string                 `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Item          string                 `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInventoryRequest) Reset() {
+	*x = SetInventoryRequest{}
+	mi := &file_resource_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInventoryRequest) ProtoMessage() {}
+
+func (x *SetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*SetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetInventoryRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *SetInventoryRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *SetInventoryRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type DeclareNeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterId    string                 `protobuf:"bytes,1,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Item          string                 `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Urgency       string                 `protobuf:"bytes,5,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	AdminId       string                 `protobuf:"bytes,6,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclareNeedRequest) Reset() {
+	*x = DeclareNeedRequest{}
+	mi := &file_resource_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclareNeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclareNeedRequest) ProtoMessage() {}
+
+func (x *DeclareNeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclareNeedRequest.ProtoReflect.Descriptor instead.
+func (*DeclareNeedRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeclareNeedRequest) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *DeclareNeedRequest) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *DeclareNeedRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *DeclareNeedRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *DeclareNeedRequest) GetUrgency() string {
+	if x != nil {
+		return x.Urgency
+	}
+	return ""
+}
+
+func (x *DeclareNeedRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+type Need struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisasterId    string                 `protobuf:"bytes,2,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Item          string                 `protobuf:"bytes,4,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Committed     int64                  `protobuf:"varint,6,opt,name=committed,proto3" json:"committed,omitempty"`
+	Urgency       string                 `protobuf:"bytes,7,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Need) Reset() {
+	*x = Need{}
+	mi := &file_resource_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Need) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Need) ProtoMessage() {}
+
+func (x *Need) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Need.ProtoReflect.Descriptor instead.
+func (*Need) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Need) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Need) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *Need) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Need) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *Need) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Need) GetCommitted() int64 {
+	if x != nil {
+		return x.Committed
+	}
+	return 0
+}
+
+func (x *Need) GetUrgency() string {
+	if x != nil {
+		return x.Urgency
+	}
+	return ""
+}
+
+func (x *Need) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Need) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Need) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListNeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterId    string                 `protobuf:"bytes,1,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNeedsRequest) Reset() {
+	*x = ListNeedsRequest{}
+	mi := &file_resource_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNeedsRequest) ProtoMessage() {}
+
+func (x *ListNeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNeedsRequest.ProtoReflect.Descriptor instead.
+func (*ListNeedsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListNeedsRequest) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+type ListNeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Needs         []*Need                `protobuf:"bytes,1,rep,name=needs,proto3" json:"needs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNeedsResponse) Reset() {
+	*x = ListNeedsResponse{}
+	mi := &file_resource_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNeedsResponse) ProtoMessage() {}
+
+func (x *ListNeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNeedsResponse.ProtoReflect.Descriptor instead.
+func (*ListNeedsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListNeedsResponse) GetNeeds() []*Need {
+	if x != nil {
+		return x.Needs
+	}
+	return nil
+}
+
+type MatchNeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterId    string                 `protobuf:"bytes,1,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	Within        int64                  `protobuf:"varint,2,opt,name=within,proto3" json:"within,omitempty"` // depot search radius in meters, 0 for the default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchNeedsRequest) Reset() {
+	*x = MatchNeedsRequest{}
+	mi := &file_resource_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchNeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchNeedsRequest) ProtoMessage() {}
+
+func (x *MatchNeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchNeedsRequest.ProtoReflect.Descriptor instead.
+func (*MatchNeedsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MatchNeedsRequest) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *MatchNeedsRequest) GetWithin() int64 {
+	if x != nil {
+		return x.Within
+	}
+	return 0
+}
+
+type SupplyProposal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NeedId        string                 `protobuf:"bytes,1,opt,name=need_id,json=needId,proto3" json:"need_id,omitempty"`
+	DisasterId    string                 `protobuf:"bytes,2,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	Depot         *Resource              `protobuf:"bytes,3,opt,name=depot,proto3" json:"depot,omitempty"`
+	Item          string                 `protobuf:"bytes,4,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Distance      float64                `protobuf:"fixed64,6,opt,name=distance,proto3" json:"distance,omitempty"` // meters from depot to disaster
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SupplyProposal) Reset() {
+	*x = SupplyProposal{}
+	mi := &file_resource_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupplyProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupplyProposal) ProtoMessage() {}
+
+func (x *SupplyProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupplyProposal.ProtoReflect.Descriptor instead.
+func (*SupplyProposal) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SupplyProposal) GetNeedId() string {
+	if x != nil {
+		return x.NeedId
+	}
+	return ""
+}
+
+func (x *SupplyProposal) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *SupplyProposal) GetDepot() *Resource {
+	if x != nil {
+		return x.Depot
+	}
+	return nil
+}
+
+func (x *SupplyProposal) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *SupplyProposal) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SupplyProposal) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type MatchNeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proposals     []*SupplyProposal      `protobuf:"bytes,1,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchNeedsResponse) Reset() {
+	*x = MatchNeedsResponse{}
+	mi := &file_resource_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchNeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchNeedsResponse) ProtoMessage() {}
+
+func (x *MatchNeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchNeedsResponse.ProtoReflect.Descriptor instead.
+func (*MatchNeedsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MatchNeedsResponse) GetProposals() []*SupplyProposal {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+type CommitSupplyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisasterId    string                 `protobuf:"bytes,1,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	NeedId        string                 `protobuf:"bytes,2,opt,name=need_id,json=needId,proto3" json:"need_id,omitempty"`
+	DepotId       string                 `protobuf:"bytes,3,opt,name=depot_id,json=depotId,proto3" json:"depot_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AdminId       string                 `protobuf:"bytes,5,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitSupplyRequest) Reset() {
+	*x = CommitSupplyRequest{}
+	mi := &file_resource_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitSupplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitSupplyRequest) ProtoMessage() {}
+
+func (x *CommitSupplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitSupplyRequest.ProtoReflect.Descriptor instead.
+func (*CommitSupplyRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CommitSupplyRequest) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *CommitSupplyRequest) GetNeedId() string {
+	if x != nil {
+		return x.NeedId
+	}
+	return ""
+}
+
+func (x *CommitSupplyRequest) GetDepotId() string {
+	if x != nil {
+		return x.DepotId
+	}
+	return ""
+}
+
+func (x *CommitSupplyRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CommitSupplyRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+type SupplyCommitment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NeedId        string                 `protobuf:"bytes,2,opt,name=need_id,json=needId,proto3" json:"need_id,omitempty"`
+	DisasterId    string                 `protobuf:"bytes,3,opt,name=disaster_id,json=disasterId,proto3" json:"disaster_id,omitempty"`
+	DepotId       string                 `protobuf:"bytes,4,opt,name=depot_id,json=depotId,proto3" json:"depot_id,omitempty"`
+	Item          string                 `protobuf:"bytes,5,opt,name=item,proto3" json:"item,omitempty"`
+	Quantity      int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Distance      float64                `protobuf:"fixed64,7,opt,name=distance,proto3" json:"distance,omitempty"`
+	CommittedBy   string                 `protobuf:"bytes,8,opt,name=committed_by,json=committedBy,proto3" json:"committed_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SupplyCommitment) Reset() {
+	*x = SupplyCommitment{}
+	mi := &file_resource_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupplyCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupplyCommitment) ProtoMessage() {}
+
+func (x *SupplyCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupplyCommitment.ProtoReflect.Descriptor instead.
+func (*SupplyCommitment) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SupplyCommitment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetNeedId() string {
+	if x != nil {
+		return x.NeedId
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetDisasterId() string {
+	if x != nil {
+		return x.DisasterId
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetDepotId() string {
+	if x != nil {
+		return x.DepotId
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SupplyCommitment) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *SupplyCommitment) GetCommittedBy() string {
+	if x != nil {
+		return x.CommittedBy
+	}
+	return ""
+}
+
+func (x *SupplyCommitment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RefreshRegion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // disaster or watch
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Within        int64                  `protobuf:"varint,5,opt,name=within,proto3" json:"within,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastRefreshAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_refresh_at,json=lastRefreshAt,proto3" json:"last_refresh_at,omitempty"`
+	LastStatus    string                 `protobuf:"bytes,8,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"` // pending, ok or failed
+	LastError     string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Found         int64                  `protobuf:"varint,10,opt,name=found,proto3" json:"found,omitempty"`
+	Closed        int64                  `protobuf:"varint,11,opt,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRegion) Reset() {
+	*x = RefreshRegion{}
+	mi := &file_resource_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRegion) ProtoMessage() {}
+
+func (x *RefreshRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRegion.ProtoReflect.Descriptor instead.
+func (*RefreshRegion) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RefreshRegion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RefreshRegion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RefreshRegion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RefreshRegion) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *RefreshRegion) GetWithin() int64 {
+	if x != nil {
+		return x.Within
+	}
+	return 0
+}
+
+func (x *RefreshRegion) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RefreshRegion) GetLastRefreshAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRefreshAt
+	}
+	return nil
+}
+
+func (x *RefreshRegion) GetLastStatus() string {
+	if x != nil {
+		return x.LastStatus
+	}
+	return ""
+}
+
+func (x *RefreshRegion) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *RefreshRegion) GetFound() int64 {
+	if x != nil {
+		return x.Found
+	}
+	return 0
+}
+
+func (x *RefreshRegion) GetClosed() int64 {
+	if x != nil {
+		return x.Closed
+	}
+	return 0
+}
+
+type ListRefreshRegionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefreshRegionsRequest) Reset() {
+	*x = ListRefreshRegionsRequest{}
+	mi := &file_resource_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefreshRegionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefreshRegionsRequest) ProtoMessage() {}
+
+func (x *ListRefreshRegionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefreshRegionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRefreshRegionsRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{28}
+}
+
+type ListRefreshRegionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Regions       []*RefreshRegion       `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefreshRegionsResponse) Reset() {
+	*x = ListRefreshRegionsResponse{}
+	mi := &file_resource_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefreshRegionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefreshRegionsResponse) ProtoMessage() {}
+
+func (x *ListRefreshRegionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefreshRegionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRefreshRegionsResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListRefreshRegionsResponse) GetRegions() []*RefreshRegion {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type WatchRegionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Within        int64                  `protobuf:"varint,3,opt,name=within,proto3" json:"within,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRegionRequest) Reset() {
+	*x = WatchRegionRequest{}
+	mi := &file_resource_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRegionRequest) ProtoMessage() {}
+
+func (x *WatchRegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRegionRequest.ProtoReflect.Descriptor instead.
+func (*WatchRegionRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchRegionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchRegionRequest) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *WatchRegionRequest) GetWithin() int64 {
+	if x != nil {
+		return x.Within
+	}
+	return 0
+}
+
+type UnwatchRegionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchRegionRequest) Reset() {
+	*x = UnwatchRegionRequest{}
+	mi := &file_resource_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchRegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchRegionRequest) ProtoMessage() {}
+
+func (x *UnwatchRegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchRegionRequest.ProtoReflect.Descriptor instead.
+func (*UnwatchRegionRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnwatchRegionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnwatchRegionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchRegionResponse) Reset() {
+	*x = UnwatchRegionResponse{}
+	mi := &file_resource_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchRegionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchRegionResponse) ProtoMessage() {}
+
+func (x *UnwatchRegionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchRegionResponse.ProtoReflect.Descriptor instead.
+func (*UnwatchRegionResponse) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnwatchRegionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TriggerRefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerRefreshRequest) Reset() {
+	*x = TriggerRefreshRequest{}
+	mi := &file_resource_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerRefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerRefreshRequest) ProtoMessage() {}
+
+func (x *TriggerRefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resource_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerRefreshRequest.ProtoReflect.Descriptor instead.
+func (*TriggerRefreshRequest) Descriptor() ([]byte, []int) {
+	return file_resource_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TriggerRefreshRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_resource_proto protoreflect.FileDescriptor
+
+const file_resource_proto_rawDesc = "" +
+	"\n" +
+	"\x0eresource.proto\x12\bresource\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd1\x01\n" +
+	"\x13GetResourcesRequest\x121\n" +
+	"\blocation\x18\x01 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x16\n" +
+	"\x06within\x18\x02 \x01(\x03R\x06within\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\tR\n" +
+	"categories\x12!\n" +
+	"\fper_category\x18\x04 \x01(\x05R\vperCategory\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\"l\n" +
+	"\x06Bounds\x12\x17\n" +
+	"\amin_lat\x18\x01 \x01(\x01R\x06minLat\x12\x17\n" +
+	"\amin_lon\x18\x02 \x01(\x01R\x06minLon\x12\x17\n" +
+	"\amax_lat\x18\x03 \x01(\x01R\x06maxLat\x12\x17\n" +
+	"\amax_lon\x18\x04 \x01(\x01R\x06maxLon\"}\n" +
+	"\x1bGetResourcesInBoundsRequest\x12(\n" +
+	"\x06bounds\x18\x01 \x01(\v2\x10.resource.BoundsR\x06bounds\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"$\n" +
+	"\x12GetResourceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x14GetResourcesResponse\x120\n" +
+	"\tresources\x18\x01 \x03(\v2\x12.resource.ResourceR\tresources\"G\n" +
+	"\vCoordinates\x12\x1c\n" +
+	"\tlongitude\x18\x01 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\"\xce\x02\n" +
+	"\bResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\famenity_type\x18\x03 \x01(\tR\vamenityType\x121\n" +
+	"\blocation\x18\x04 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x1a\n" +
+	"\bdistance\x18\x05 \x01(\x01R\bdistance\x12?\n" +
+	"\tinventory\x18\x06 \x03(\v2!.resource.Resource.InventoryEntryR\tinventory\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x15\n" +
+	"\x06org_id\x18\b \x01(\tR\x05orgId\x1a<\n" +
+	"\x0eInventoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xf9\x01\n" +
+	"\x14RankResourcesRequest\x12-\n" +
+	"\x06origin\x18\x01 \x01(\v2\x15.resource.CoordinatesR\x06origin\x12\x16\n" +
+	"\x06within\x18\x02 \x01(\x03R\x06within\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\tR\n" +
+	"categories\x12!\n" +
+	"\fper_category\x18\x04 \x01(\x05R\vperCategory\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12)\n" +
+	"\x10include_geometry\x18\a \x01(\bR\x0fincludeGeometry\"\xdb\x01\n" +
+	"\x0eRankedResource\x12.\n" +
+	"\bresource\x18\x01 \x01(\v2\x12.resource.ResourceR\bresource\x12\x1c\n" +
+	"\treachable\x18\x02 \x01(\bR\treachable\x12'\n" +
+	"\x0ftravel_distance\x18\x03 \x01(\x01R\x0etravelDistance\x12\x1f\n" +
+	"\vtravel_time\x18\x04 \x01(\x01R\n" +
+	"travelTime\x121\n" +
+	"\bgeometry\x18\x05 \x03(\v2\x15.resource.CoordinatesR\bgeometry\"O\n" +
+	"\x15RankResourcesResponse\x126\n" +
+	"\tresources\x18\x01 \x03(\v2\x18.resource.RankedResourceR\tresources\"\\\n" +
+	"\x10BlockRoadRequest\x12\x15\n" +
+	"\x06way_id\x18\x01 \x01(\x03R\x05wayId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x19\n" +
+	"\badmin_id\x18\x03 \x01(\tR\aadminId\"\x96\x01\n" +
+	"\vBlockedRoad\x12\x15\n" +
+	"\x06way_id\x18\x01 \x01(\x03R\x05wayId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x03 \x01(\tR\tblockedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"+\n" +
+	"\x12UnblockRoadRequest\x12\x15\n" +
+	"\x06way_id\x18\x01 \x01(\x03R\x05wayId\",\n" +
+	"\x13UnblockRoadResponse\x12\x15\n" +
+	"\x06way_id\x18\x01 \x01(\x03R\x05wayId\"\x19\n" +
+	"\x17ListBlockedRoadsRequest\"G\n" +
+	"\x18ListBlockedRoadsResponse\x12+\n" +
+	"\x05roads\x18\x01 \x03(\v2\x15.resource.BlockedRoadR\x05roads\"\xa4\x02\n" +
+	"\x15CreateResourceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\famenity_type\x18\x02 \x01(\tR\vamenityType\x121\n" +
+	"\blocation\x18\x03 \x01(\v2\x15.resource.CoordinatesR\blocation\x12L\n" +
+	"\tinventory\x18\x04 \x03(\v2..resource.CreateResourceRequest.InventoryEntryR\tinventory\x12\x15\n" +
+	"\x06org_id\x18\x05 \x01(\tR\x05orgId\x1a<\n" +
+	"\x0eInventoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"f\n" +
+	"\x13SetInventoryRequest\x12\x1f\n" +
+	"\vresource_id\x18\x01 \x01(\tR\n" +
+	"resourceId\x12\x12\n" +
+	"\x04item\x18\x02 \x01(\tR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\"\xcd\x01\n" +
+	"\x12DeclareNeedRequest\x12\x1f\n" +
+	"\vdisaster_id\x18\x01 \x01(\tR\n" +
+	"disasterId\x121\n" +
+	"\blocation\x18\x02 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x12\n" +
+	"\x04item\x18\x03 \x01(\tR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x18\n" +
+	"\aurgency\x18\x05 \x01(\tR\aurgency\x12\x19\n" +
+	"\badmin_id\x18\x06 \x01(\tR\aadminId\"\xe7\x02\n" +
+	"\x04Need\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdisaster_id\x18\x02 \x01(\tR\n" +
+	"disasterId\x121\n" +
+	"\blocation\x18\x03 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x12\n" +
+	"\x04item\x18\x04 \x01(\tR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x03R\bquantity\x12\x1c\n" +
+	"\tcommitted\x18\x06 \x01(\x03R\tcommitted\x12\x18\n" +
+	"\aurgency\x18\a \x01(\tR\aurgency\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"3\n" +
+	"\x10ListNeedsRequest\x12\x1f\n" +
+	"\vdisaster_id\x18\x01 \x01(\tR\n" +
+	"disasterId\"9\n" +
+	"\x11ListNeedsResponse\x12$\n" +
+	"\x05needs\x18\x01 \x03(\v2\x0e.resource.NeedR\x05needs\"L\n" +
+	"\x11MatchNeedsRequest\x12\x1f\n" +
+	"\vdisaster_id\x18\x01 \x01(\tR\n" +
+	"disasterId\x12\x16\n" +
+	"\x06within\x18\x02 \x01(\x03R\x06within\"\xc0\x01\n" +
+	"\x0eSupplyProposal\x12\x17\n" +
+	"\aneed_id\x18\x01 \x01(\tR\x06needId\x12\x1f\n" +
+	"\vdisaster_id\x18\x02 \x01(\tR\n" +
+	"disasterId\x12(\n" +
+	"\x05depot\x18\x03 \x01(\v2\x12.resource.ResourceR\x05depot\x12\x12\n" +
+	"\x04item\x18\x04 \x01(\tR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x03R\bquantity\x12\x1a\n" +
+	"\bdistance\x18\x06 \x01(\x01R\bdistance\"L\n" +
+	"\x12MatchNeedsResponse\x126\n" +
+	"\tproposals\x18\x01 \x03(\v2\x18.resource.SupplyProposalR\tproposals\"\xa1\x01\n" +
+	"\x13CommitSupplyRequest\x12\x1f\n" +
+	"\vdisaster_id\x18\x01 \x01(\tR\n" +
+	"disasterId\x12\x17\n" +
+	"\aneed_id\x18\x02 \x01(\tR\x06needId\x12\x19\n" +
+	"\bdepot_id\x18\x03 \x01(\tR\adepotId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x19\n" +
+	"\badmin_id\x18\x05 \x01(\tR\aadminId\"\xa1\x02\n" +
+	"\x10SupplyCommitment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aneed_id\x18\x02 \x01(\tR\x06needId\x12\x1f\n" +
+	"\vdisaster_id\x18\x03 \x01(\tR\n" +
+	"disasterId\x12\x19\n" +
+	"\bdepot_id\x18\x04 \x01(\tR\adepotId\x12\x12\n" +
+	"\x04item\x18\x05 \x01(\tR\x04item\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x1a\n" +
+	"\bdistance\x18\a \x01(\x01R\bdistance\x12!\n" +
+	"\fcommitted_by\x18\b \x01(\tR\vcommittedBy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xff\x02\n" +
+	"\rRefreshRegion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x121\n" +
+	"\blocation\x18\x04 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x16\n" +
+	"\x06within\x18\x05 \x01(\x03R\x06within\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12B\n" +
+	"\x0flast_refresh_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastRefreshAt\x12\x1f\n" +
+	"\vlast_status\x18\b \x01(\tR\n" +
+	"lastStatus\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12\x14\n" +
+	"\x05found\x18\n" +
+	" \x01(\x03R\x05found\x12\x16\n" +
+	"\x06closed\x18\v \x01(\x03R\x06closed\"\x1b\n" +
+	"\x19ListRefreshRegionsRequest\"O\n" +
+	"\x1aListRefreshRegionsResponse\x121\n" +
+	"\aregions\x18\x01 \x03(\v2\x17.resource.RefreshRegionR\aregions\"s\n" +
+	"\x12WatchRegionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
+	"\blocation\x18\x02 \x01(\v2\x15.resource.CoordinatesR\blocation\x12\x16\n" +
+	"\x06within\x18\x03 \x01(\x03R\x06within\"&\n" +
+	"\x14UnwatchRegionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15UnwatchRegionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15TriggerRefreshRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xb1\n" +
+	"\n" +
+	"\x0fResourceService\x12S\n" +
+	"\x12GetNearbyResources\x12\x1d.resource.GetResourcesRequest\x1a\x1e.resource.GetResourcesResponse\x12?\n" +
+	"\vGetResource\x12\x1c.resource.GetResourceRequest\x1a\x12.resource.Resource\x12\\\n" +
+	"\x19RankResourcesByTravelTime\x12\x1e.resource.RankResourcesRequest\x1a\x1f.resource.RankResourcesResponse\x12>\n" +
+	"\tBlockRoad\x12\x1a.resource.BlockRoadRequest\x1a\x15.resource.BlockedRoad\x12J\n" +
+	"\vUnblockRoad\x12\x1c.resource.UnblockRoadRequest\x1a\x1d.resource.UnblockRoadResponse\x12Y\n" +
+	"\x10ListBlockedRoads\x12!.resource.ListBlockedRoadsRequest\x1a\".resource.ListBlockedRoadsResponse\x12E\n" +
+	"\x0eCreateResource\x12\x1f.resource.CreateResourceRequest\x1a\x12.resource.Resource\x12A\n" +
+	"\fSetInventory\x12\x1d.resource.SetInventoryRequest\x1a\x12.resource.Resource\x12;\n" +
+	"\vDeclareNeed\x12\x1c.resource.DeclareNeedRequest\x1a\x0e.resource.Need\x12D\n" +
+	"\tListNeeds\x12\x1a.resource.ListNeedsRequest\x1a\x1b.resource.ListNeedsResponse\x12G\n" +
+	"\n" +
+	"MatchNeeds\x12\x1b.resource.MatchNeedsRequest\x1a\x1c.resource.MatchNeedsResponse\x12I\n" +
+	"\fCommitSupply\x12\x1d.resource.CommitSupplyRequest\x1a\x1a.resource.SupplyCommitment\x12_\n" +
+	"\x12ListRefreshRegions\x12#.resource.ListRefreshRegionsRequest\x1a$.resource.ListRefreshRegionsResponse\x12D\n" +
+	"\vWatchRegion\x12\x1c.resource.WatchRegionRequest\x1a\x17.resource.RefreshRegion\x12P\n" +
+	"\rUnwatchRegion\x12\x1e.resource.UnwatchRegionRequest\x1a\x1f.resource.UnwatchRegionResponse\x12J\n" +
+	"\x0eTriggerRefresh\x12\x1f.resource.TriggerRefreshRequest\x1a\x17.resource.RefreshRegion\x12]\n" +
+	"\x14GetResourcesInBounds\x12%.resource.GetResourcesInBoundsRequest\x1a\x1e.resource.GetResourcesResponseB Z\x1eshared/proto/resource;resourceb\x06proto3"
 
 var (
 	file_resource_proto_rawDescOnce sync.Once
@@ -270,24 +2385,112 @@ func file_resource_proto_rawDescGZIP() []byte {
 	return file_resource_proto_rawDescData
 }
 
-var file_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_resource_proto_goTypes = []any{
-	(*GetResourcesRequest)(nil),  // 0: resource.GetResourcesRequest
-	(*GetResourcesResponse)(nil), // 1: resource.GetResourcesResponse
-	(*Coordinates)(nil),          // 2: resource.Coordinates
-	(*Resource)(nil),             // 3: resource.Resource
+	(*GetResourcesRequest)(nil),         // 0: resource.GetResourcesRequest
+	(*Bounds)(nil),                      // 1: resource.Bounds
+	(*GetResourcesInBoundsRequest)(nil), // 2: resource.GetResourcesInBoundsRequest
+	(*GetResourceRequest)(nil),          // 3: resource.GetResourceRequest
+	(*GetResourcesResponse)(nil),        // 4: resource.GetResourcesResponse
+	(*Coordinates)(nil),                 // 5: resource.Coordinates
+	(*Resource)(nil),                    // 6: resource.Resource
+	(*RankResourcesRequest)(nil),        // 7: resource.RankResourcesRequest
+	(*RankedResource)(nil),              // 8: resource.RankedResource
+	(*RankResourcesResponse)(nil),       // 9: resource.RankResourcesResponse
+	(*BlockRoadRequest)(nil),            // 10: resource.BlockRoadRequest
+	(*BlockedRoad)(nil),                 // 11: resource.BlockedRoad
+	(*UnblockRoadRequest)(nil),          // 12: resource.UnblockRoadRequest
+	(*UnblockRoadResponse)(nil),         // 13: resource.UnblockRoadResponse
+	(*ListBlockedRoadsRequest)(nil),     // 14: resource.ListBlockedRoadsRequest
+	(*ListBlockedRoadsResponse)(nil),    // 15: resource.ListBlockedRoadsResponse
+	(*CreateResourceRequest)(nil),       // 16: resource.CreateResourceRequest
+	(*SetInventoryRequest)(nil),         // 17: resource.SetInventoryRequest
+	(*DeclareNeedRequest)(nil),          // 18: resource.DeclareNeedRequest
+	(*Need)(nil),                        // 19: resource.Need
+	(*ListNeedsRequest)(nil),            // 20: resource.ListNeedsRequest
+	(*ListNeedsResponse)(nil),           // 21: resource.ListNeedsResponse
+	(*MatchNeedsRequest)(nil),           // 22: resource.MatchNeedsRequest
+	(*SupplyProposal)(nil),              // 23: resource.SupplyProposal
+	(*MatchNeedsResponse)(nil),          // 24: resource.MatchNeedsResponse
+	(*CommitSupplyRequest)(nil),         // 25: resource.CommitSupplyRequest
+	(*SupplyCommitment)(nil),            // 26: resource.SupplyCommitment
+	(*RefreshRegion)(nil),               // 27: resource.RefreshRegion
+	(*ListRefreshRegionsRequest)(nil),   // 28: resource.ListRefreshRegionsRequest
+	(*ListRefreshRegionsResponse)(nil),  // 29: resource.ListRefreshRegionsResponse
+	(*WatchRegionRequest)(nil),          // 30: resource.WatchRegionRequest
+	(*UnwatchRegionRequest)(nil),        // 31: resource.UnwatchRegionRequest
+	(*UnwatchRegionResponse)(nil),       // 32: resource.UnwatchRegionResponse
+	(*TriggerRefreshRequest)(nil),       // 33: resource.TriggerRefreshRequest
+	nil,                                 // 34: resource.Resource.InventoryEntry
+	nil,                                 // 35: resource.CreateResourceRequest.InventoryEntry
+	(*timestamppb.Timestamp)(nil),       // 36: google.protobuf.Timestamp
 }
 var file_resource_proto_depIdxs = []int32{
-	2, // 0: resource.GetResourcesRequest.location:type_name -> resource.Coordinates
-	3, // 1: resource.GetResourcesResponse.resources:type_name -> resource.Resource
-	2, // 2: resource.Resource.location:type_name -> resource.Coordinates
-	0, // 3: resource.ResourceService.GetNearbyResources:input_type -> resource.GetResourcesRequest
-	1, // 4: resource.ResourceService.GetNearbyResources:output_type -> resource.GetResourcesResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5,  // 0: resource.GetResourcesRequest.location:type_name -> resource.Coordinates
+	1,  // 1: resource.GetResourcesInBoundsRequest.bounds:type_name -> resource.Bounds
+	6,  // 2: resource.GetResourcesResponse.resources:type_name -> resource.Resource
+	5,  // 3: resource.Resource.location:type_name -> resource.Coordinates
+	34, // 4: resource.Resource.inventory:type_name -> resource.Resource.InventoryEntry
+	5,  // 5: resource.RankResourcesRequest.origin:type_name -> resource.Coordinates
+	6,  // 6: resource.RankedResource.resource:type_name -> resource.Resource
+	5,  // 7: resource.RankedResource.geometry:type_name -> resource.Coordinates
+	8,  // 8: resource.RankResourcesResponse.resources:type_name -> resource.RankedResource
+	36, // 9: resource.BlockedRoad.created_at:type_name -> google.protobuf.Timestamp
+	11, // 10: resource.ListBlockedRoadsResponse.roads:type_name -> resource.BlockedRoad
+	5,  // 11: resource.CreateResourceRequest.location:type_name -> resource.Coordinates
+	35, // 12: resource.CreateResourceRequest.inventory:type_name -> resource.CreateResourceRequest.InventoryEntry
+	5,  // 13: resource.DeclareNeedRequest.location:type_name -> resource.Coordinates
+	5,  // 14: resource.Need.location:type_name -> resource.Coordinates
+	36, // 15: resource.Need.created_at:type_name -> google.protobuf.Timestamp
+	36, // 16: resource.Need.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 17: resource.ListNeedsResponse.needs:type_name -> resource.Need
+	6,  // 18: resource.SupplyProposal.depot:type_name -> resource.Resource
+	23, // 19: resource.MatchNeedsResponse.proposals:type_name -> resource.SupplyProposal
+	36, // 20: resource.SupplyCommitment.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 21: resource.RefreshRegion.location:type_name -> resource.Coordinates
+	36, // 22: resource.RefreshRegion.expires_at:type_name -> google.protobuf.Timestamp
+	36, // 23: resource.RefreshRegion.last_refresh_at:type_name -> google.protobuf.Timestamp
+	27, // 24: resource.ListRefreshRegionsResponse.regions:type_name -> resource.RefreshRegion
+	5,  // 25: resource.WatchRegionRequest.location:type_name -> resource.Coordinates
+	0,  // 26: resource.ResourceService.GetNearbyResources:input_type -> resource.GetResourcesRequest
+	3,  // 27: resource.ResourceService.GetResource:input_type -> resource.GetResourceRequest
+	7,  // 28: resource.ResourceService.RankResourcesByTravelTime:input_type -> resource.RankResourcesRequest
+	10, // 29: resource.ResourceService.BlockRoad:input_type -> resource.BlockRoadRequest
+	12, // 30: resource.ResourceService.UnblockRoad:input_type -> resource.UnblockRoadRequest
+	14, // 31: resource.ResourceService.ListBlockedRoads:input_type -> resource.ListBlockedRoadsRequest
+	16, // 32: resource.ResourceService.CreateResource:input_type -> resource.CreateResourceRequest
+	17, // 33: resource.ResourceService.SetInventory:input_type -> resource.SetInventoryRequest
+	18, // 34: resource.ResourceService.DeclareNeed:input_type -> resource.DeclareNeedRequest
+	20, // 35: resource.ResourceService.ListNeeds:input_type -> resource.ListNeedsRequest
+	22, // 36: resource.ResourceService.MatchNeeds:input_type -> resource.MatchNeedsRequest
+	25, // 37: resource.ResourceService.CommitSupply:input_type -> resource.CommitSupplyRequest
+	28, // 38: resource.ResourceService.ListRefreshRegions:input_type -> resource.ListRefreshRegionsRequest
+	30, // 39: resource.ResourceService.WatchRegion:input_type -> resource.WatchRegionRequest
+	31, // 40: resource.ResourceService.UnwatchRegion:input_type -> resource.UnwatchRegionRequest
+	33, // 41: resource.ResourceService.TriggerRefresh:input_type -> resource.TriggerRefreshRequest
+	2,  // 42: resource.ResourceService.GetResourcesInBounds:input_type -> resource.GetResourcesInBoundsRequest
+	4,  // 43: resource.ResourceService.GetNearbyResources:output_type -> resource.GetResourcesResponse
+	6,  // 44: resource.ResourceService.GetResource:output_type -> resource.Resource
+	9,  // 45: resource.ResourceService.RankResourcesByTravelTime:output_type -> resource.RankResourcesResponse
+	11, // 46: resource.ResourceService.BlockRoad:output_type -> resource.BlockedRoad
+	13, // 47: resource.ResourceService.UnblockRoad:output_type -> resource.UnblockRoadResponse
+	15, // 48: resource.ResourceService.ListBlockedRoads:output_type -> resource.ListBlockedRoadsResponse
+	6,  // 49: resource.ResourceService.CreateResource:output_type -> resource.Resource
+	6,  // 50: resource.ResourceService.SetInventory:output_type -> resource.Resource
+	19, // 51: resource.ResourceService.DeclareNeed:output_type -> resource.Need
+	21, // 52: resource.ResourceService.ListNeeds:output_type -> resource.ListNeedsResponse
+	24, // 53: resource.ResourceService.MatchNeeds:output_type -> resource.MatchNeedsResponse
+	26, // 54: resource.ResourceService.CommitSupply:output_type -> resource.SupplyCommitment
+	29, // 55: resource.ResourceService.ListRefreshRegions:output_type -> resource.ListRefreshRegionsResponse
+	27, // 56: resource.ResourceService.WatchRegion:output_type -> resource.RefreshRegion
+	32, // 57: resource.ResourceService.UnwatchRegion:output_type -> resource.UnwatchRegionResponse
+	27, // 58: resource.ResourceService.TriggerRefresh:output_type -> resource.RefreshRegion
+	4,  // 59: resource.ResourceService.GetResourcesInBounds:output_type -> resource.GetResourcesResponse
+	43, // [43:60] is the sub-list for method output_type
+	26, // [26:43] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_resource_proto_init() }
@@ -301,7 +2504,7 @@ func file_resource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resource_proto_rawDesc), len(file_resource_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ResourceService_GetNearbyResources_FullMethodName = "/resource.ResourceService/GetNearbyResources"
+	ResourceService_GetNearbyResources_FullMethodName        = "/resource.ResourceService/GetNearbyResources"
+	ResourceService_GetResource_FullMethodName               = "/resource.ResourceService/GetResource"
+	ResourceService_RankResourcesByTravelTime_FullMethodName = "/resource.ResourceService/RankResourcesByTravelTime"
+	ResourceService_BlockRoad_FullMethodName                 = "/resource.ResourceService/BlockRoad"
+	ResourceService_UnblockRoad_FullMethodName               = "/resource.ResourceService/UnblockRoad"
+	ResourceService_ListBlockedRoads_FullMethodName          = "/resource.ResourceService/ListBlockedRoads"
+	ResourceService_CreateResource_FullMethodName            = "/resource.ResourceService/CreateResource"
+	ResourceService_SetInventory_FullMethodName              = "/resource.ResourceService/SetInventory"
+	ResourceService_DeclareNeed_FullMethodName               = "/resource.ResourceService/DeclareNeed"
+	ResourceService_ListNeeds_FullMethodName                 = "/resource.ResourceService/ListNeeds"
+	ResourceService_MatchNeeds_FullMethodName                = "/resource.ResourceService/MatchNeeds"
+	ResourceService_CommitSupply_FullMethodName              = "/resource.ResourceService/CommitSupply"
+	ResourceService_ListRefreshRegions_FullMethodName        = "/resource.ResourceService/ListRefreshRegions"
+	ResourceService_WatchRegion_FullMethodName               = "/resource.ResourceService/WatchRegion"
+	ResourceService_UnwatchRegion_FullMethodName             = "/resource.ResourceService/UnwatchRegion"
+	ResourceService_TriggerRefresh_FullMethodName            = "/resource.ResourceService/TriggerRefresh"
+	ResourceService_GetResourcesInBounds_FullMethodName      = "/resource.ResourceService/GetResourcesInBounds"
 )
 
 // ResourceServiceClient is the client API for ResourceService service.
@@ -27,6 +43,22 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ResourceServiceClient interface {
 	GetNearbyResources(ctx context.Context, in *GetResourcesRequest, opts ...grpc.CallOption) (*GetResourcesResponse, error)
+	GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*Resource, error)
+	RankResourcesByTravelTime(ctx context.Context, in *RankResourcesRequest, opts ...grpc.CallOption) (*RankResourcesResponse, error)
+	BlockRoad(ctx context.Context, in *BlockRoadRequest, opts ...grpc.CallOption) (*BlockedRoad, error)
+	UnblockRoad(ctx context.Context, in *UnblockRoadRequest, opts ...grpc.CallOption) (*UnblockRoadResponse, error)
+	ListBlockedRoads(ctx context.Context, in *ListBlockedRoadsRequest, opts ...grpc.CallOption) (*ListBlockedRoadsResponse, error)
+	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*Resource, error)
+	SetInventory(ctx context.Context, in *SetInventoryRequest, opts ...grpc.CallOption) (*Resource, error)
+	DeclareNeed(ctx context.Context, in *DeclareNeedRequest, opts ...grpc.CallOption) (*Need, error)
+	ListNeeds(ctx context.Context, in *ListNeedsRequest, opts ...grpc.CallOption) (*ListNeedsResponse, error)
+	MatchNeeds(ctx context.Context, in *MatchNeedsRequest, opts ...grpc.CallOption) (*MatchNeedsResponse, error)
+	CommitSupply(ctx context.Context, in *CommitSupplyRequest, opts ...grpc.CallOption) (*SupplyCommitment, error)
+	ListRefreshRegions(ctx context.Context, in *ListRefreshRegionsRequest, opts ...grpc.CallOption) (*ListRefreshRegionsResponse, error)
+	WatchRegion(ctx context.Context, in *WatchRegionRequest, opts ...grpc.CallOption) (*RefreshRegion, error)
+	UnwatchRegion(ctx context.Context, in *UnwatchRegionRequest, opts ...grpc.CallOption) (*UnwatchRegionResponse, error)
+	TriggerRefresh(ctx context.Context, in *TriggerRefreshRequest, opts ...grpc.CallOption) (*RefreshRegion, error)
+	GetResourcesInBounds(ctx context.Context, in *GetResourcesInBoundsRequest, opts ...grpc.CallOption) (*GetResourcesResponse, error)
 }
 
 type resourceServiceClient struct {
@@ -47,11 +79,187 @@ func (c *resourceServiceClient) GetNearbyResources(ctx context.Context, in *GetR
 	return out, nil
 }
 
+func (c *resourceServiceClient) GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*Resource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Resource)
+	err := c.cc.Invoke(ctx, ResourceService_GetResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) RankResourcesByTravelTime(ctx context.Context, in *RankResourcesRequest, opts ...grpc.CallOption) (*RankResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RankResourcesResponse)
+	err := c.cc.Invoke(ctx, ResourceService_RankResourcesByTravelTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) BlockRoad(ctx context.Context, in *BlockRoadRequest, opts ...grpc.CallOption) (*BlockedRoad, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockedRoad)
+	err := c.cc.Invoke(ctx, ResourceService_BlockRoad_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) UnblockRoad(ctx context.Context, in *UnblockRoadRequest, opts ...grpc.CallOption) (*UnblockRoadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockRoadResponse)
+	err := c.cc.Invoke(ctx, ResourceService_UnblockRoad_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) ListBlockedRoads(ctx context.Context, in *ListBlockedRoadsRequest, opts ...grpc.CallOption) (*ListBlockedRoadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedRoadsResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListBlockedRoads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*Resource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Resource)
+	err := c.cc.Invoke(ctx, ResourceService_CreateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) SetInventory(ctx context.Context, in *SetInventoryRequest, opts ...grpc.CallOption) (*Resource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Resource)
+	err := c.cc.Invoke(ctx, ResourceService_SetInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) DeclareNeed(ctx context.Context, in *DeclareNeedRequest, opts ...grpc.CallOption) (*Need, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Need)
+	err := c.cc.Invoke(ctx, ResourceService_DeclareNeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) ListNeeds(ctx context.Context, in *ListNeedsRequest, opts ...grpc.CallOption) (*ListNeedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNeedsResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListNeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) MatchNeeds(ctx context.Context, in *MatchNeedsRequest, opts ...grpc.CallOption) (*MatchNeedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchNeedsResponse)
+	err := c.cc.Invoke(ctx, ResourceService_MatchNeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) CommitSupply(ctx context.Context, in *CommitSupplyRequest, opts ...grpc.CallOption) (*SupplyCommitment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupplyCommitment)
+	err := c.cc.Invoke(ctx, ResourceService_CommitSupply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) ListRefreshRegions(ctx context.Context, in *ListRefreshRegionsRequest, opts ...grpc.CallOption) (*ListRefreshRegionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefreshRegionsResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListRefreshRegions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) WatchRegion(ctx context.Context, in *WatchRegionRequest, opts ...grpc.CallOption) (*RefreshRegion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshRegion)
+	err := c.cc.Invoke(ctx, ResourceService_WatchRegion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) UnwatchRegion(ctx context.Context, in *UnwatchRegionRequest, opts ...grpc.CallOption) (*UnwatchRegionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnwatchRegionResponse)
+	err := c.cc.Invoke(ctx, ResourceService_UnwatchRegion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) TriggerRefresh(ctx context.Context, in *TriggerRefreshRequest, opts ...grpc.CallOption) (*RefreshRegion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshRegion)
+	err := c.cc.Invoke(ctx, ResourceService_TriggerRefresh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) GetResourcesInBounds(ctx context.Context, in *GetResourcesInBoundsRequest, opts ...grpc.CallOption) (*GetResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourcesResponse)
+	err := c.cc.Invoke(ctx, ResourceService_GetResourcesInBounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResourceServiceServer is the server API for ResourceService service.
 // All implementations must embed UnimplementedResourceServiceServer
 // for forward compatibility.
 type ResourceServiceServer interface {
 	GetNearbyResources(context.Context, *GetResourcesRequest) (*GetResourcesResponse, error)
+	GetResource(context.Context, *GetResourceRequest) (*Resource, error)
+	RankResourcesByTravelTime(context.Context, *RankResourcesRequest) (*RankResourcesResponse, error)
+	BlockRoad(context.Context, *BlockRoadRequest) (*BlockedRoad, error)
+	UnblockRoad(context.Context, *UnblockRoadRequest) (*UnblockRoadResponse, error)
+	ListBlockedRoads(context.Context, *ListBlockedRoadsRequest) (*ListBlockedRoadsResponse, error)
+	CreateResource(context.Context, *CreateResourceRequest) (*Resource, error)
+	SetInventory(context.Context, *SetInventoryRequest) (*Resource, error)
+	DeclareNeed(context.Context, *DeclareNeedRequest) (*Need, error)
+	ListNeeds(context.Context, *ListNeedsRequest) (*ListNeedsResponse, error)
+	MatchNeeds(context.Context, *MatchNeedsRequest) (*MatchNeedsResponse, error)
+	CommitSupply(context.Context, *CommitSupplyRequest) (*SupplyCommitment, error)
+	ListRefreshRegions(context.Context, *ListRefreshRegionsRequest) (*ListRefreshRegionsResponse, error)
+	WatchRegion(context.Context, *WatchRegionRequest) (*RefreshRegion, error)
+	UnwatchRegion(context.Context, *UnwatchRegionRequest) (*UnwatchRegionResponse, error)
+	TriggerRefresh(context.Context, *TriggerRefreshRequest) (*RefreshRegion, error)
+	GetResourcesInBounds(context.Context, *GetResourcesInBoundsRequest) (*GetResourcesResponse, error)
 	mustEmbedUnimplementedResourceServiceServer()
 }
 
@@ -65,6 +273,54 @@ type UnimplementedResourceServiceServer struct{}
 func (UnimplementedResourceServiceServer) GetNearbyResources(context.Context, *GetResourcesRequest) (*GetResourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyResources not implemented")
 }
+func (UnimplementedResourceServiceServer) GetResource(context.Context, *GetResourceRequest) (*Resource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedResourceServiceServer) RankResourcesByTravelTime(context.Context, *RankResourcesRequest) (*RankResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RankResourcesByTravelTime not implemented")
+}
+func (UnimplementedResourceServiceServer) BlockRoad(context.Context, *BlockRoadRequest) (*BlockedRoad, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockRoad not implemented")
+}
+func (UnimplementedResourceServiceServer) UnblockRoad(context.Context, *UnblockRoadRequest) (*UnblockRoadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockRoad not implemented")
+}
+func (UnimplementedResourceServiceServer) ListBlockedRoads(context.Context, *ListBlockedRoadsRequest) (*ListBlockedRoadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlockedRoads not implemented")
+}
+func (UnimplementedResourceServiceServer) CreateResource(context.Context, *CreateResourceRequest) (*Resource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedResourceServiceServer) SetInventory(context.Context, *SetInventoryRequest) (*Resource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInventory not implemented")
+}
+func (UnimplementedResourceServiceServer) DeclareNeed(context.Context, *DeclareNeedRequest) (*Need, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclareNeed not implemented")
+}
+func (UnimplementedResourceServiceServer) ListNeeds(context.Context, *ListNeedsRequest) (*ListNeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNeeds not implemented")
+}
+func (UnimplementedResourceServiceServer) MatchNeeds(context.Context, *MatchNeedsRequest) (*MatchNeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchNeeds not implemented")
+}
+func (UnimplementedResourceServiceServer) CommitSupply(context.Context, *CommitSupplyRequest) (*SupplyCommitment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitSupply not implemented")
+}
+func (UnimplementedResourceServiceServer) ListRefreshRegions(context.Context, *ListRefreshRegionsRequest) (*ListRefreshRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefreshRegions not implemented")
+}
+func (UnimplementedResourceServiceServer) WatchRegion(context.Context, *WatchRegionRequest) (*RefreshRegion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchRegion not implemented")
+}
+func (UnimplementedResourceServiceServer) UnwatchRegion(context.Context, *UnwatchRegionRequest) (*UnwatchRegionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnwatchRegion not implemented")
+}
+func (UnimplementedResourceServiceServer) TriggerRefresh(context.Context, *TriggerRefreshRequest) (*RefreshRegion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerRefresh not implemented")
+}
+func (UnimplementedResourceServiceServer) GetResourcesInBounds(context.Context, *GetResourcesInBoundsRequest) (*GetResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourcesInBounds not implemented")
+}
 func (UnimplementedResourceServiceServer) mustEmbedUnimplementedResourceServiceServer() {}
 func (UnimplementedResourceServiceServer) testEmbeddedByValue()                         {}
 
@@ -104,6 +360,294 @@ func _ResourceService_GetNearbyResources_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceService_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_GetResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).GetResource(ctx, req.(*GetResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_RankResourcesByTravelTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).RankResourcesByTravelTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_RankResourcesByTravelTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).RankResourcesByTravelTime(ctx, req.(*RankResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_BlockRoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).BlockRoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_BlockRoad_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).BlockRoad(ctx, req.(*BlockRoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_UnblockRoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockRoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).UnblockRoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_UnblockRoad_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).UnblockRoad(ctx, req.(*UnblockRoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_ListBlockedRoads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRoadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListBlockedRoads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListBlockedRoads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListBlockedRoads(ctx, req.(*ListBlockedRoadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).CreateResource(ctx, req.(*CreateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_SetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).SetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_SetInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).SetInventory(ctx, req.(*SetInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_DeclareNeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclareNeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).DeclareNeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_DeclareNeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).DeclareNeed(ctx, req.(*DeclareNeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_ListNeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListNeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListNeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListNeeds(ctx, req.(*ListNeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_MatchNeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchNeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).MatchNeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_MatchNeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).MatchNeeds(ctx, req.(*MatchNeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_CommitSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitSupplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).CommitSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_CommitSupply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).CommitSupply(ctx, req.(*CommitSupplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_ListRefreshRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefreshRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListRefreshRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListRefreshRegions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListRefreshRegions(ctx, req.(*ListRefreshRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_WatchRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).WatchRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_WatchRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).WatchRegion(ctx, req.(*WatchRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_UnwatchRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnwatchRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).UnwatchRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_UnwatchRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).UnwatchRegion(ctx, req.(*UnwatchRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_TriggerRefresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).TriggerRefresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_TriggerRefresh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).TriggerRefresh(ctx, req.(*TriggerRefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_GetResourcesInBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourcesInBoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).GetResourcesInBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_GetResourcesInBounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).GetResourcesInBounds(ctx, req.(*GetResourcesInBoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ResourceService_ServiceDesc is the grpc.ServiceDesc for ResourceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +659,70 @@ var ResourceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNearbyResources",
 			Handler:    _ResourceService_GetNearbyResources_Handler,
 		},
+		{
+			MethodName: "GetResource",
+			Handler:    _ResourceService_GetResource_Handler,
+		},
+		{
+			MethodName: "RankResourcesByTravelTime",
+			Handler:    _ResourceService_RankResourcesByTravelTime_Handler,
+		},
+		{
+			MethodName: "BlockRoad",
+			Handler:    _ResourceService_BlockRoad_Handler,
+		},
+		{
+			MethodName: "UnblockRoad",
+			Handler:    _ResourceService_UnblockRoad_Handler,
+		},
+		{
+			MethodName: "ListBlockedRoads",
+			Handler:    _ResourceService_ListBlockedRoads_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _ResourceService_CreateResource_Handler,
+		},
+		{
+			MethodName: "SetInventory",
+			Handler:    _ResourceService_SetInventory_Handler,
+		},
+		{
+			MethodName: "DeclareNeed",
+			Handler:    _ResourceService_DeclareNeed_Handler,
+		},
+		{
+			MethodName: "ListNeeds",
+			Handler:    _ResourceService_ListNeeds_Handler,
+		},
+		{
+			MethodName: "MatchNeeds",
+			Handler:    _ResourceService_MatchNeeds_Handler,
+		},
+		{
+			MethodName: "CommitSupply",
+			Handler:    _ResourceService_CommitSupply_Handler,
+		},
+		{
+			MethodName: "ListRefreshRegions",
+			Handler:    _ResourceService_ListRefreshRegions_Handler,
+		},
+		{
+			MethodName: "WatchRegion",
+			Handler:    _ResourceService_WatchRegion_Handler,
+		},
+		{
+			MethodName: "UnwatchRegion",
+			Handler:    _ResourceService_UnwatchRegion_Handler,
+		},
+		{
+			MethodName: "TriggerRefresh",
+			Handler:    _ResourceService_TriggerRefresh_Handler,
+		},
+		{
+			MethodName: "GetResourcesInBounds",
+			Handler:    _ResourceService_GetResourcesInBounds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "resource.proto",
@@ -0,0 +1,152 @@
+package taxonomy
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+const DefaultFile = "default.json"
+
+//go:embed "default.json"
+var FS embed.FS
+
+// validToken restricts tag keys and values to characters that are safe to embed in an Overpass query.
+var validToken = regexp.MustCompile(`^[A-Za-z0-9_:\-]+$`)
+
+// Filter is a single OSM tag filter, e.g. amenity=hospital or emergency=landing_site.
+type Filter struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Category maps a relief resource category to the OSM tag filters that identify it.
+// A category without filters is never synced from OSM and can only be assigned to
+// manually created resources, e.g., supply depots.
+type Category struct {
+	Name    string   `json:"name"`
+	Label   string   `json:"label"`
+	Filters []Filter `json:"filters"`
+}
+
+// Taxonomy is the set of resource categories known to the platform.
+type Taxonomy struct {
+	Categories []Category `json:"categories"`
+	byName     map[string]*Category
+}
+
+// Load reads the taxonomy from the given file path.
+// If path is empty, the embedded default taxonomy is used.
+func Load(path string) (*Taxonomy, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "" {
+		data, err = FS.ReadFile(DefaultFile)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read taxonomy: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes and validates a JSON encoded taxonomy.
+func Parse(data []byte) (*Taxonomy, error) {
+	var t Taxonomy
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode taxonomy: %w", err)
+	}
+
+	if len(t.Categories) == 0 {
+		return nil, fmt.Errorf("taxonomy has no categories")
+	}
+
+	filtered := false
+
+	t.byName = make(map[string]*Category, len(t.Categories))
+	for i := range t.Categories {
+		c := &t.Categories[i]
+		if !validToken.MatchString(c.Name) {
+			return nil, fmt.Errorf("invalid category name %q", c.Name)
+		}
+		if _, ok := t.byName[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate category %q", c.Name)
+		}
+		for _, f := range c.Filters {
+			if !validToken.MatchString(f.Key) || !validToken.MatchString(f.Value) {
+				return nil, fmt.Errorf("invalid filter %s=%s in category %q", f.Key, f.Value, c.Name)
+			}
+		}
+		filtered = filtered || len(c.Filters) > 0
+		if c.Label == "" {
+			c.Label = c.Name
+		}
+		t.byName[c.Name] = c
+	}
+
+	if !filtered {
+		return nil, fmt.Errorf("taxonomy has no categories with OSM filters")
+	}
+
+	return &t, nil
+}
+
+// Names returns the category names in the order they are declared.
+func (t *Taxonomy) Names() []string {
+	names := make([]string, 0, len(t.Categories))
+	for _, c := range t.Categories {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Has reports whether the taxonomy contains a category with the given name.
+func (t *Taxonomy) Has(name string) bool {
+	_, ok := t.byName[name]
+	return ok
+}
+
+// Category returns the category with the given name.
+func (t *Taxonomy) Category(name string) (*Category, bool) {
+	c, ok := t.byName[name]
+	return c, ok
+}
+
+// FiltersByKey groups the tag values of all categories by OSM tag key.
+// Keys and values are returned in declaration order without duplicates.
+func (t *Taxonomy) FiltersByKey() ([]string, map[string][]string) {
+	var keys []string
+	values := make(map[string][]string)
+	seen := make(map[Filter]bool)
+	for _, c := range t.Categories {
+		for _, f := range c.Filters {
+			if seen[f] {
+				continue
+			}
+			seen[f] = true
+			if _, ok := values[f.Key]; !ok {
+				keys = append(keys, f.Key)
+			}
+			values[f.Key] = append(values[f.Key], f.Value)
+		}
+	}
+	return keys, values
+}
+
+// Classify returns the first category whose filters match the given OSM tags.
+func (t *Taxonomy) Classify(tags map[string]string) (string, bool) {
+	for _, c := range t.Categories {
+		for _, f := range c.Filters {
+			if tags[f.Key] == f.Value {
+				return c.Name, true
+			}
+		}
+	}
+	return "", false
+}
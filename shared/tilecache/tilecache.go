@@ -0,0 +1,98 @@
+// Package tilecache caches encoded map tiles in Redis.
+//
+// Every layer has a version counter that is part of the cache key. Invalidating a layer bumps its
+// version, so stale tiles are never served and simply expire.
+package tilecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Tile layers.
+const (
+	LayerResources = "resources"
+	LayerDisasters = "disasters"
+)
+
+// DefaultTTL is how long an encoded tile is kept when no TTL is configured.
+const DefaultTTL = 10 * time.Minute
+
+// Cache stores encoded tiles per layer. A nil Cache is valid and caches nothing.
+type Cache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// New creates a tile cache on the given Redis client.
+func New(client *redis.Client, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{client: client, ttl: ttl}
+}
+
+// Key identifies a cached tile. Variant distinguishes filtered renderings of the same tile, e.g., by category.
+type Key struct {
+	Layer   string
+	Z, X, Y uint32
+	Variant string
+}
+
+// Version returns the current version of a layer.
+func (c *Cache) Version(ctx context.Context, layer string) (int64, error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	v, err := c.client.Get(ctx, versionKey(layer)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return v, err
+}
+
+// Get returns a cached tile of the given layer version, or nil on a cache miss.
+func (c *Cache) Get(ctx context.Context, version int64, k Key) ([]byte, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	tile, err := c.client.Get(ctx, tileKey(version, k)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return tile, err
+}
+
+// Set caches a tile rendered at the given layer version.
+func (c *Cache) Set(ctx context.Context, version int64, k Key, tile []byte) error {
+	if c == nil {
+		return nil
+	}
+	return c.client.Set(ctx, tileKey(version, k), tile, c.ttl).Err()
+}
+
+// Invalidate discards every cached tile of a layer.
+func (c *Cache) Invalidate(ctx context.Context, layer string) error {
+	if c == nil {
+		return nil
+	}
+	return c.client.Incr(ctx, versionKey(layer)).Err()
+}
+
+func versionKey(layer string) string {
+	return fmt.Sprintf("tiles:%s:version", layer)
+}
+
+func tileKey(version int64, k Key) string {
+	key := fmt.Sprintf("tiles:%s:%d:%d:%d:%d", k.Layer, version, k.Z, k.X, k.Y)
+	if k.Variant != "" {
+		key += ":" + k.Variant
+	}
+	return key
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AlertSubscription is an area a user wants to be alerted about when a disaster inside it is approved.
+// The area is either a circle, given by a center and radius, or a polygon.
+type AlertSubscription struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    bson.ObjectID `json:"user_id" bson:"user_id"`
+	Name      string        `json:"name" bson:"name"`
+	Center    *Coordinates  `json:"center,omitempty" bson:"center,omitempty"`
+	Radius    int           `json:"radius,omitempty" bson:"radius,omitempty"`   // meters, circular areas only
+	Polygon   []Coordinates `json:"polygon,omitempty" bson:"polygon,omitempty"` // outer ring, first and last point need not repeat
+	Hazards   []string      `json:"hazards" bson:"hazards"`                     // disaster tags to alert on, empty for every hazard
+	Channels  []string      `json:"channels" bson:"channels"`                   // tried in order, empty for the user's notification settings
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+}
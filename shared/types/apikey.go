@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// APIKey lets a partner's scripts act for an organization with a set of permissions. Only the key's hash
+// is stored; its prefix is stored in the clear to look the key up.
+type APIKey struct {
+	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID       bson.ObjectID `json:"org_id" bson:"org_id"`
+	Name        string        `json:"name" bson:"name"`
+	Prefix      string        `json:"prefix" bson:"prefix"`
+	KeyHash     string        `json:"-" bson:"key_hash"`
+	Permissions []string      `json:"permissions" bson:"permissions"`
+	RateLimit   int           `json:"rate_limit" bson:"rate_limit"` // requests per minute
+	CreatedBy   string        `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // nil for keys that don't expire
+	LastUsedAt  *time.Time    `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt   *time.Time    `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+}
+
+// Active reports whether the key may be used at a time.
+func (k *APIKey) Active(at time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || at.Before(*k.ExpiresAt))
+}
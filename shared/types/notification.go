@@ -0,0 +1,64 @@
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Notification channels.
+const (
+	ChannelEmail   = "email"
+	ChannelSMS     = "sms"
+	ChannelPush    = "push"
+	ChannelWebhook = "webhook"
+)
+
+// NotificationChannels lists the channels users can be notified through.
+var NotificationChannels = []string{ChannelEmail, ChannelSMS, ChannelPush, ChannelWebhook}
+
+// Delivery statuses.
+const (
+	DeliveryPending = "pending"
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+)
+
+// How admins receive notifications of new disaster reports: one email per report, or a periodic summary.
+// High and critical reports are always sent immediately.
+const (
+	ReportDeliveryImmediate = "immediate"
+	ReportDeliveryDigest    = "digest"
+)
+
+// NotificationSettings is how a user wants to be notified: the channels to try, in order, their address on each,
+// and the language.
+type NotificationSettings struct {
+	Channels   []string `json:"channels" bson:"channels"`
+	Phone      string   `json:"phone,omitempty" bson:"phone,omitempty"`             // E.164, e.g., +919812345678
+	PushTokens []string `json:"push_tokens,omitempty" bson:"push_tokens,omitempty"` // Expo push tokens of the user's devices
+	WebhookURL string   `json:"webhook_url,omitempty" bson:"webhook_url,omitempty"`
+	Locale     string   `json:"locale,omitempty" bson:"locale,omitempty"` // language of emails, e.g., hi; English when unset
+
+	// ReportDelivery is how admins receive new disaster reports, immediate when unset.
+	ReportDelivery string `json:"report_delivery,omitempty" bson:"report_delivery,omitempty"`
+}
+
+// Delivery is the delivery status of a notification to a user, with every channel it was tried on.
+type Delivery struct {
+	ID        bson.ObjectID     `json:"id" bson:"_id,omitempty"`
+	UserID    bson.ObjectID     `json:"user_id" bson:"user_id"`
+	Kind      string            `json:"kind" bson:"kind"` // what the notification is about, e.g., disaster_alert
+	Status    string            `json:"status" bson:"status"`
+	Channel   string            `json:"channel,omitempty" bson:"channel,omitempty"` // channel it was delivered through
+	Attempts  []DeliveryAttempt `json:"attempts" bson:"attempts"`
+	CreatedAt time.Time         `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" bson:"updated_at"`
+}
+
+// DeliveryAttempt is a try to deliver a notification on one channel; Error is empty if it succeeded.
+type DeliveryAttempt struct {
+	Channel string    `json:"channel" bson:"channel"`
+	Error   string    `json:"error,omitempty" bson:"error,omitempty"`
+	At      time.Time `json:"at" bson:"at"`
+}
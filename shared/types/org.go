@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// OrgRole is the role of a member within an organization, independent of the member's platform role.
+type OrgRole string
+
+// Organization roles.
+const (
+	OrgRoleMember      OrgRole = "member"      // takes part in the organization's teams
+	OrgRoleCoordinator OrgRole = "coordinator" // also manages teams
+	OrgRoleAdmin       OrgRole = "org_admin"   // also manages members and invites
+)
+
+// OrgRoles lists the organization roles from least to most privileged.
+var OrgRoles = []OrgRole{OrgRoleMember, OrgRoleCoordinator, OrgRoleAdmin}
+
+// ParseOrgRole parses and validates an organization role name.
+func ParseOrgRole(s string) (OrgRole, error) {
+	r := OrgRole(s)
+	if !r.Valid() {
+		return "", fmt.Errorf("unknown organization role: %q", s)
+	}
+	return r, nil
+}
+
+// Valid reports whether r is a known organization role.
+func (r OrgRole) Valid() bool {
+	return slices.Contains(OrgRoles, r)
+}
+
+// Rank returns the privilege level of an organization role, higher is more privileged; -1 for unknown roles.
+func (r OrgRole) Rank() int {
+	return slices.Index(OrgRoles, r)
+}
+
+// Organization is an NGO or agency whose members respond to disasters together.
+type Organization struct {
+	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string        `json:"name" bson:"name"`
+	Description string        `json:"description,omitempty" bson:"description,omitempty"`
+	CreatedBy   string        `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+// Membership is a user's membership in an organization.
+type Membership struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID     bson.ObjectID `json:"org_id" bson:"org_id"`
+	UserID    bson.ObjectID `json:"user_id" bson:"user_id"`
+	Role      OrgRole       `json:"role" bson:"role"`
+	TeamIDs   []string      `json:"team_ids" bson:"team_ids"`
+	InvitedBy string        `json:"invited_by,omitempty" bson:"invited_by,omitempty"` // empty for the organization's creator
+	JoinedAt  time.Time     `json:"joined_at" bson:"joined_at"`
+}
+
+// Team is a group of members within an organization, e.g., a medical unit.
+type Team struct {
+	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID       bson.ObjectID `json:"org_id" bson:"org_id"`
+	Name        string        `json:"name" bson:"name"`
+	Description string        `json:"description,omitempty" bson:"description,omitempty"`
+	CreatedBy   string        `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+}
+
+// OrgInvite invites an email address to join an organization with a role. Only the code's hash is stored.
+type OrgInvite struct {
+	ID         bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID      bson.ObjectID `json:"org_id" bson:"org_id"`
+	Email      string        `json:"email" bson:"email"` // lowercased
+	Role       OrgRole       `json:"role" bson:"role"`
+	CodeHash   string        `json:"-" bson:"code_hash"`
+	InvitedBy  string        `json:"invited_by" bson:"invited_by"`
+	CreatedAt  time.Time     `json:"created_at" bson:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at" bson:"expires_at"`
+	AcceptedBy string        `json:"accepted_by,omitempty" bson:"accepted_by,omitempty"`
+	AcceptedAt *time.Time    `json:"accepted_at,omitempty" bson:"accepted_at,omitempty"`
+}
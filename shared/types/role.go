@@ -0,0 +1,82 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Role is a user role.
+type Role string
+
+// User roles.
+const (
+	RoleUser      Role = "user"
+	RoleVolunteer Role = "volunteer"
+	RoleAdmin     Role = "admin"
+)
+
+// Roles lists the user roles from least to most privileged.
+var Roles = []Role{RoleUser, RoleVolunteer, RoleAdmin}
+
+// DefaultRole is the role every new user signs up with. Higher roles are granted by admins.
+const DefaultRole = RoleUser
+
+// ParseRole parses and validates a role name.
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if !r.Valid() {
+		return "", fmt.Errorf("unknown role: %q", s)
+	}
+	return r, nil
+}
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Rank returns the privilege level of a role, higher is more privileged; -1 for unknown roles.
+func (r Role) Rank() int {
+	return slices.Index(Roles, r)
+}
+
+// Region is a named geographic area, e.g., a district, that a user's role is scoped to.
+type Region struct {
+	Name    string        `json:"name" bson:"name"`
+	Polygon []Coordinates `json:"polygon" bson:"polygon"` // outer ring, first and last point need not repeat
+}
+
+// Role change reasons.
+const (
+	RoleChangeAdmin  = "admin"  // set by an admin
+	RoleChangeInvite = "invite" // granted by redeeming an invite at signup
+)
+
+// RoleChange is an audit record of a change to a user's role.
+type RoleChange struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    string        `json:"user_id" bson:"user_id"`
+	OldRole   Role          `json:"old_role,omitempty" bson:"old_role,omitempty"` // empty when granted at signup
+	NewRole   Role          `json:"new_role" bson:"new_role"`
+	ChangedBy string        `json:"changed_by" bson:"changed_by"` // ID of the admin who set the role or created the invite
+	Source    string        `json:"source" bson:"source"`
+	InviteID  string        `json:"invite_id,omitempty" bson:"invite_id,omitempty"`
+	Reason    string        `json:"reason,omitempty" bson:"reason,omitempty"`
+	Regions   []string      `json:"regions,omitempty" bson:"regions,omitempty"` // names of the regions the new role is scoped to
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+}
+
+// Invite is an admin-generated code that grants a role when redeemed at signup. Only the code's hash is stored.
+type Invite struct {
+	ID         bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	CodeHash   string        `json:"-" bson:"code_hash"`
+	Role       Role          `json:"role" bson:"role"`
+	CreatedBy  string        `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time     `json:"created_at" bson:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at" bson:"expires_at"`
+	RedeemedBy string        `json:"redeemed_by,omitempty" bson:"redeemed_by,omitempty"`
+	RedeemedAt *time.Time    `json:"redeemed_at,omitempty" bson:"redeemed_at,omitempty"`
+}
@@ -6,19 +6,108 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// Default resource categories. The full set is defined by the resource taxonomy (see shared/taxonomy).
 const (
 	Hospital    = "hospital"
 	FireStation = "fire_station"
 	Police      = "police"
 	Shelter     = "shelter"
 	Pharmacy    = "pharmacy"
+	Depot       = "depot"
 )
 
+// Resource statuses. Closed resources vanished from OpenStreetMap and are kept for reference.
+const (
+	ResourceOpen   = "open"
+	ResourceClosed = "closed"
+)
+
+// Refresh region kinds: the area around a disaster or a region watch-listed by admins.
+const (
+	RegionDisaster = "disaster"
+	RegionWatch    = "watch"
+)
+
+// Refresh outcomes of a region.
+const (
+	RefreshPending = "pending"
+	RefreshOK      = "ok"
+	RefreshFailed  = "failed"
+)
+
+// Disaster review statuses.
+const (
+	DisasterPending  = "pending"
+	DisasterApproved = "approved"
+	DisasterRejected = "rejected"
+)
+
+// Dispatch assignee kinds: a volunteer user, a mobile resource such as an ambulance or boat, or a team of
+// an organization.
+const (
+	AssigneeVolunteer = "volunteer"
+	AssigneeResource  = "resource"
+	AssigneeTeam      = "team"
+)
+
+// AssigneeTypes lists the kinds of assignees that can be dispatched.
+var AssigneeTypes = []string{AssigneeVolunteer, AssigneeResource, AssigneeTeam}
+
+// Dispatch assignment statuses, in lifecycle order.
+const (
+	DispatchAssigned     = "assigned"
+	DispatchAcknowledged = "acknowledged"
+	DispatchEnRoute      = "en_route"
+	DispatchOnScene      = "on_scene"
+	DispatchReleased     = "released"
+)
+
+// Supply items that disasters can declare needs for and depots can stock.
+const (
+	SupplyWaterLiters = "water_liters"
+	SupplyFoodPacks   = "food_packs"
+	SupplyBlankets    = "blankets"
+	SupplyMedicalKits = "medical_kits"
+)
+
+// SupplyItems lists all known supply items.
+var SupplyItems = []string{SupplyWaterLiters, SupplyFoodPacks, SupplyBlankets, SupplyMedicalKits}
+
+// Need urgency levels, from least to most urgent.
+const (
+	UrgencyLow      = "low"
+	UrgencyMedium   = "medium"
+	UrgencyHigh     = "high"
+	UrgencyCritical = "critical"
+)
+
+// UrgencyLevels lists the urgency levels from least to most urgent.
+var UrgencyLevels = []string{UrgencyLow, UrgencyMedium, UrgencyHigh, UrgencyCritical}
+
+// Disaster report severity levels, from least to most severe. Reports without one are medium.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// SeverityLevels lists the severity levels from least to most severe.
+var SeverityLevels = []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
 type Coordinates struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
 
+// Bounds is a latitude/longitude bounding box, e.g., the area covered by a map tile.
+type Bounds struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
 type Disaster struct {
 	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
 	Title       string        `json:"title" bson:"title"`
@@ -30,6 +119,24 @@ type Disaster struct {
 	ImageURLs   []string      `json:"image_urls" bson:"image_urls"`
 	Location    Coordinates   `json:"location" bson:"location"`
 	Status      string        `json:"status" bson:"status"`
+	Severity    string        `json:"severity,omitempty" bson:"severity,omitempty"`
+	OrgID       string        `json:"org_id,omitempty" bson:"org_id,omitempty"` // organization the report was filed for
+
+	// Resources found around the disaster when it was reported, nearest first, with per-category counts
+	Resources        []ResourceSnapshot `json:"resources,omitempty" bson:"resources,omitempty"`
+	ResourceCounts   map[string]int64   `json:"resource_counts,omitempty" bson:"resource_counts,omitempty"`
+	ResourcesFoundAt time.Time          `json:"resources_found_at,omitempty" bson:"resources_found_at,omitempty"`
+	SearchRadius     int                `json:"search_radius,omitempty" bson:"search_radius,omitempty"` // meters, chosen by the resource service
+}
+
+// ResourceSnapshot is a copy of a resource linked to a disaster, kept so the disaster can be shown
+// without querying the resource service.
+type ResourceSnapshot struct {
+	ID       string      `json:"id" bson:"id"`
+	Name     string      `json:"name" bson:"name"`
+	Category string      `json:"category" bson:"category"`
+	Location Coordinates `json:"location" bson:"location"`
+	Distance float64     `json:"distance" bson:"distance"` // meters from the disaster
 }
 
 type User struct {
@@ -38,21 +145,125 @@ type User struct {
 	Email     string        `json:"email" bson:"email"`
 	Password  string        `json:"-" bson:"password"`
 	AvatarURL string        `json:"avatar_url,omitempty" bson:"avatar_url,omitempty"`
-	Role      string        `json:"role" bson:"role"`
+	Role      Role          `json:"role" bson:"role"`
 	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time     `json:"updated_at" bson:"updated_at"`
+
+	// EmailVerified is set once the user confirmed owning the email address; unverified users can't report disasters.
+	EmailVerified bool `json:"email_verified" bson:"email_verified"`
+
+	// Regions limits the role's permissions to geographic areas; empty means unrestricted.
+	Regions []Region `json:"regions,omitempty" bson:"regions,omitempty"`
+
+	MFA MFA `json:"mfa" bson:"mfa,omitempty"`
+
+	// Notifications holds contact details, so it is only served by the notification settings endpoints.
+	Notifications NotificationSettings `json:"-" bson:"notifications,omitempty"`
+}
+
+// MFA is the TOTP multi-factor authentication state of a user. Secrets and recovery codes never leave the user service.
+type MFA struct {
+	Enabled       bool       `json:"enabled" bson:"enabled"`
+	EnabledAt     *time.Time `json:"enabled_at,omitempty" bson:"enabled_at,omitempty"`
+	Secret        string     `json:"-" bson:"secret,omitempty"`         // base32 TOTP secret
+	PendingSecret string     `json:"-" bson:"pending_secret,omitempty"` // secret of an enrollment awaiting confirmation
+	RecoveryCodes []string   `json:"-" bson:"recovery_codes,omitempty"` // hashes of the unused recovery codes
+	LastStep      int64      `json:"-" bson:"last_step,omitempty"`      // time step of the last accepted code, so codes can't be replayed
 }
 
 type Resource struct {
-	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name        string        `json:"name" bson:"name"`
-	AmenityType string        `json:"amenity_type" bson:"amenity_type"` // e.g., amentiy type
-	Location    *Location     `json:"location" bson:"location"`
-	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at" bson:"updated_at"`
+	ID          bson.ObjectID    `json:"id" bson:"_id,omitempty"`
+	OSMID       string           `json:"osm_id,omitempty" bson:"osm_id,omitempty"` // e.g., node/123456
+	Name        string           `json:"name" bson:"name"`
+	AmenityType string           `json:"amenity_type" bson:"amenity_type"` // taxonomy category, e.g., hospital
+	Location    *Location        `json:"location" bson:"location"`
+	Distance    float64          `json:"distance,omitempty" bson:"distance,omitempty"`   // meters from the query point, set by nearby queries
+	Inventory   map[string]int64 `json:"inventory,omitempty" bson:"inventory,omitempty"` // supply item stock, e.g., water_liters
+	Manual      bool             `json:"manual,omitempty" bson:"manual,omitempty"`       // created by an admin rather than synced from OSM
+	Status      string           `json:"status,omitempty" bson:"status,omitempty"`       // open or closed, OSM resources only
+	OrgID       string           `json:"org_id,omitempty" bson:"org_id,omitempty"`       // organization running a manual resource
+	LastSeenAt  time.Time        `json:"last_seen_at,omitempty" bson:"last_seen_at,omitempty"`
+	ClosedAt    time.Time        `json:"closed_at,omitempty" bson:"closed_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" bson:"updated_at"`
 }
 
 type Location struct {
 	Type        string    `json:"type" bson:"type"`               // e.g., "Point"
 	Coordinates []float64 `json:"coordinates" bson:"coordinates"` // [longitude, latitude]
 }
+
+// RefreshRegion is an area whose OSM resources are periodically re-synced.
+type RefreshRegion struct {
+	ID            string      `json:"id" bson:"_id"` // e.g., disaster:<disaster id> or watch:<name>
+	Kind          string      `json:"kind" bson:"kind"`
+	Name          string      `json:"name" bson:"name"`
+	Location      Coordinates `json:"location" bson:"location"`
+	Radius        int         `json:"radius" bson:"radius"` // meters
+	ExpiresAt     time.Time   `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	LastRefreshAt time.Time   `json:"last_refresh_at" bson:"last_refresh_at"`
+	LastStatus    string      `json:"last_status" bson:"last_status"`
+	LastError     string      `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	Found         int         `json:"found" bson:"found"`   // resources returned by the last refresh
+	Closed        int64       `json:"closed" bson:"closed"` // resources marked closed by the last refresh
+	CreatedAt     time.Time   `json:"created_at" bson:"created_at"`
+}
+
+// BlockedRoad marks an OSM way as impassable for routing, e.g., flooded or collapsed.
+type BlockedRoad struct {
+	WayID     int64     `json:"way_id" bson:"way_id"`
+	Reason    string    `json:"reason" bson:"reason"`
+	BlockedBy string    `json:"blocked_by" bson:"blocked_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Need is a quantity of a supply item a disaster requires.
+type Need struct {
+	ID         bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	DisasterID string        `json:"disaster_id" bson:"disaster_id"`
+	Location   *Location     `json:"location" bson:"location"`
+	Item       string        `json:"item" bson:"item"`
+	Quantity   int64         `json:"quantity" bson:"quantity"`
+	Committed  int64         `json:"committed" bson:"committed"`
+	Urgency    string        `json:"urgency" bson:"urgency"`
+	CreatedBy  string        `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+// SupplyCommitment records a depot committing stock to a disaster's need.
+type SupplyCommitment struct {
+	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	NeedID      string        `json:"need_id" bson:"need_id"`
+	DisasterID  string        `json:"disaster_id" bson:"disaster_id"`
+	DepotID     string        `json:"depot_id" bson:"depot_id"`
+	Item        string        `json:"item" bson:"item"`
+	Quantity    int64         `json:"quantity" bson:"quantity"`
+	Distance    float64       `json:"distance" bson:"distance"` // meters from depot to disaster
+	CommittedBy string        `json:"committed_by" bson:"committed_by"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+}
+
+// Assignment dispatches a volunteer or mobile resource to a disaster.
+type Assignment struct {
+	ID            bson.ObjectID        `json:"id" bson:"_id,omitempty"`
+	DisasterID    string               `json:"disaster_id" bson:"disaster_id"`
+	AssigneeType  string               `json:"assignee_type" bson:"assignee_type"`
+	AssigneeID    string               `json:"assignee_id" bson:"assignee_id"`
+	AssigneeName  string               `json:"assignee_name" bson:"assignee_name"`
+	AssigneeOrgID string               `json:"assignee_org_id,omitempty" bson:"assignee_org_id,omitempty"` // organization of a team assignee
+	Status        string               `json:"status" bson:"status"`
+	Active        bool                 `json:"active" bson:"active"` // false once released
+	Notes         string               `json:"notes,omitempty" bson:"notes,omitempty"`
+	AssignedBy    string               `json:"assigned_by" bson:"assigned_by"`
+	History       []AssignmentProgress `json:"history" bson:"history"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+// AssignmentProgress is a timestamped status change of an assignment.
+type AssignmentProgress struct {
+	Status    string    `json:"status" bson:"status"`
+	ChangedBy string    `json:"changed_by" bson:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" bson:"changed_at"`
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Volunteer skills, the controlled vocabulary profiles are tagged and searched with.
+const (
+	SkillFirstAid      = "first_aid"
+	SkillMedical       = "medical" // doctors, nurses and paramedics
+	SkillSearchRescue  = "search_rescue"
+	SkillWaterRescue   = "water_rescue"
+	SkillFirefighting  = "firefighting"
+	SkillCounseling    = "counseling"
+	SkillConstruction  = "construction"
+	SkillLogistics     = "logistics"
+	SkillCooking       = "cooking"
+	SkillRadio         = "radio" // amateur radio and emergency communications
+	SkillTranslation   = "translation"
+	SkillChildCare     = "child_care"
+	SkillAnimalRescue  = "animal_rescue"
+	SkillHeavyMachines = "heavy_machinery"
+)
+
+// VolunteerSkills lists all known volunteer skills.
+var VolunteerSkills = []string{
+	SkillFirstAid, SkillMedical, SkillSearchRescue, SkillWaterRescue, SkillFirefighting, SkillCounseling,
+	SkillConstruction, SkillLogistics, SkillCooking, SkillRadio, SkillTranslation, SkillChildCare,
+	SkillAnimalRescue, SkillHeavyMachines,
+}
+
+// Vehicles volunteers can bring along.
+const (
+	VehicleCar            = "car"
+	VehicleMotorcycle     = "motorcycle"
+	VehicleFourWheelDrive = "four_wheel_drive"
+	VehicleTruck          = "truck"
+	VehicleBoat           = "boat"
+)
+
+// Vehicles lists all known vehicle kinds.
+var Vehicles = []string{VehicleCar, VehicleMotorcycle, VehicleFourWheelDrive, VehicleTruck, VehicleBoat}
+
+// VolunteerProfile describes what a volunteer can help with, when, and where from.
+type VolunteerProfile struct {
+	UserID       bson.ObjectID        `json:"user_id" bson:"_id"`
+	Skills       []string             `json:"skills" bson:"skills"`
+	Languages    []string             `json:"languages" bson:"languages"` // ISO 639 codes, e.g., en or hi
+	Vehicles     []string             `json:"vehicles" bson:"vehicles"`
+	Availability []AvailabilityWindow `json:"availability" bson:"availability"`
+	TimeZone     string               `json:"time_zone" bson:"time_zone"` // IANA time zone of the availability windows, e.g., Asia/Kolkata
+	Home         *Location            `json:"home,omitempty" bson:"home,omitempty"`
+	UpdatedAt    time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+// AvailabilityWindow is a weekly recurring time range a volunteer can be dispatched in, in the profile's time zone.
+// Windows past midnight are split in two.
+type AvailabilityWindow struct {
+	Day   time.Weekday `json:"day" bson:"day"`
+	Start int          `json:"start" bson:"start"` // minutes after midnight
+	End   int          `json:"end" bson:"end"`     // minutes after midnight, exclusive
+}
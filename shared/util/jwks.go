@@ -0,0 +1,103 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// JSONWebKey is the public part of a token signing key as a JSON Web Key (RFC 7517).
+type JSONWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"` // OKP keys
+	X   string `json:"x,omitempty"`   // OKP keys
+	N   string `json:"n,omitempty"`   // RSA keys
+	E   string `json:"e,omitempty"`   // RSA keys
+}
+
+// JSONWebKeySet is a set of JSON Web Keys, as served from /.well-known/jwks.json.
+type JSONWebKeySet struct {
+	Keys []*JSONWebKey `json:"keys"`
+}
+
+// NewJSONWebKey encodes an Ed25519 or RSA public key as a JSON Web Key.
+func NewJSONWebKey(kid string, key crypto.PublicKey) (*JSONWebKey, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return &JSONWebKey{
+			Kid: kid,
+			Kty: "OKP",
+			Alg: "EdDSA",
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	case *rsa.PublicKey:
+		return &JSONWebKey{
+			Kid: kid,
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// PublicKey decodes the public key of a JSON Web Key.
+func (k *JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("key %s: unsupported curve %s", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("key %s: invalid Ed25519 key size", k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %s: invalid RSA exponent", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %s", k.Kid, k.Kty)
+	}
+}
+
+// JSONWebKeys encodes the public keys of a key ring as a JSON Web Key Set.
+func (k *KeyRing) JSONWebKeys() (*JSONWebKeySet, error) {
+	set := &JSONWebKeySet{Keys: make([]*JSONWebKey, 0, len(k.keys))}
+	for kid, key := range k.PublicKeys() {
+		jwk, err := NewJSONWebKey(kid, key)
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	slices.SortFunc(set.Keys, func(a, b *JSONWebKey) int { return strings.Compare(a.Kid, b.Kid) })
+	return set, nil
+}
@@ -1,18 +1,29 @@
 package util
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"slices"
 	"time"
 
+	"github.com/cprakhar/relief-ops/shared/types"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // Claims represents the JWT claims with custom user claims.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID        string `json:"user_id"`
+	Email         string `json:"email"`
+	Role          string `json:"role"`
+	Generation    int64  `json:"gen,omitempty"` // user's token generation at issue time, see UserDetails
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	MFA           bool   `json:"mfa,omitempty"` // the sign in was verified with a second factor
+
+	// Regions the role is scoped to; omitted when the role applies everywhere
+	Regions []types.Region `json:"regions,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -21,6 +32,15 @@ type UserDetails struct {
 	UserID string
 	Email  string
 	Role   string
+
+	// Generation is the user's current token generation. Bumping it invalidates every token issued before.
+	Generation int64
+
+	EmailVerified bool
+	Regions       []types.Region
+
+	// MFA is set when the user signed in with a second factor.
+	MFA bool
 }
 
 var (
@@ -29,13 +49,23 @@ var (
 	Sub = "user-authentication"
 )
 
-// GenerateToken creates a JWT token for the given user details.
-func GenerateToken(user *UserDetails, secretKey string, expiry time.Duration) (string, error) {
+// KeyResolver looks up the public key a token was signed with by its key ID (kid).
+type KeyResolver interface {
+	PublicKey(kid string) (crypto.PublicKey, error)
+}
+
+// GenerateToken creates a JWT token for the given user details, signed with the active key of the key ring.
+func GenerateToken(user *UserDetails, keys *KeyRing, expiry time.Duration) (string, error) {
 	claims := &Claims{
-		UserID: user.UserID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:        user.UserID,
+		Email:         user.Email,
+		Role:          user.Role,
+		Generation:    user.Generation,
+		EmailVerified: user.EmailVerified,
+		Regions:       user.Regions,
+		MFA:           user.MFA,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        rand.Text(), // jti, identifies the token for revocation
 			Issuer:    Iss,
 			Audience:  []string{"relief-ops-users"},
 			Subject:   Sub,
@@ -45,18 +75,20 @@ func GenerateToken(user *UserDetails, secretKey string, expiry time.Duration) (s
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secretKey))
+	kid, key := keys.SigningKey()
+	method, err := signingMethod(key.Public())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	return token.SignedString(key)
 }
 
-// ParseToken validates the JWT token and returns the claims if valid.
-func ParseToken(tokenStr, secretKey string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secretKey), nil
-	})
+// ParseToken validates the JWT token against the public key named by its kid header and returns the claims if valid.
+func ParseToken(tokenStr string, keys KeyResolver) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, keyFunc(keys))
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +105,43 @@ func ParseToken(tokenStr, secretKey string) (*Claims, error) {
 	return claims, nil
 }
 
+// keyFunc resolves the verification key of a token from its kid header.
+func keyFunc(keys KeyResolver) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("token has no key ID")
+		}
+
+		key, err := keys.PublicKey(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		// The algorithm must match the key, so an RSA key can never be used to verify e.g. an HMAC
+		method, err := signingMethod(key)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key, nil
+	}
+}
+
+// signingMethod returns the signing method used with a public key: EdDSA for Ed25519 and RS256 for RSA keys.
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
 // validateClaims checks the standard claims of the token.
 func validateClaims(claims *Claims) error {
 	if claims.ExpiresAt.Time.Before(time.Now()) {
@@ -93,3 +162,57 @@ func validateClaims(claims *Claims) error {
 	}
 	return nil
 }
+
+// ActionClaims are the claims of a token authorizing a single account action, e.g., confirming an email
+// address. The action is the token's audience, so a token issued for one action is rejected by the others.
+type ActionClaims struct {
+	jwt.RegisteredClaims
+}
+
+// GenerateActionToken creates a signed token authorizing an action for a user. It returns the token and its
+// ID (jti), which the issuer tracks to make the token single use.
+func GenerateActionToken(action, userID string, keys *KeyRing, expiry time.Duration) (string, string, error) {
+	claims := &ActionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        rand.Text(),
+			Issuer:    Iss,
+			Audience:  []string{action},
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
+		},
+	}
+
+	kid, key := keys.SigningKey()
+	method, err := signingMethod(key.Public())
+	if err != nil {
+		return "", "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", "", err
+	}
+	return signed, claims.ID, nil
+}
+
+// ParseActionToken validates a token issued for the given action and returns its claims.
+func ParseActionToken(tokenStr, action string, keys KeyResolver) (*ActionClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &ActionClaims{}, keyFunc(keys),
+		jwt.WithIssuer(Iss),
+		jwt.WithAudience(action),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*ActionClaims)
+	if !(ok && token.Valid) || claims.ID == "" || claims.Subject == "" {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	return claims, nil
+}
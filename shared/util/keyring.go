@@ -0,0 +1,149 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// minRSABits is the smallest accepted RSA signing key size.
+const minRSABits = 2048
+
+// KeyRing holds the private keys used to sign tokens, by key ID (kid). The active key signs new tokens;
+// the others stay published for verification until the tokens they signed have expired, so keys can be
+// rotated with overlap without logging anyone out.
+type KeyRing struct {
+	active string
+	keys   map[string]crypto.Signer
+}
+
+// NewKeyRing creates a key ring from Ed25519 or RSA private keys. An empty activeID selects the last
+// key ID in lexical order, so date-named keys rotate by adding a newer key.
+func NewKeyRing(activeID string, keys map[string]crypto.Signer) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key ring has no keys")
+	}
+
+	for kid, key := range keys {
+		if err := checkSigningKey(key); err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+	}
+
+	if activeID == "" {
+		ids := make([]string, 0, len(keys))
+		for kid := range keys {
+			ids = append(ids, kid)
+		}
+		activeID = slices.Max(ids)
+	}
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key %s is not in the key ring", activeID)
+	}
+
+	return &KeyRing{active: activeID, keys: keys}, nil
+}
+
+// LoadKeyRing loads PEM-encoded private keys (PKCS#8, or PKCS#1 for RSA) from the *.pem files in dir.
+// Each file name without its extension is the key ID.
+func LoadKeyRing(dir, activeID string) (*KeyRing, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.Signer, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		keys[strings.TrimSuffix(filepath.Base(file), ".pem")] = key
+	}
+
+	return NewKeyRing(activeID, keys)
+}
+
+// GenerateKeyRing creates a key ring with a single random Ed25519 key. Tokens it signs cannot be verified
+// once the process exits, so it is only meant for development.
+func GenerateKeyRing() (*KeyRing, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyRing("", map[string]crypto.Signer{"dev-" + rand.Text()[:8]: key})
+}
+
+// SigningKey returns the active key and its ID.
+func (k *KeyRing) SigningKey() (string, crypto.Signer) {
+	return k.active, k.keys[k.active]
+}
+
+// PublicKey returns the public key with the given ID.
+func (k *KeyRing) PublicKey(kid string) (crypto.PublicKey, error) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return key.Public(), nil
+}
+
+// PublicKeys returns every public key of the key ring by ID.
+func (k *KeyRing) PublicKeys() map[string]crypto.PublicKey {
+	keys := make(map[string]crypto.PublicKey, len(k.keys))
+	for kid, key := range k.keys {
+		keys[kid] = key.Public()
+	}
+	return keys
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func checkSigningKey(key crypto.Signer) error {
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		return nil
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSABits {
+			return fmt.Errorf("RSA key must have at least %d bits", minRSABits)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T, expected Ed25519 or RSA", key)
+	}
+}